@@ -15,17 +15,75 @@
 package cacheadapters
 
 import (
+	"context"
+	"math"
 	"time"
 )
 
 // TTLExpired represents the zero-value of a time expiration.
 const TTLExpired time.Duration = 0
 
+// TTLInfinite is returned by TTL operations on keys which are
+// present in the cache without an expiration.
+const TTLInfinite time.Duration = math.MaxInt64
+
+// BatchResult contains the outcome of a batch operation for each of
+// the keys involved.
+//
+// A nil error means the operation succeeded for that key, while
+// ErrNotFound marks a miss in GetMany operations.
+type BatchResult map[string]error
+
+// Version is an opaque token identifying the value stored in a key
+// at a given moment, obtained with GetWithVersion and checked by
+// CompareAndSwap operations.
+//
+// Versions are specific to the adapter which generated them.
+type Version string
+
+// KeyIterator iterates over the keys found by a Scan operation.
+//
+// Example:
+//
+//	iterator, err := adapter.Scan("a:prefix:")
+//	if err != nil {
+//	    // handle the error
+//	}
+//
+//	defer iterator.Close()
+//
+//	for iterator.Next() {
+//	    key := iterator.Key()
+//	    // use the key
+//	}
+//
+//	if err := iterator.Err(); err != nil {
+//	    // handle the error
+//	}
+type KeyIterator interface {
+	// Next advances the iterator to the next key, returns false
+	// when there are no more keys or an error occurred.
+	Next() bool
+
+	// Key returns the current key of the iterator.
+	Key() string
+
+	// Err returns the error occurred during the iteration, if any.
+	Err() error
+
+	// Close releases the resources held by the iterator.
+	Close() error
+}
+
 // CacheAdapter represents a Cache Mechanism abstraction.
 type CacheAdapter interface {
 	// OpenSession opens a new Cache Session.
 	OpenSession() (CacheSessionAdapter, error)
 
+	// OpenSessionContext opens a new Cache Session, honoring the
+	// cancellation and the deadline of the given context.
+	OpenSessionContext(ctx context.Context) (CacheSessionAdapter, error)
+
 	cacheOperator
 }
 
@@ -37,6 +95,26 @@ type CacheSessionAdapter interface {
 	cacheOperator
 }
 
+// AdapterAsSession allows to use a CacheAdapter where a
+// CacheSessionAdapter is expected, e.g. to share the implementation
+// of a wrapper between adapters and sessions.
+//
+// Closing the returned session does nothing, since
+// the adapter has no session to close.
+func AdapterAsSession(adapter CacheAdapter) CacheSessionAdapter {
+	return adapterSession{adapter}
+}
+
+// adapterSession is the CacheSessionAdapter returned by AdapterAsSession.
+type adapterSession struct {
+	CacheAdapter
+}
+
+// Close does nothing, the wrapped adapter has no session to close.
+func (as adapterSession) Close() error {
+	return nil
+}
+
 // cacheOperator is an intermediary interface to share methods between CacheAdapter and CacheSessionAdapter
 type cacheOperator interface {
 	// Get obtains a value from the cache using a key, then tries to unmarshal
@@ -53,4 +131,177 @@ type cacheOperator interface {
 
 	// Delete deletes a key from the cache.
 	Delete(key string) error
+
+	// GetContext is the same as Get, but honors the cancellation
+	// and the deadline of the given context.
+	GetContext(ctx context.Context, key string, objectRef interface{}) error
+
+	// SetContext is the same as Set, but honors the cancellation
+	// and the deadline of the given context.
+	SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error
+
+	// SetTTLContext is the same as SetTTL, but honors the cancellation
+	// and the deadline of the given context.
+	SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error
+
+	// DeleteContext is the same as Delete, but honors the cancellation
+	// and the deadline of the given context.
+	DeleteContext(ctx context.Context, key string) error
+
+	// GetMany obtains multiple values from the cache at once, then tries
+	// to unmarshal each of them into the object reference mapped to its key.
+	//
+	// The returned BatchResult contains the outcome for every key, while the
+	// error is non-nil only if the whole operation failed.
+	GetMany(objectRefs map[string]interface{}) (BatchResult, error)
+
+	// SetMany sets multiple values into the cache at once, each one with
+	// the key it is mapped to and all of them with the same TTL.
+	//
+	// The returned BatchResult contains the outcome for every key, while the
+	// error is non-nil only if the whole operation failed.
+	SetMany(objects map[string]interface{}, TTL *time.Duration) (BatchResult, error)
+
+	// DeleteMany deletes multiple keys from the cache at once.
+	//
+	// The returned BatchResult contains the outcome for every key, while the
+	// error is non-nil only if the whole operation failed.
+	DeleteMany(keys []string) (BatchResult, error)
+
+	// GetManyContext is the same as GetMany, but honors the cancellation
+	// and the deadline of the given context.
+	GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (BatchResult, error)
+
+	// SetManyContext is the same as SetMany, but honors the cancellation
+	// and the deadline of the given context.
+	SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (BatchResult, error)
+
+	// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+	// and the deadline of the given context.
+	DeleteManyContext(ctx context.Context, keys []string) (BatchResult, error)
+
+	// Exists checks if a key is present in the cache and not expired,
+	// without obtaining its value.
+	Exists(key string) (bool, error)
+
+	// TTL obtains the remaining time to live of a key, returns
+	// ErrNotFound if the key is not present in the cache or expired.
+	TTL(key string) (time.Duration, error)
+
+	// ExistsContext is the same as Exists, but honors the cancellation
+	// and the deadline of the given context.
+	ExistsContext(ctx context.Context, key string) (bool, error)
+
+	// TTLContext is the same as TTL, but honors the cancellation
+	// and the deadline of the given context.
+	TTLContext(ctx context.Context, key string) (time.Duration, error)
+
+	// Increment atomically adds delta to the integer counter stored
+	// in the key, returning the new value.
+	//
+	// If the key is not present or expired, the counter starts from
+	// zero and the given TTL (or the default one if nil) is applied,
+	// otherwise the TTL of the counter is left untouched.
+	// Returns ErrInvalidCounter if the key contains a non-integer value,
+	// in which case the value is left untouched.
+	//
+	// Integers written by the Set operations can be incremented by the
+	// Redis and the InMemory adapters, while the MongoDB adapter
+	// increments only the counters created by Increment and Decrement.
+	Increment(key string, delta int64, TTL *time.Duration) (int64, error)
+
+	// Decrement atomically subtracts delta from the integer counter
+	// stored in the key, returning the new value.
+	//
+	// It follows the same rules of Increment.
+	Decrement(key string, delta int64, TTL *time.Duration) (int64, error)
+
+	// IncrementContext is the same as Increment, but honors the cancellation
+	// and the deadline of the given context.
+	IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error)
+
+	// DecrementContext is the same as Decrement, but honors the cancellation
+	// and the deadline of the given context.
+	DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error)
+
+	// SetIfAbsent sets a value represented by the object parameter into
+	// the cache, with the specified key, only if the key is not present
+	// or expired. Returns whether the value has been written.
+	SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error)
+
+	// SetIfPresent sets a value represented by the object parameter into
+	// the cache, with the specified key, only if the key is present and
+	// not expired. Returns whether the value has been written.
+	SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error)
+
+	// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+	// and the deadline of the given context.
+	SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error)
+
+	// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+	// and the deadline of the given context.
+	SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error)
+
+	// GetWithVersion is the same as Get, but also returns the
+	// version of the value, to be used in CompareAndSwap operations.
+	GetWithVersion(key string, objectRef interface{}) (Version, error)
+
+	// CompareAndSwap sets a value represented by the newObject parameter
+	// into the cache, with the specified key, only if the value stored
+	// still has the given version, otherwise returns ErrVersionConflict.
+	CompareAndSwap(key string, version Version, newObject interface{}, TTL *time.Duration) error
+
+	// GetWithVersionContext is the same as GetWithVersion, but honors the
+	// cancellation and the deadline of the given context.
+	GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (Version, error)
+
+	// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+	// cancellation and the deadline of the given context.
+	CompareAndSwapContext(ctx context.Context, key string, version Version, newObject interface{}, TTL *time.Duration) error
+
+	// SetWithTags is the same as Set, but also attaches the given tags
+	// to the entry, so that it can be removed using InvalidateTag.
+	SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error
+
+	// InvalidateTag deletes all the entries carrying the given tag.
+	//
+	// Adapters may also delete keys which carried the tag in the past
+	// and have been overwritten since, but never leave in the cache an
+	// entry which is carrying the tag.
+	InvalidateTag(tag string) error
+
+	// SetWithTagsContext is the same as SetWithTags, but honors the
+	// cancellation and the deadline of the given context.
+	SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error
+
+	// InvalidateTagContext is the same as InvalidateTag, but honors the
+	// cancellation and the deadline of the given context.
+	InvalidateTagContext(ctx context.Context, tag string) error
+
+	// Scan returns an iterator over the keys of the cache starting
+	// with the given prefix, or over all the keys if it is empty.
+	//
+	// Keys are fetched while iterating, so keys written or deleted in
+	// the meantime may or may not be returned, and some adapters may
+	// return the same key more than once. Expired keys are skipped.
+	Scan(prefix string) (KeyIterator, error)
+
+	// ScanContext is the same as Scan, but honors the cancellation
+	// and the deadline of the given context, for the whole iteration.
+	ScanContext(ctx context.Context, prefix string) (KeyIterator, error)
+
+	// Clear deletes all the entries of the cache.
+	Clear() error
+
+	// ClearPrefix deletes all the entries whose key starts with the
+	// given prefix, or all the entries of the cache if it is empty.
+	ClearPrefix(prefix string) error
+
+	// ClearContext is the same as Clear, but honors the cancellation
+	// and the deadline of the given context.
+	ClearContext(ctx context.Context) error
+
+	// ClearPrefixContext is the same as ClearPrefix, but honors the
+	// cancellation and the deadline of the given context.
+	ClearPrefixContext(ctx context.Context, prefix string) error
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestCacheAdapterSuite(t *testing.T) {
+	suite.Run(t, new(CacheAdapterTestSuite))
+}
+
+// CacheAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type CacheAdapterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CacheAdapterTestSuite) TestAdapterAsSession() {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid New")
+
+	session := cacheadapters.AdapterAsSession(adapter)
+
+	err = session.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+	suite.Require().NoError(session.Close(), "Should not error on Close")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should write through the adapter")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec encodes the values stored in the cache and decodes them back.
+//
+// The adapters use the codec for every value written with the Set
+// operations and read with the Get operations, while counters created
+// with Increment and Decrement are always stored as plain integers.
+type Codec interface {
+	// Marshal encodes the object into its stored representation.
+	Marshal(object interface{}) ([]byte, error)
+
+	// Unmarshal decodes the stored representation into
+	// the object reference passed as parameter.
+	Unmarshal(content []byte, objectRef interface{}) error
+}
+
+// JSONCodec is the Codec using encoding/json, which is the default
+// of the adapters storing values as bytes.
+type JSONCodec struct{}
+
+// Marshal encodes the object as JSON.
+func (JSONCodec) Marshal(object interface{}) ([]byte, error) {
+	return json.Marshal(object)
+}
+
+// Unmarshal decodes the JSON content into the object reference.
+func (JSONCodec) Unmarshal(content []byte, objectRef interface{}) error {
+	return json.Unmarshal(content, objectRef)
+}
+
+// GobCodec is the Codec using encoding/gob.
+//
+// Remember to register with gob.Register the concrete
+// types stored in interface fields of the cached values.
+type GobCodec struct{}
+
+// Marshal encodes the object as gob.
+func (GobCodec) Marshal(object interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	err := gob.NewEncoder(&buffer).Encode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Unmarshal decodes the gob content into the object reference.
+func (GobCodec) Unmarshal(content []byte, objectRef interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(content)).Decode(objectRef)
+}
+
+// RawMessage is a value already encoded by the Codec of an adapter.
+//
+// Adapters store a RawMessage as it is in the Set operations, and fill
+// a *RawMessage with the stored content, without decoding it, in the
+// Get operations. This allows to move values between adapters using
+// the same Codec without decoding and encoding them again.
+type RawMessage []byte
+
+// MarshalJSON returns the content of the RawMessage, so that
+// a RawMessage is stored as it is by the JSONCodec as well.
+func (rm RawMessage) MarshalJSON() ([]byte, error) {
+	if rm == nil {
+		return []byte("null"), nil
+	}
+
+	return rm, nil
+}
+
+// UnmarshalJSON sets the content of the RawMessage, so that
+// a RawMessage is filled as it is by the JSONCodec as well.
+func (rm *RawMessage) UnmarshalJSON(content []byte) error {
+	*rm = append((*rm)[0:0], content...)
+	return nil
+}
+
+// Marshal encodes the object using the codec,
+// unless it is an already encoded RawMessage.
+func Marshal(codec Codec, object interface{}) ([]byte, error) {
+	switch raw := object.(type) {
+	case RawMessage:
+		return raw, nil
+	case *RawMessage:
+		if raw != nil {
+			return *raw, nil
+		}
+	}
+
+	return codec.Marshal(object)
+}
+
+// Unmarshal decodes the content into the object reference using
+// the codec, unless the reference is a *RawMessage, which is filled
+// with the content as it is.
+func Unmarshal(codec Codec, content []byte, objectRef interface{}) error {
+	if raw, ok := objectRef.(*RawMessage); ok && raw != nil {
+		*raw = append((*raw)[0:0], content...)
+		return nil
+	}
+
+	return codec.Unmarshal(content, objectRef)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestCodecSuite(t *testing.T) {
+	suite.Run(t, new(CodecTestSuite))
+}
+
+// CodecTestSuite contains all methods to run tests in a
+// isolated suite.
+type CodecTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CodecTestSuite) TestJSONCodec_OK() {
+	codec := cacheadapters.JSONCodec{}
+
+	content, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+	suite.Require().JSONEq(string(testutil.TestValueJSON), string(content), "Should encode the value as JSON")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal(content, &actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().Equal(testutil.TestValue, actual, "Should decode the same value")
+}
+
+func (suite *CodecTestSuite) TestJSONCodec_Invalid() {
+	codec := cacheadapters.JSONCodec{}
+
+	_, err := codec.Marshal(make(chan int))
+	suite.Require().Error(err, "Should error on a value which cannot be encoded")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal([]byte("not json"), &actual)
+	suite.Require().Error(err, "Should error on invalid content")
+}
+
+func (suite *CodecTestSuite) TestGobCodec_OK() {
+	codec := cacheadapters.GobCodec{}
+
+	content, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal(content, &actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().Equal(testutil.TestValue, actual, "Should decode the same value")
+}
+
+func (suite *CodecTestSuite) TestGobCodec_Invalid() {
+	codec := cacheadapters.GobCodec{}
+
+	_, err := codec.Marshal(make(chan int))
+	suite.Require().Error(err, "Should error on a value which cannot be encoded")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal(testutil.TestValueJSON, &actual)
+	suite.Require().Error(err, "Should error on invalid content")
+}
+
+func (suite *CodecTestSuite) TestMarshal_RawMessage() {
+	codec := cacheadapters.GobCodec{}
+
+	content, err := cacheadapters.Marshal(codec, testutil.TestValueRaw)
+	suite.Require().NoError(err, "Should not error on RawMessage")
+	suite.Require().Equal([]byte(testutil.TestValueRaw), content, "Should not encode a RawMessage")
+
+	content, err = cacheadapters.Marshal(codec, &testutil.TestValueRaw)
+	suite.Require().NoError(err, "Should not error on RawMessage reference")
+	suite.Require().Equal([]byte(testutil.TestValueRaw), content, "Should not encode a RawMessage reference")
+
+	expected, _ := codec.Marshal(testutil.TestValue)
+	content, err = cacheadapters.Marshal(codec, testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+	suite.Require().Equal(expected, content, "Should encode other values with the codec")
+}
+
+func (suite *CodecTestSuite) TestUnmarshal_RawMessage() {
+	codec := cacheadapters.GobCodec{}
+
+	var raw cacheadapters.RawMessage
+	err := cacheadapters.Unmarshal(codec, testutil.TestValueJSON, &raw)
+	suite.Require().NoError(err, "Should not error on RawMessage reference")
+	suite.Require().Equal(testutil.TestValueRaw, raw, "Should fill a RawMessage with the content")
+
+	content, _ := codec.Marshal(testutil.TestValue)
+	var actual testutil.TestStruct
+	err = cacheadapters.Unmarshal(codec, content, &actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().Equal(testutil.TestValue, actual, "Should decode other values with the codec")
+}
+
+func (suite *CodecTestSuite) TestRawMessage_JSONCodec() {
+	codec := cacheadapters.JSONCodec{}
+
+	content, err := codec.Marshal(testutil.TestValueRaw)
+	suite.Require().NoError(err, "Should not error on RawMessage")
+	suite.Require().Equal([]byte(testutil.TestValueRaw), content, "Should encode a RawMessage as it is")
+
+	content, err = codec.Marshal(cacheadapters.RawMessage(nil))
+	suite.Require().NoError(err, "Should not error on nil RawMessage")
+	suite.Require().Equal([]byte("null"), content, "Should encode a nil RawMessage as null")
+
+	var raw cacheadapters.RawMessage
+	err = codec.Unmarshal(testutil.TestValueJSON, &raw)
+	suite.Require().NoError(err, "Should not error on RawMessage reference")
+	suite.Require().Equal(testutil.TestValueRaw, raw, "Should decode a RawMessage as it is")
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressioncodec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Algorithm identifies the algorithm used to compress a value.
+//
+// The values of the constants are written in the header of the
+// compressed values, so they must never change.
+type Algorithm byte
+
+const (
+	None   Algorithm = iota // The value is not compressed.
+	Gzip                    // The value is compressed with gzip.
+	Snappy                  // The value is compressed with snappy.
+	Zstd                    // The value is compressed with zstd.
+)
+
+var (
+	zstdEncoder     *zstd.Encoder // The encoder shared by all the codecs, safe for concurrent use.
+	zstdDecoder     *zstd.Decoder // The decoder shared by all the codecs, safe for concurrent use.
+	zstdEncoderOnce sync.Once
+	zstdDecoderOnce sync.Once
+)
+
+// String returns the name of the algorithm.
+func (algorithm Algorithm) String() string {
+	switch algorithm {
+	case None:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Snappy:
+		return "snappy"
+	case Zstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// valid checks if the algorithm is supported.
+func (algorithm Algorithm) valid() bool {
+	return algorithm <= Zstd
+}
+
+// compress compresses the content with the algorithm.
+func (algorithm Algorithm) compress(content []byte) ([]byte, error) {
+	switch algorithm {
+	case None:
+		return content, nil
+	case Gzip:
+		var buffer bytes.Buffer
+
+		writer := gzip.NewWriter(&buffer)
+		_, err := writer.Write(content)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writer.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	case Snappy:
+		return snappy.Encode(nil, content), nil
+	case Zstd:
+		zstdEncoderOnce.Do(func() {
+			// cannot fail without options.
+			zstdEncoder, _ = zstd.NewWriter(nil)
+		})
+
+		return zstdEncoder.EncodeAll(content, nil), nil
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+}
+
+// decompress decompresses the content compressed with the algorithm.
+func (algorithm Algorithm) decompress(content []byte) ([]byte, error) {
+	switch algorithm {
+	case None:
+		return content, nil
+	case Gzip:
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return ioutil.ReadAll(reader)
+	case Snappy:
+		return snappy.Decode(nil, content)
+	case Zstd:
+		zstdDecoderOnce.Do(func() {
+			// cannot fail without options.
+			zstdDecoder, _ = zstd.NewReader(nil)
+		})
+
+		return zstdDecoder.DecodeAll(content, nil)
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+}
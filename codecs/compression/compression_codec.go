@@ -0,0 +1,122 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressioncodec
+
+import (
+	"bytes"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// headerMagic starts the header of the values written by the Codec.
+//
+// No JSON, MessagePack or Protocol Buffers content starts with these
+// bytes, so the values written without the header (the values smaller
+// than the threshold, or written without the Codec) are recognized
+// and decoded as they are.
+var headerMagic = []byte{0x00, 'c', 'z'}
+
+// headerSize is the size of the header: the magic bytes
+// followed by the byte of the compression algorithm.
+var headerSize = len(headerMagic) + 1
+
+// Codec is a cacheadapters.Codec which compresses the values
+// encoded by an inner codec, when they are bigger than a threshold.
+//
+// Compressed values start with a header telling the algorithm used,
+// so values compressed with different algorithms, and uncompressed
+// values, can be stored together and are all read back transparently.
+type Codec struct {
+	inner     cacheadapters.Codec // The codec encoding the values before the compression.
+	algorithm Algorithm           // The algorithm used to compress the values.
+	threshold int                 // The size starting from which the values are compressed.
+}
+
+// New creates a new compression Codec wrapping the inner
+// codec, configured with the given options.
+func New(inner cacheadapters.Codec, opts ...Option) (*Codec, error) {
+	if inner == nil {
+		return nil, cacheadapters.ErrNilCodec
+	}
+
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Codec{
+		inner:     inner,
+		algorithm: config.algorithm,
+		threshold: config.threshold,
+	}, nil
+}
+
+// Marshal encodes the object with the inner codec, then compresses
+// the content if its size reaches the threshold.
+//
+// The content is stored uncompressed if the compression
+// does not make it smaller.
+func (c *Codec) Marshal(object interface{}) ([]byte, error) {
+	content, err := c.inner.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.algorithm != None && len(content) >= c.threshold {
+		compressed, err := c.algorithm.compress(content)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(compressed)+headerSize < len(content) {
+			return withHeader(c.algorithm, compressed), nil
+		}
+	}
+
+	if bytes.HasPrefix(content, headerMagic) {
+		// the content would be mistaken for a compressed one.
+		return withHeader(None, content), nil
+	}
+
+	return content, nil
+}
+
+// Unmarshal decompresses the content, if it starts with the header,
+// then decodes it into the object reference with the inner codec.
+func (c *Codec) Unmarshal(content []byte, objectRef interface{}) error {
+	if len(content) >= headerSize && bytes.HasPrefix(content, headerMagic) {
+		algorithm := Algorithm(content[len(headerMagic)])
+		if !algorithm.valid() {
+			return ErrUnknownAlgorithm
+		}
+
+		var err error
+		content, err = algorithm.decompress(content[headerSize:])
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.inner.Unmarshal(content, objectRef)
+}
+
+// withHeader prepends the header of the algorithm to the content.
+func withHeader(algorithm Algorithm, content []byte) []byte {
+	result := make([]byte, 0, headerSize+len(content))
+	result = append(result, headerMagic...)
+	result = append(result, byte(algorithm))
+
+	return append(result, content...)
+}
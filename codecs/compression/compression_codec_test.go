@@ -0,0 +1,166 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressioncodec_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	compressioncodec "github.com/tryvium-travels/golang-cache-adapters/codecs/compression"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+var (
+	testAlgorithms = []compressioncodec.Algorithm{compressioncodec.Gzip, compressioncodec.Snappy, compressioncodec.Zstd}
+	testBigValue   = testutil.TestStruct{Value: strings.Repeat("a compressible value ", 1000)}
+)
+
+func TestCompressionCodecSuite(t *testing.T) {
+	suite.Run(t, new(CompressionCodecTestSuite))
+}
+
+type CompressionCodecTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CompressionCodecTestSuite) TestNew_NilCodec() {
+	codec, err := compressioncodec.New(nil)
+	suite.Require().Nil(codec, "Should be nil on nil inner codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil inner codec")
+}
+
+func (suite *CompressionCodecTestSuite) TestNew_InvalidOptions() {
+	codec, err := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithAlgorithm(compressioncodec.Algorithm(255)))
+	suite.Require().Nil(codec, "Should be nil on unknown algorithm")
+	suite.Require().ErrorIs(err, compressioncodec.ErrUnknownAlgorithm, "Should give error on unknown algorithm")
+
+	codec, err = compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithThreshold(-1))
+	suite.Require().Nil(codec, "Should be nil on negative threshold")
+	suite.Require().ErrorIs(err, compressioncodec.ErrNegativeThreshold, "Should give error on negative threshold")
+}
+
+func (suite *CompressionCodecTestSuite) TestMarshalUnmarshal_Compressed() {
+	plain, err := cacheadapters.JSONCodec{}.Marshal(testBigValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+
+	for _, algorithm := range testAlgorithms {
+		codec, err := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithAlgorithm(algorithm))
+		suite.Require().NoError(err, "Should not error on valid %s codec", algorithm)
+
+		content, err := codec.Marshal(testBigValue)
+		suite.Require().NoError(err, "Should not error on valid %s Marshal", algorithm)
+		suite.Require().Less(len(content), len(plain), "Should compress the value with %s", algorithm)
+
+		var actual testutil.TestStruct
+		err = codec.Unmarshal(content, &actual)
+		suite.Require().NoError(err, "Should not error on valid %s Unmarshal", algorithm)
+		suite.Require().Equal(testBigValue, actual, "Should decode the same value with %s", algorithm)
+	}
+}
+
+func (suite *CompressionCodecTestSuite) TestMarshal_BelowThreshold() {
+	codec, err := compressioncodec.New(cacheadapters.JSONCodec{})
+	suite.Require().NoError(err, "Should not error on valid codec")
+
+	content, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+	suite.Require().JSONEq(string(testutil.TestValueJSON), string(content), "Should not compress small values")
+}
+
+func (suite *CompressionCodecTestSuite) TestMarshal_Incompressible() {
+	codec, err := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithThreshold(0))
+	suite.Require().NoError(err, "Should not error on valid codec")
+
+	content, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+	suite.Require().JSONEq(string(testutil.TestValueJSON), string(content), "Should not compress values which do not get smaller")
+}
+
+func (suite *CompressionCodecTestSuite) TestUnmarshal_Mixed() {
+	contents := [][]byte{testutil.TestValueJSON}
+	for _, algorithm := range testAlgorithms {
+		codec, _ := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithAlgorithm(algorithm))
+
+		content, err := codec.Marshal(testBigValue)
+		suite.Require().NoError(err, "Should not error on valid %s Marshal", algorithm)
+
+		contents = append(contents, content)
+	}
+
+	codec, _ := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithAlgorithm(compressioncodec.None))
+	for _, content := range contents {
+		var actual testutil.TestStruct
+		err := codec.Unmarshal(content, &actual)
+		suite.Require().NoError(err, "Should read every stored value, whatever the algorithm")
+		suite.Require().NotEmpty(actual.Value, "Should decode the value")
+	}
+}
+
+func (suite *CompressionCodecTestSuite) TestMarshalUnmarshal_MagicPrefix() {
+	codec, err := compressioncodec.New(rawCodec{})
+	suite.Require().NoError(err, "Should not error on valid codec")
+
+	expected := []byte{0x00, 'c', 'z', 0x01, 0x02}
+	content, err := codec.Marshal(expected)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+
+	var actual []byte
+	err = codec.Unmarshal(content, &actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().Equal(expected, actual, "Should not mistake the value for a compressed one")
+}
+
+func (suite *CompressionCodecTestSuite) TestUnmarshal_Invalid() {
+	codec, _ := compressioncodec.New(cacheadapters.JSONCodec{})
+
+	var actual testutil.TestStruct
+	err := codec.Unmarshal([]byte{0x00, 'c', 'z', 0xff, 0x01}, &actual)
+	suite.Require().ErrorIs(err, compressioncodec.ErrUnknownAlgorithm, "Should error on unknown algorithm in the header")
+
+	for _, algorithm := range testAlgorithms {
+		err = codec.Unmarshal([]byte{0x00, 'c', 'z', byte(algorithm), 0x01}, &actual)
+		suite.Require().Error(err, "Should error on corrupted %s content", algorithm)
+	}
+}
+
+func (suite *CompressionCodecTestSuite) TestWithAdapter_OK() {
+	codec, _ := compressioncodec.New(cacheadapters.JSONCodec{}, compressioncodec.WithAlgorithm(compressioncodec.Snappy))
+
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, inmemorycacheadapters.WithCodec(codec))
+	suite.Require().NoError(err, "Should not error on valid codec")
+
+	err = adapter.Set(testutil.TestKeyForSet, testBigValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal(testBigValue, actual, "Should be equal to the value set")
+}
+
+// rawCodec is a codec storing byte slices as they are.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(object interface{}) ([]byte, error) {
+	return append([]byte(nil), object.([]byte)...), nil
+}
+
+func (rawCodec) Unmarshal(content []byte, objectRef interface{}) error {
+	*objectRef.(*[]byte) = append([]byte(nil), content...)
+	return nil
+}
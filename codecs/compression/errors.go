@@ -0,0 +1,25 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressioncodec
+
+import "fmt"
+
+var (
+	// ErrUnknownAlgorithm will come out if you try to use, or to decode
+	// a value compressed with, an unsupported compression algorithm.
+	ErrUnknownAlgorithm = fmt.Errorf("the compression algorithm is not supported")
+	// ErrNegativeThreshold will come out if you try to set a negative size threshold.
+	ErrNegativeThreshold = fmt.Errorf("cannot use a negative size threshold")
+)
@@ -0,0 +1,73 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressioncodec
+
+// DefaultThreshold is the size, in bytes, starting from
+// which the values are compressed if not set otherwise.
+const DefaultThreshold = 1024
+
+// Option configures a Codec when passed to New.
+type Option func(*settings) error
+
+// settings contains the configuration of a Codec.
+type settings struct {
+	algorithm Algorithm // The algorithm used to compress the values.
+	threshold int       // The size starting from which the values are compressed.
+}
+
+// newSettings creates the configuration of a Codec,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{
+		algorithm: Gzip,
+		threshold: DefaultThreshold,
+	}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithAlgorithm sets the algorithm used to compress the values,
+// Gzip is used if not set. None disables the compression, while
+// still reading the values compressed with any algorithm.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(s *settings) error {
+		if !algorithm.valid() {
+			return ErrUnknownAlgorithm
+		}
+
+		s.algorithm = algorithm
+		return nil
+	}
+}
+
+// WithThreshold sets the size, in bytes, of the encoded values starting
+// from which they are compressed, DefaultThreshold is used if not set.
+func WithThreshold(threshold int) Option {
+	return func(s *settings) error {
+		if threshold < 0 {
+			return ErrNegativeThreshold
+		}
+
+		s.threshold = threshold
+		return nil
+	}
+}
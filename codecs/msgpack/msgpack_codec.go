@@ -0,0 +1,35 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgpackcodec
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec is the cacheadapters.Codec using MessagePack.
+//
+// The fields of the structs are encoded using their names,
+// or the names set in the `msgpack` struct tags.
+type Codec struct{}
+
+// Marshal encodes the object as MessagePack.
+func (Codec) Marshal(object interface{}) ([]byte, error) {
+	return msgpack.Marshal(object)
+}
+
+// Unmarshal decodes the MessagePack content into the object reference.
+func (Codec) Unmarshal(content []byte, objectRef interface{}) error {
+	return msgpack.Unmarshal(content, objectRef)
+}
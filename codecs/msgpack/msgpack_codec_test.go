@@ -0,0 +1,56 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgpackcodec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	msgpackcodec "github.com/tryvium-travels/golang-cache-adapters/codecs/msgpack"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestMsgpackCodecSuite(t *testing.T) {
+	suite.Run(t, new(MsgpackCodecTestSuite))
+}
+
+// MsgpackCodecTestSuite contains all methods to run tests in a
+// isolated suite.
+type MsgpackCodecTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MsgpackCodecTestSuite) TestMarshalUnmarshal_OK() {
+	codec := msgpackcodec.Codec{}
+
+	content, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal(content, &actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().Equal(testutil.TestValue, actual, "Should decode the same value")
+}
+
+func (suite *MsgpackCodecTestSuite) TestMarshalUnmarshal_Invalid() {
+	codec := msgpackcodec.Codec{}
+
+	_, err := codec.Marshal(make(chan int))
+	suite.Require().Error(err, "Should error on a value which cannot be encoded")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal([]byte{0xc1}, &actual)
+	suite.Require().Error(err, "Should error on invalid content")
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobufcodec
+
+import "fmt"
+
+var (
+	// ErrNotProtoMessage will come out if you try to encode a value,
+	// or decode into an object reference, not implementing proto.Message.
+	ErrNotProtoMessage = fmt.Errorf("the protobuf codec only supports values implementing proto.Message")
+)
@@ -0,0 +1,44 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobufcodec
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec is the cacheadapters.Codec using Protocol Buffers.
+type Codec struct{}
+
+// Marshal encodes the object, which must implement proto.Message,
+// in the Protocol Buffers wire format.
+func (Codec) Marshal(object interface{}) ([]byte, error) {
+	message, ok := object.(proto.Message)
+	if !ok {
+		return nil, ErrNotProtoMessage
+	}
+
+	return proto.Marshal(message)
+}
+
+// Unmarshal decodes the Protocol Buffers content into the
+// object reference, which must implement proto.Message.
+func (Codec) Unmarshal(content []byte, objectRef interface{}) error {
+	message, ok := objectRef.(proto.Message)
+	if !ok {
+		return ErrNotProtoMessage
+	}
+
+	return proto.Unmarshal(content, message)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobufcodec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	protobufcodec "github.com/tryvium-travels/golang-cache-adapters/codecs/protobuf"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtobufCodecSuite(t *testing.T) {
+	suite.Run(t, new(ProtobufCodecTestSuite))
+}
+
+// ProtobufCodecTestSuite contains all methods to run tests in a
+// isolated suite.
+type ProtobufCodecTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ProtobufCodecTestSuite) TestMarshalUnmarshal_OK() {
+	codec := protobufcodec.Codec{}
+	expected := wrapperspb.String(testutil.TestValue.Value)
+
+	content, err := codec.Marshal(expected)
+	suite.Require().NoError(err, "Should not error on valid Marshal")
+
+	actual := &wrapperspb.StringValue{}
+	err = codec.Unmarshal(content, actual)
+	suite.Require().NoError(err, "Should not error on valid Unmarshal")
+	suite.Require().True(proto.Equal(expected, actual), "Should decode the same message")
+}
+
+func (suite *ProtobufCodecTestSuite) TestMarshalUnmarshal_NotProtoMessage() {
+	codec := protobufcodec.Codec{}
+
+	_, err := codec.Marshal(testutil.TestValue)
+	suite.Require().ErrorIs(err, protobufcodec.ErrNotProtoMessage, "Should error on a value which is not a message")
+
+	var actual testutil.TestStruct
+	err = codec.Unmarshal([]byte{}, &actual)
+	suite.Require().ErrorIs(err, protobufcodec.ErrNotProtoMessage, "Should error on an object reference which is not a message")
+}
+
+func (suite *ProtobufCodecTestSuite) TestUnmarshal_Invalid() {
+	codec := protobufcodec.Codec{}
+
+	err := codec.Unmarshal([]byte{0xff}, &wrapperspb.StringValue{})
+	suite.Require().Error(err, "Should error on invalid content")
+}
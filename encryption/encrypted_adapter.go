@@ -0,0 +1,80 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters
+
+import (
+	"context"
+	"reflect"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// EncryptedAdapter is a cache adapter which encrypts every value with
+// AES-GCM before passing it to the wrapped adapter, and decrypts it back
+// when read.
+//
+// Keys, tags, TTLs and integer counters are not encrypted.
+type EncryptedAdapter struct {
+	encryptedOperator
+	adapter cacheadapters.CacheAdapter // The wrapped adapter.
+}
+
+// New creates a new EncryptedAdapter which encrypts the values of
+// the given adapter with the keys of the keyring, configured with
+// the given options.
+//
+// The encrypted values are stored as raw bytes, so MongoDB
+// adapters must be created with a codec other than BSON
+// (e.g. WithCodec(cacheadapters.JSONCodec{})).
+func New(adapter cacheadapters.CacheAdapter, keyring *Keyring, opts ...Option) (*EncryptedAdapter, error) {
+	if value := reflect.ValueOf(adapter); adapter == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	if keyring == nil {
+		return nil, ErrNilKeyring
+	}
+
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedAdapter{
+		encryptedOperator: encryptedOperator{
+			inner:   cacheadapters.AdapterAsSession(adapter),
+			keyring: keyring,
+			codec:   config.codec,
+		},
+		adapter: adapter,
+	}, nil
+}
+
+// OpenSession opens a new Cache Session on the wrapped adapter,
+// using the same keyring.
+func (ea *EncryptedAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
+	return ea.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext is the same as OpenSession, but honors the
+// cancellation and the deadline of the given context.
+func (ea *EncryptedAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	session, err := ea.adapter.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSession(session, ea.keyring, WithCodec(ea.codec))
+}
@@ -0,0 +1,284 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	encryptioncacheadapters "github.com/tryvium-travels/golang-cache-adapters/encryption"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+var (
+	testOldKey = bytes.Repeat([]byte{1}, 32) // The key which is being rotated out.
+	testNewKey = bytes.Repeat([]byte{2}, 32) // The key which is being rotated in.
+)
+
+// EncryptedAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type EncryptedAdapterTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL time.Duration
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+func newTestKeyring() *encryptioncacheadapters.Keyring {
+	keyring, _ := encryptioncacheadapters.NewKeyring("old", map[string][]byte{"old": testOldKey})
+	return keyring
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		adapter, err := newTestAdapterFunc(defaultTTL)()
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter.OpenSession()
+	}
+}
+
+// newEncryptedTestSuite creates a new test suite with tests for
+// Encrypted adapters and sessions, backed by an In-Memory adapter.
+func newEncryptedTestSuite(defaultTTL time.Duration) *EncryptedAdapterTestSuite {
+	var suite suite.Suite
+
+	return &EncryptedAdapterTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestEncryptedAdapterSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newEncryptedTestSuite(defaultTTL))
+}
+
+func (suite *EncryptedAdapterTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+func (suite *EncryptedAdapterTestSuite) TestNew_NilAdapter() {
+	var nilAdapter *inmemorycacheadapters.InMemoryAdapter
+
+	adapter, err := encryptioncacheadapters.New(nil, newTestKeyring())
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+
+	adapter, err = encryptioncacheadapters.New(nilAdapter, newTestKeyring())
+	suite.Require().Nil(adapter, "Should be nil on typed nil adapter")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrNilAdapter, "Should give error on typed nil adapter")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestNew_InvalidOptions() {
+	adapter, err := encryptioncacheadapters.New(suite.newInMemoryAdapter(), nil)
+	suite.Require().Nil(adapter, "Should be nil on nil keyring")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrNilKeyring, "Should give error on nil keyring")
+
+	adapter, err = encryptioncacheadapters.New(suite.newInMemoryAdapter(), newTestKeyring(), encryptioncacheadapters.WithCodec(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestNewSession_Invalid() {
+	session, err := encryptioncacheadapters.NewSession(nil, newTestKeyring())
+	suite.Require().Nil(session, "Should be nil on nil session")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrNilAdapter, "Should give error on nil session")
+
+	innerSession, err := suite.newInMemoryAdapter().OpenSession()
+	suite.Require().NoError(err, "Should open the in-memory session")
+
+	session, err = encryptioncacheadapters.NewSession(innerSession, nil)
+	suite.Require().Nil(session, "Should be nil on nil keyring")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrNilKeyring, "Should give error on nil keyring")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestNewKeyring_Invalid() {
+	keyring, err := encryptioncacheadapters.NewKeyring("missing", map[string][]byte{"old": testOldKey})
+	suite.Require().Nil(keyring, "Should be nil on missing primary key")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrInvalidKeyID, "Should give error on missing primary key")
+
+	keyring, err = encryptioncacheadapters.NewKeyring("", map[string][]byte{"": testOldKey})
+	suite.Require().Nil(keyring, "Should be nil on empty key ID")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrInvalidKeyID, "Should give error on empty key ID")
+
+	longID := string(bytes.Repeat([]byte{'a'}, 256))
+	keyring, err = encryptioncacheadapters.NewKeyring(longID, map[string][]byte{longID: testOldKey})
+	suite.Require().Nil(keyring, "Should be nil on too long key ID")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrInvalidKeyID, "Should give error on too long key ID")
+
+	keyring, err = encryptioncacheadapters.NewKeyring("old", map[string][]byte{"old": []byte("too short")})
+	suite.Require().Nil(keyring, "Should be nil on invalid key")
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrInvalidKey, "Should give error on invalid key")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestSet_Encrypted() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var stored cacheadapters.RawMessage
+	err = inMemoryAdapter.Get(testutil.TestKeyForSet, &stored)
+	suite.Require().NoError(err, "Should find the value in the wrapped adapter")
+	suite.Require().False(bytes.Contains(stored, testutil.TestValueJSON), "Should not store the value in plain text")
+	suite.Require().True(bytes.Contains(stored, []byte("old")), "Should store the ID of the key")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestGet_Tampered() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := adapter.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var stored cacheadapters.RawMessage
+	err = inMemoryAdapter.Get(testutil.TestKeyForGet, &stored)
+	suite.Require().NoError(err, "Should find the value in the wrapped adapter")
+
+	stored[len(stored)-1] ^= 0xff
+	err = inMemoryAdapter.Set(testutil.TestKeyForGet, stored, nil)
+	suite.Require().NoError(err, "Should tamper with the value")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should detect the tampered value")
+
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForGet: &actual})
+	suite.Require().NoError(err, "Should not error on the whole GetMany")
+	suite.Require().ErrorIs(result[testutil.TestKeyForGet], encryptioncacheadapters.ErrUndecryptableValue, "Should detect the tampered value")
+
+	_, err = adapter.GetWithVersion(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should detect the tampered value")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestGet_Unencrypted() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := inMemoryAdapter.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should not accept unencrypted values")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestGet_ReplacedByInteger() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := adapter.Set(testutil.TestKeyForGet, "a secret", nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = inMemoryAdapter.Set(testutil.TestKeyForGet, cacheadapters.RawMessage("42"), nil)
+	suite.Require().NoError(err, "Should replace the value with an integer")
+
+	var actual string
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should not accept an unencrypted integer outside of the counters")
+
+	var rawActual cacheadapters.RawMessage
+	err = adapter.Get(testutil.TestKeyForGet, &rawActual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should not accept an unencrypted integer outside of the counters")
+
+	var counter int64
+	err = adapter.Get(testutil.TestKeyForGet, &counter)
+	suite.Require().NoError(err, "Should read the counters, which are not encrypted")
+	suite.Require().Equal(int64(42), counter, "Should decode the counter")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestGet_MovedToAnotherKey() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var stored cacheadapters.RawMessage
+	err = inMemoryAdapter.Get(testutil.TestKeyForSet, &stored)
+	suite.Require().NoError(err, "Should find the value in the wrapped adapter")
+
+	err = inMemoryAdapter.Set(testutil.TestKeyForGet, stored, nil)
+	suite.Require().NoError(err, "Should copy the value to another key")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should not accept values encrypted for another key")
+}
+
+func (suite *EncryptedAdapterTestSuite) TestKeyRotation() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	oldAdapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newTestKeyring())
+
+	err := oldAdapter.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	rotatedKeyring, err := encryptioncacheadapters.NewKeyring("new", map[string][]byte{"old": testOldKey, "new": testNewKey})
+	suite.Require().NoError(err, "Should not error on valid keyring")
+	suite.Require().Equal("new", rotatedKeyring.PrimaryID(), "Should encrypt with the primary key")
+
+	adapter, _ := encryptioncacheadapters.New(inMemoryAdapter, rotatedKeyring)
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().NoError(err, "Should decrypt the values encrypted with the old key")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	newKeyring, _ := encryptioncacheadapters.NewKeyring("new", map[string][]byte{"new": testNewKey})
+	newAdapter, _ := encryptioncacheadapters.New(inMemoryAdapter, newKeyring)
+
+	err = newAdapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should decrypt the values encrypted with the new key")
+
+	err = newAdapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, encryptioncacheadapters.ErrUndecryptableValue, "Should not decrypt the values of removed keys")
+}
@@ -0,0 +1,412 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// encryptedOperator implements the operations shared between
+// EncryptedAdapter and EncryptedSessionAdapter.
+type encryptedOperator struct {
+	inner   cacheadapters.CacheSessionAdapter // The wrapped adapter or session.
+	keyring *Keyring                          // The keys used to encrypt and decrypt the values.
+	codec   cacheadapters.Codec               // The codec encoding the values before the encryption.
+}
+
+// Get obtains a value from the cache using a key, then tries to decrypt
+// and unmarshal it into the object reference passed as parameter.
+//
+// Returns ErrUndecryptableValue if the value cannot be decrypted.
+func (eo encryptedOperator) Get(key string, objectRef interface{}) error {
+	return eo.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	if objectRef == nil {
+		return cacheadapters.ErrGetRequiresObjectReference
+	}
+
+	var content cacheadapters.RawMessage
+	err := eo.inner.GetContext(ctx, key, &content)
+	if err != nil {
+		return err
+	}
+
+	return eo.decrypt(key, content, objectRef)
+}
+
+// Set encrypts a value represented by the object parameter and
+// sets it into the cache, with the specified key.
+func (eo encryptedOperator) Set(key string, object interface{}, TTL *time.Duration) error {
+	return eo.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	content, err := eo.encrypt(key, object)
+	if err != nil {
+		return err
+	}
+
+	return eo.inner.SetContext(ctx, key, content, TTL)
+}
+
+// SetTTL marks the specified key new expiration, deletes it via using
+// cacheadapters.TTLExpired or negative duration.
+func (eo encryptedOperator) SetTTL(key string, newTTL time.Duration) error {
+	return eo.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	return eo.inner.SetTTLContext(ctx, key, newTTL)
+}
+
+// Delete deletes a key from the cache.
+func (eo encryptedOperator) Delete(key string) error {
+	return eo.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) DeleteContext(ctx context.Context, key string) error {
+	return eo.inner.DeleteContext(ctx, key)
+}
+
+// GetMany obtains multiple values from the cache at once, decrypting and
+// unmarshalling each of them into the object reference mapped to its key.
+func (eo encryptedOperator) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return eo.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	contents := make(map[string]interface{}, len(objectRefs))
+	for key := range objectRefs {
+		contents[key] = new(cacheadapters.RawMessage)
+	}
+
+	result, err := eo.inner.GetManyContext(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, objectRef := range objectRefs {
+		if result[key] != nil {
+			continue
+		}
+
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		result[key] = eo.decrypt(key, *contents[key].(*cacheadapters.RawMessage), objectRef)
+	}
+
+	return result, nil
+}
+
+// SetMany encrypts multiple values and sets them into the cache
+// at once, with the same TTL.
+func (eo encryptedOperator) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return eo.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	failures := make(cacheadapters.BatchResult)
+	contents := make(map[string]interface{}, len(objects))
+	for key, object := range objects {
+		content, err := eo.encrypt(key, object)
+		if err != nil {
+			failures[key] = err
+			continue
+		}
+
+		contents[key] = content
+	}
+
+	result, err := eo.inner.SetManyContext(ctx, contents, TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, err := range failures {
+		result[key] = err
+	}
+
+	return result, nil
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (eo encryptedOperator) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return eo.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	return eo.inner.DeleteManyContext(ctx, keys)
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (eo encryptedOperator) Exists(key string) (bool, error) {
+	return eo.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) ExistsContext(ctx context.Context, key string) (bool, error) {
+	return eo.inner.ExistsContext(ctx, key)
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (eo encryptedOperator) TTL(key string) (time.Duration, error) {
+	return eo.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	return eo.inner.TTLContext(ctx, key)
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// Counters are not encrypted, since the wrapped adapter
+// must be able to update them atomically.
+func (eo encryptedOperator) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return eo.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return eo.inner.IncrementContext(ctx, key, delta, TTL)
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// Counters are not encrypted, since the wrapped adapter
+// must be able to update them atomically.
+func (eo encryptedOperator) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return eo.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return eo.inner.DecrementContext(ctx, key, delta, TTL)
+}
+
+// SetIfAbsent encrypts a value represented by the object parameter and
+// sets it into the cache, with the specified key, only if the key is not
+// present or expired. Returns whether the value has been written.
+func (eo encryptedOperator) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return eo.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	content, err := eo.encrypt(key, object)
+	if err != nil {
+		return false, err
+	}
+
+	return eo.inner.SetIfAbsentContext(ctx, key, content, TTL)
+}
+
+// SetIfPresent encrypts a value represented by the object parameter and
+// sets it into the cache, with the specified key, only if the key is
+// present and not expired. Returns whether the value has been written.
+func (eo encryptedOperator) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return eo.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	content, err := eo.encrypt(key, object)
+	if err != nil {
+		return false, err
+	}
+
+	return eo.inner.SetIfPresentContext(ctx, key, content, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (eo encryptedOperator) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return eo.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (eo encryptedOperator) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	if objectRef == nil {
+		return "", cacheadapters.ErrGetRequiresObjectReference
+	}
+
+	var content cacheadapters.RawMessage
+	version, err := eo.inner.GetWithVersionContext(ctx, key, &content)
+	if err != nil {
+		return "", err
+	}
+
+	err = eo.decrypt(key, content, objectRef)
+	if err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
+
+// CompareAndSwap encrypts a value represented by the newObject parameter
+// and sets it into the cache, with the specified key, only if the value
+// stored still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (eo encryptedOperator) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return eo.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (eo encryptedOperator) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	content, err := eo.encrypt(key, newObject)
+	if err != nil {
+		return err
+	}
+
+	return eo.inner.CompareAndSwapContext(ctx, key, version, content, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+//
+// Tags are not encrypted.
+func (eo encryptedOperator) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return eo.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (eo encryptedOperator) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	content, err := eo.encrypt(key, object)
+	if err != nil {
+		return err
+	}
+
+	return eo.inner.SetWithTagsContext(ctx, key, content, TTL, tags...)
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+func (eo encryptedOperator) InvalidateTag(tag string) error {
+	return eo.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (eo encryptedOperator) InvalidateTagContext(ctx context.Context, tag string) error {
+	return eo.inner.InvalidateTagContext(ctx, tag)
+}
+
+// Scan returns an iterator over the keys starting with the
+// given prefix, or over all the keys if it is empty.
+//
+// Keys are not encrypted.
+func (eo encryptedOperator) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return eo.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	return eo.inner.ScanContext(ctx, prefix)
+}
+
+// Clear deletes all the entries of the wrapped adapter.
+func (eo encryptedOperator) Clear() error {
+	return eo.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (eo encryptedOperator) ClearContext(ctx context.Context) error {
+	return eo.inner.ClearContext(ctx)
+}
+
+// ClearPrefix deletes all the entries whose key starts
+// with the given prefix, or all of them if it is empty.
+func (eo encryptedOperator) ClearPrefix(prefix string) error {
+	return eo.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (eo encryptedOperator) ClearPrefixContext(ctx context.Context, prefix string) error {
+	return eo.inner.ClearPrefixContext(ctx, prefix)
+}
+
+// encrypt encodes the object with the codec, then encrypts it with
+// the primary key of the keyring, bound to the key in cache so that
+// it cannot be moved to another key.
+func (eo encryptedOperator) encrypt(key string, object interface{}) (cacheadapters.RawMessage, error) {
+	plaintext, err := cacheadapters.Marshal(eo.codec, object)
+	if err != nil {
+		return nil, err
+	}
+
+	return eo.keyring.seal(plaintext, key)
+}
+
+// decrypt decrypts the content stored in the key, then decodes
+// it into the object reference with the codec.
+//
+// Integer counters, which are never encrypted, are decoded as they are,
+// but only when read into an *int64: any other read requires a value
+// encrypted and authenticated with the keyring, so a plain integer
+// replacing a ciphertext is reported as ErrUndecryptableValue.
+func (eo encryptedOperator) decrypt(key string, content []byte, objectRef interface{}) error {
+	if _, isCounter := objectRef.(*int64); isCounter {
+		if _, err := strconv.ParseInt(string(content), 10, 64); err == nil {
+			return cacheadapters.Unmarshal(eo.codec, content, objectRef)
+		}
+	}
+
+	plaintext, err := eo.keyring.open(content, key)
+	if err != nil {
+		return err
+	}
+
+	return cacheadapters.Unmarshal(eo.codec, plaintext, objectRef)
+}
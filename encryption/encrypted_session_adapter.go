@@ -0,0 +1,59 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters
+
+import (
+	"reflect"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// EncryptedSessionAdapter is a cache session adapter which encrypts every
+// value with AES-GCM before passing it to the wrapped session, and decrypts
+// it back when read.
+type EncryptedSessionAdapter struct {
+	encryptedOperator
+}
+
+// NewSession creates a new EncryptedSessionAdapter which encrypts the
+// values of the given session with the keys of the keyring, configured
+// with the given options.
+func NewSession(session cacheadapters.CacheSessionAdapter, keyring *Keyring, opts ...Option) (*EncryptedSessionAdapter, error) {
+	if value := reflect.ValueOf(session); session == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	if keyring == nil {
+		return nil, ErrNilKeyring
+	}
+
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedSessionAdapter{
+		encryptedOperator: encryptedOperator{
+			inner:   session,
+			keyring: keyring,
+			codec:   config.codec,
+		},
+	}, nil
+}
+
+// Close closes the wrapped Cache Session.
+func (esa *EncryptedSessionAdapter) Close() error {
+	return esa.inner.Close()
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters
+
+import "fmt"
+
+var (
+	// ErrNilAdapter will come out if you try to pass a nil adapter
+	// or session when creating a new EncryptedAdapter or a session.
+	ErrNilAdapter = fmt.Errorf("you must pass a valid adapter to encrypt, nil found")
+	// ErrNilKeyring will come out if you try to pass a nil keyring
+	// when creating a new EncryptedAdapter or a session.
+	ErrNilKeyring = fmt.Errorf("you must pass a valid keyring, nil found")
+	// ErrInvalidKeyID will come out if you try to create a keyring with
+	// an empty key ID, a key ID longer than 255 bytes, or a primary key
+	// ID not contained in the keys.
+	ErrInvalidKeyID = fmt.Errorf("the key IDs must be non-empty, at most 255 bytes long, and the primary key ID must be one of them")
+	// ErrInvalidKey will come out if you try to create a keyring with a
+	// key which is not 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+	ErrInvalidKey = fmt.Errorf("the keys must be 16, 24 or 32 bytes long")
+	// ErrUndecryptableValue will come out if a value in cache has been
+	// tampered with, has not been written by an EncryptedAdapter, or has
+	// been encrypted with a key which is not in the keyring anymore.
+	ErrUndecryptableValue = fmt.Errorf("the value in cache cannot be decrypted, it may have been tampered with or its key is not in the keyring")
+)
@@ -0,0 +1,134 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryptioncacheadapters
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+)
+
+// formatVersion is the first byte of the encrypted values,
+// identifying the layout of the content.
+const formatVersion byte = 1
+
+// maxKeyIDLength is the maximum length of a key ID,
+// since it is stored in a single byte.
+const maxKeyIDLength = 255
+
+// Keyring contains the AES keys used to encrypt and decrypt the values,
+// each one identified by an ID.
+//
+// Values are always encrypted with the primary key, and carry its ID,
+// so they can be decrypted with any key of the keyring. To rotate the
+// keys, create a new Keyring with a new primary key, keeping the old
+// keys until the values encrypted with them have expired.
+type Keyring struct {
+	primaryID string                 // The ID of the key used to encrypt.
+	ciphers   map[string]cipher.AEAD // The AES-GCM ciphers, by key ID.
+}
+
+// NewKeyring creates a new Keyring from the keys, mapped by their ID,
+// encrypting the values with the key of the primary ID.
+//
+// Keys must be 16, 24 or 32 bytes long, to use AES-128,
+// AES-192 or AES-256 respectively.
+func NewKeyring(primaryID string, keys map[string][]byte) (*Keyring, error) {
+	if _, exists := keys[primaryID]; !exists {
+		return nil, ErrInvalidKeyID
+	}
+
+	ciphers := make(map[string]cipher.AEAD, len(keys))
+	for keyID, key := range keys {
+		if keyID == "" || len(keyID) > maxKeyIDLength {
+			return nil, ErrInvalidKeyID
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		ciphers[keyID] = aead
+	}
+
+	return &Keyring{
+		primaryID: primaryID,
+		ciphers:   ciphers,
+	}, nil
+}
+
+// PrimaryID returns the ID of the key used to encrypt the values.
+func (k *Keyring) PrimaryID() string {
+	return k.primaryID
+}
+
+// seal encrypts the plaintext with the primary key, authenticating the
+// additional data as well.
+//
+// The result is made of the format version, the length of the key ID,
+// the key ID, the nonce and the ciphertext, in this order.
+func (k *Keyring) seal(plaintext []byte, additionalData string) ([]byte, error) {
+	aead := k.ciphers[k.primaryID]
+
+	header := make([]byte, 0, 2+len(k.primaryID))
+	header = append(header, formatVersion, byte(len(k.primaryID)))
+	header = append(header, k.primaryID...)
+
+	result := make([]byte, len(header)+aead.NonceSize(), len(header)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(result, header)
+
+	nonce := result[len(header):]
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(result, nonce, plaintext, append(header, additionalData...)), nil
+}
+
+// open decrypts the content created by seal, with the key it carries,
+// checking that it has been sealed with the same additional data.
+func (k *Keyring) open(content []byte, additionalData string) ([]byte, error) {
+	if len(content) < 2 || content[0] != formatVersion {
+		return nil, ErrUndecryptableValue
+	}
+
+	headerLength := 2 + int(content[1])
+	if len(content) < headerLength {
+		return nil, ErrUndecryptableValue
+	}
+
+	aead, exists := k.ciphers[string(content[2:headerLength])]
+	if !exists || len(content) < headerLength+aead.NonceSize() {
+		return nil, ErrUndecryptableValue
+	}
+
+	header := content[:headerLength:headerLength]
+	nonce := content[headerLength : headerLength+aead.NonceSize()]
+
+	plaintext, err := aead.Open(nil, nonce, content[headerLength+aead.NonceSize():], append(header, additionalData...))
+	if err != nil {
+		return nil, ErrUndecryptableValue
+	}
+
+	return plaintext, nil
+}
@@ -25,6 +25,25 @@ var (
 	// ErrInvalidTTL will come out if you try to set a zero-or-negative
 	// TTL in a Set operation.
 	ErrInvalidTTL = fmt.Errorf("cannot provide a negative TTL to Set operations")
+	// ErrInvalidCounter will come out if you try to Increment or
+	// Decrement a key which does not contain an integer value.
+	ErrInvalidCounter = fmt.Errorf("the value stored in the key is not an integer counter")
+	// ErrVersionConflict will come out if a CompareAndSwap operation
+	// finds a value which has changed, or has been removed, since
+	// the version was obtained.
+	ErrVersionConflict = fmt.Errorf("the value has been modified since the version was obtained")
+	// ErrInvalidLoaderAdapter will come out if you try to create
+	// a Loader with a nil adapter.
+	ErrInvalidLoaderAdapter = fmt.Errorf("cannot create a Loader without an adapter, nil found")
+	// ErrInvalidNegativeKeyPrefix will come out if you try to set
+	// an empty prefix for the keys of the negative results.
+	ErrInvalidNegativeKeyPrefix = fmt.Errorf("the prefix of the keys of the negative results cannot be empty")
+	// ErrInvalidTypedAdapter will come out if you try to create
+	// a Typed cache with a nil adapter or session.
+	ErrInvalidTypedAdapter = fmt.Errorf("cannot create a Typed cache without an adapter, nil found")
+	// ErrNilCodec will come out if you try to
+	// configure an adapter with a nil Codec.
+	ErrNilCodec = fmt.Errorf("you must pass a valid codec, nil found")
 	// errNotImplemented will come out if you are a bad dev and you did
 	// not implement the method which returns this error. You should see this error
 	// only during development.
@@ -0,0 +1,129 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+// The parameters of the TinyLFU admission policy.
+const (
+	sketchDepth      = 4  // The number of rows of the count-min sketch.
+	sketchMaxCounter = 15 // The value at which the counters saturate.
+	sketchMinWidth   = 16 // The minimum number of counters in every row.
+	sketchResetRatio = 10 // The number of accesses, over the maximum entries, after which the counters are halved.
+
+	sketchDefaultEntries = 10000 // The size of the cache assumed when there is only a byte limit.
+)
+
+// tinyLFU is the TinyLFU admission policy: it estimates the frequency
+// of the recent accesses of the keys with a count-min sketch, and
+// admits a new key in a full cache only if it is accessed more
+// frequently than the one which would be evicted.
+//
+// The counters are halved after a number of accesses proportional to
+// the size of the cache, so that the old accesses are forgotten.
+// The caller must hold the mutex of the shard.
+type tinyLFU struct {
+	counters [sketchDepth][]uint8 // The rows of the count-min sketch.
+	mask     uint64               // The mask of the indexes of the counters in a row.
+	accesses int                  // The accesses recorded since the last reset.
+	resetAt  int                  // The number of accesses after which the counters are halved.
+}
+
+// newTinyLFU creates a new tinyLFU for a cache of the given size,
+// or of sketchDefaultEntries if not limited.
+func newTinyLFU(maxEntries int) *tinyLFU {
+	if maxEntries <= 0 {
+		maxEntries = sketchDefaultEntries
+	}
+
+	width := sketchMinWidth
+	for width < maxEntries {
+		width *= 2
+	}
+
+	result := &tinyLFU{
+		mask:    uint64(width - 1),
+		resetAt: sketchResetRatio * maxEntries,
+	}
+
+	for i := range result.counters {
+		result.counters[i] = make([]uint8, width)
+	}
+
+	return result
+}
+
+// record records an access of the key.
+func (tl *tinyLFU) record(key string) {
+	low, high := hashKey(key)
+	for i := range tl.counters {
+		index := (low + uint64(i)*high) & tl.mask
+		if tl.counters[i][index] < sketchMaxCounter {
+			tl.counters[i][index]++
+		}
+	}
+
+	tl.accesses++
+	if tl.accesses >= tl.resetAt {
+		tl.reset()
+	}
+}
+
+// estimate returns the estimated frequency of the accesses of the key.
+func (tl *tinyLFU) estimate(key string) uint8 {
+	result := uint8(sketchMaxCounter)
+
+	low, high := hashKey(key)
+	for i := range tl.counters {
+		index := (low + uint64(i)*high) & tl.mask
+		if tl.counters[i][index] < result {
+			result = tl.counters[i][index]
+		}
+	}
+
+	return result
+}
+
+// admit checks if the candidate key is accessed more
+// frequently than the victim key it would replace.
+func (tl *tinyLFU) admit(candidate string, victim string) bool {
+	return tl.estimate(candidate) > tl.estimate(victim)
+}
+
+// reset halves all the counters.
+func (tl *tinyLFU) reset() {
+	for i := range tl.counters {
+		for j := range tl.counters[i] {
+			tl.counters[i][j] /= 2
+		}
+	}
+
+	tl.accesses /= 2
+}
+
+// hashKey returns two independent hashes of the key, computed with
+// the 64 bits FNV-1a hash, used for double hashing in the sketch.
+func hashKey(key string) (uint64, uint64) {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+
+	hash := uint64(offset)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint64(key[i])
+		hash *= prime
+	}
+
+	return hash, (hash >> 32) | 1
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import "time"
+
+// Clock provides the current time and the tickers to an
+// InMemoryAdapter, so that the expiration of the entries and
+// the janitor can be tested deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a Ticker ticking every interval.
+	NewTicker(interval time.Duration) Ticker
+}
+
+// Ticker delivers the ticks of a Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// systemClock is the Clock using the system time, the default one.
+type systemClock struct{}
+
+// Now returns the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a Ticker wrapping a time.Ticker.
+func (systemClock) NewTicker(interval time.Duration) Ticker {
+	return systemTicker{time.NewTicker(interval)}
+}
+
+// systemTicker is the Ticker of the systemClock.
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel of the time.Ticker.
+func (st systemTicker) C() <-chan time.Time {
+	return st.Ticker.C
+}
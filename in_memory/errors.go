@@ -0,0 +1,75 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import "fmt"
+
+var (
+	// ErrInvalidMaxEntries will come out if you try to pass a
+	// non-positive number of entries to WithMaxEntries.
+	ErrInvalidMaxEntries = fmt.Errorf("you must pass a positive maximum number of entries")
+	// ErrUnknownEvictionPolicy will come out if you try to pass
+	// an unknown policy to WithEvictionPolicy.
+	ErrUnknownEvictionPolicy = fmt.Errorf("unknown eviction policy")
+	// ErrInvalidMaxBytes will come out if you try to pass a
+	// non-positive number of bytes to WithMaxBytes or WithMaxItemBytes.
+	ErrInvalidMaxBytes = fmt.Errorf("you must pass a positive maximum number of bytes")
+	// ErrNilCostFunc will come out if you try to pass a nil
+	// function to WithCostFunc.
+	ErrNilCostFunc = fmt.Errorf("you must pass a valid cost function, not a nil one")
+	// ErrItemTooLarge will come out if you try to write a value whose
+	// cost exceeds the maximum set with WithMaxItemBytes or WithMaxBytes.
+	ErrItemTooLarge = fmt.Errorf("the value exceeds the maximum size of an item in cache")
+	// ErrRejected will come out if you try to write a new value in a full
+	// cache and the admission policy, enabled with WithAdmission, prefers
+	// the entries it would evict. The value is not written.
+	ErrRejected = fmt.Errorf("the value has been rejected by the admission policy")
+	// ErrNilClock will come out if you try to pass a nil clock to WithClock.
+	ErrNilClock = fmt.Errorf("you must pass a valid clock, not a nil one")
+	// ErrInvalidJanitorInterval will come out if you try to pass a
+	// non-positive interval to WithJanitor.
+	ErrInvalidJanitorInterval = fmt.Errorf("you must pass a positive janitor interval")
+	// ErrInvalidSampleSize will come out if you try to pass a
+	// non-positive sample size to WithJanitorSampling.
+	ErrInvalidSampleSize = fmt.Errorf("you must pass a positive janitor sample size")
+	// ErrJanitorNotConfigured will come out if you try to start the
+	// janitor of an adapter created without WithJanitor.
+	ErrJanitorNotConfigured = fmt.Errorf("the janitor has not been configured, use WithJanitor")
+	// ErrInvalidShards will come out if you try to pass a
+	// non-positive number of shards to WithShards.
+	ErrInvalidShards = fmt.Errorf("you must pass a positive number of shards")
+	// ErrTooManyShards will come out if you try to create an adapter with
+	// more shards than the maximum number of entries or bytes.
+	ErrTooManyShards = fmt.Errorf("the number of shards exceeds the maximum number of entries or bytes")
+	// ErrMaxItemBytesTooLarge will come out if you try to create an adapter
+	// with a maximum cost of an item larger than the byte budget of a shard,
+	// which is the one set with WithMaxBytes divided by the number of shards.
+	ErrMaxItemBytesTooLarge = fmt.Errorf("the maximum cost of an item exceeds the byte budget of a shard")
+	// ErrUnknownObjectMode will come out if you try to pass
+	// an unknown mode to WithObjectMode.
+	ErrUnknownObjectMode = fmt.Errorf("unknown object mode")
+	// ErrNilCloner will come out if you try to pass a nil
+	// function to WithCloner.
+	ErrNilCloner = fmt.Errorf("you must pass a valid cloner, not a nil one")
+	// ErrClonerRequired will come out if you try to use the
+	// DeepCopies object mode without passing WithCloner.
+	ErrClonerRequired = fmt.Errorf("the DeepCopies object mode requires a cloner, use WithCloner")
+	// ErrObjectModeByteLimits will come out if you try to use an object
+	// mode together with WithMaxBytes or WithMaxItemBytes.
+	ErrObjectModeByteLimits = fmt.Errorf("the byte limits cannot be used with an object mode, since values are not encoded")
+	// ErrTypeMismatch will come out if you try to Get, with an object
+	// mode, a value into an object reference of a different type.
+	ErrTypeMismatch = fmt.Errorf("the type of the value in cache does not match the type of the object reference")
+)
@@ -0,0 +1,210 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import "container/list"
+
+// EvictionPolicy chooses the entries evicted when the
+// InMemoryAdapter is full, see WithMaxEntries.
+type EvictionPolicy int
+
+// The available eviction policies.
+const (
+	LRU  EvictionPolicy = iota // Evicts the least recently used entry, the default.
+	LFU                        // Evicts the least frequently used entry, the least recently used among the ones used as frequently.
+	FIFO                       // Evicts the oldest entry, regardless of its usage.
+)
+
+// evictionPolicy tracks the keys in cache and chooses the
+// one to evict. All the methods but victims run in constant
+// time and the caller must hold the mutex of the shard.
+type evictionPolicy interface {
+	add(key string)                      // add tracks a key written in cache.
+	access(key string)                   // access tracks a key read or overwritten.
+	remove(key string)                   // remove stops tracking a key.
+	victim() (string, bool)              // victim returns the key to evict, if any.
+	victims(visit func(key string) bool) // victims visits the keys in eviction order, until visit returns false.
+	clear()                              // clear stops tracking all the keys.
+}
+
+// newEvictionPolicy creates the evictionPolicy implementing the given policy.
+func newEvictionPolicy(policy EvictionPolicy) evictionPolicy {
+	switch policy {
+	case LFU:
+		return newLFUPolicy()
+	case FIFO:
+		return newListPolicy(false)
+	default:
+		return newListPolicy(true)
+	}
+}
+
+// listPolicy is the evictionPolicy keeping the keys in a list,
+// evicting the one at the back.
+//
+// It implements LRU moving the accessed keys to the front,
+// and FIFO leaving them in the order they have been added.
+type listPolicy struct {
+	keys         *list.List               // The keys, the next one to evict at the back.
+	elements     map[string]*list.Element // The elements of the list, by key.
+	moveOnAccess bool                     // Whether the accessed keys are moved to the front.
+}
+
+// newListPolicy creates a new empty listPolicy.
+func newListPolicy(moveOnAccess bool) *listPolicy {
+	return &listPolicy{
+		keys:         list.New(),
+		elements:     make(map[string]*list.Element),
+		moveOnAccess: moveOnAccess,
+	}
+}
+
+func (lp *listPolicy) add(key string) {
+	lp.elements[key] = lp.keys.PushFront(key)
+}
+
+func (lp *listPolicy) access(key string) {
+	if element, exists := lp.elements[key]; exists && lp.moveOnAccess {
+		lp.keys.MoveToFront(element)
+	}
+}
+
+func (lp *listPolicy) remove(key string) {
+	if element, exists := lp.elements[key]; exists {
+		lp.keys.Remove(element)
+		delete(lp.elements, key)
+	}
+}
+
+func (lp *listPolicy) victim() (string, bool) {
+	element := lp.keys.Back()
+	if element == nil {
+		return "", false
+	}
+
+	return element.Value.(string), true
+}
+
+func (lp *listPolicy) victims(visit func(key string) bool) {
+	for element := lp.keys.Back(); element != nil; element = element.Prev() {
+		if !visit(element.Value.(string)) {
+			return
+		}
+	}
+}
+
+func (lp *listPolicy) clear() {
+	lp.keys.Init()
+	lp.elements = make(map[string]*list.Element)
+}
+
+// lfuPolicy is the evictionPolicy evicting the least frequently used
+// key, in constant time, keeping the keys in buckets by frequency.
+type lfuPolicy struct {
+	buckets *list.List           // The buckets, by increasing frequency.
+	entries map[string]*lfuEntry // The entries, by key.
+}
+
+// lfuBucket contains the keys used with the same frequency.
+type lfuBucket struct {
+	frequency uint64     // The number of accesses of the keys.
+	entries   *list.List // The entries, the least recently used at the back.
+}
+
+// lfuEntry is a key tracked by the lfuPolicy.
+type lfuEntry struct {
+	key     string        // The key of the entry.
+	bucket  *list.Element // The element of the bucket of the entry.
+	element *list.Element // The element of the entry in its bucket.
+}
+
+// newLFUPolicy creates a new empty lfuPolicy.
+func newLFUPolicy() *lfuPolicy {
+	return &lfuPolicy{
+		buckets: list.New(),
+		entries: make(map[string]*lfuEntry),
+	}
+}
+
+func (lp *lfuPolicy) add(key string) {
+	bucket := lp.buckets.Front()
+	if bucket == nil || bucket.Value.(*lfuBucket).frequency != 1 {
+		bucket = lp.buckets.PushFront(&lfuBucket{frequency: 1, entries: list.New()})
+	}
+
+	entry := &lfuEntry{key: key, bucket: bucket}
+	entry.element = bucket.Value.(*lfuBucket).entries.PushFront(entry)
+	lp.entries[key] = entry
+}
+
+func (lp *lfuPolicy) access(key string) {
+	entry, exists := lp.entries[key]
+	if !exists {
+		return
+	}
+
+	current := entry.bucket.Value.(*lfuBucket)
+
+	next := entry.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket).frequency != current.frequency+1 {
+		next = lp.buckets.InsertAfter(&lfuBucket{frequency: current.frequency + 1, entries: list.New()}, entry.bucket)
+	}
+
+	lp.detach(entry)
+	entry.bucket = next
+	entry.element = next.Value.(*lfuBucket).entries.PushFront(entry)
+}
+
+func (lp *lfuPolicy) remove(key string) {
+	if entry, exists := lp.entries[key]; exists {
+		lp.detach(entry)
+		delete(lp.entries, key)
+	}
+}
+
+func (lp *lfuPolicy) victim() (string, bool) {
+	bucket := lp.buckets.Front()
+	if bucket == nil {
+		return "", false
+	}
+
+	return bucket.Value.(*lfuBucket).entries.Back().Value.(*lfuEntry).key, true
+}
+
+func (lp *lfuPolicy) victims(visit func(key string) bool) {
+	for bucket := lp.buckets.Front(); bucket != nil; bucket = bucket.Next() {
+		for element := bucket.Value.(*lfuBucket).entries.Back(); element != nil; element = element.Prev() {
+			if !visit(element.Value.(*lfuEntry).key) {
+				return
+			}
+		}
+	}
+}
+
+func (lp *lfuPolicy) clear() {
+	lp.buckets.Init()
+	lp.entries = make(map[string]*lfuEntry)
+}
+
+// detach removes the entry from its bucket,
+// removing the bucket as well if left empty.
+func (lp *lfuPolicy) detach(entry *lfuEntry) {
+	bucket := entry.bucket.Value.(*lfuBucket)
+	bucket.entries.Remove(entry.element)
+
+	if bucket.entries.Len() == 0 {
+		lp.buckets.Remove(entry.bucket)
+	}
+}
@@ -15,8 +15,12 @@
 package inmemorycacheadapters
 
 import (
-	"encoding/json"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
@@ -25,80 +29,272 @@ import (
 // cacheItem is the internal struct
 // handling the mechanism of cache expiration.
 type cacheItem struct {
-	item      json.RawMessage // The actual item in cache.
-	expiresAt time.Time       // The expiration time of the item in cache.
+	item      []byte      // The actual item in cache, encoded by the codec.
+	object    interface{} // The actual item in cache, stored as it is in the object modes.
+	isObject  bool        // Whether the item is stored in object instead of item.
+	expiresAt time.Time   // The expiration time of the item in cache.
+	version   uint64      // The version of the item in cache, changed on every write.
+	cost      int64       // The cost of the item in cache, counted in the byte budget.
 }
 
-// cacheData is the container of all the in-memory
-// cache used by the adapter.
+// isExpired checks if the item in cache is expired at the given time,
+// which is the case from its expiration time on.
+//
+// Every operation checks the expiration with it, so
+// that all of them agree on when an item expires.
+func (item cacheItem) isExpired(now time.Time) bool {
+	return !now.Before(item.expiresAt)
+}
+
+// cacheData is the container of the in-memory
+// cache of a shard of the adapter.
 type cacheData map[string]cacheItem
 
+// tagIndex maps every tag to the set
+// of keys which have been set with it.
+type tagIndex map[string]map[string]struct{}
+
+// keyTags maps every tagged key to its tags,
+// to detach them when the key is written again
+// or deleted.
+type keyTags map[string][]string
+
 // InMemoryAdapter is the cache adapter which uses internal memory
 // of the process.
 type InMemoryAdapter struct {
-	defaultTTL time.Duration // The defaultTTL of the Set operations.
-	data       cacheData     // The data being stored in the in-memory cache.
-	mutex      sync.Mutex    // The mutex locking the operations.
+	lastVersion uint64              // The last version assigned to an item in cache, first for the alignment of the atomic operations.
+	defaultTTL  time.Duration       // The defaultTTL of the Set operations.
+	codec       cacheadapters.Codec // The codec of the values in cache.
+	objectMode  ObjectMode          // How the values are stored in cache.
+	cloner      Cloner              // The function copying the values in the DeepCopies object mode.
+	shards      []*shard            // The shards containing the entries, by the hash of their keys.
+	clock       Clock               // The clock giving the current time.
+
+	janitorInterval   time.Duration // The interval between the runs of the janitor, zero if disabled.
+	janitorSampleSize int           // The number of keys sampled by the janitor, zero to check all the keys.
+	janitorMutex      sync.Mutex    // The mutex locking the start and the stop of the janitor.
+	janitor           *janitor      // The running janitor, if any.
+}
+
+// Stats contains the statistics of an InMemoryAdapter.
+type Stats struct {
+	Entries    int    // The number of entries in cache, including the expired ones not removed yet.
+	Bytes      int64  // The cost of the entries in cache, zero if there is no byte limit.
+	Evictions  uint64 // The number of entries evicted to make room for new ones.
+	Rejections uint64 // The number of writes rejected by the admission policy.
+	TaggedKeys int    // The number of entries carrying at least one tag.
 }
 
-// New creates a new InMemoryAdapter from an default TTL.
-func New(defaultTTL time.Duration) (cacheadapters.CacheAdapter, error) {
+// New creates a new InMemoryAdapter from an default TTL,
+// configured with the given options.
+//
+// If the janitor is enabled with WithJanitor, call Close when the
+// adapter is no longer needed, to stop its goroutine.
+func New(defaultTTL time.Duration, opts ...Option) (*InMemoryAdapter, error) {
 	if defaultTTL <= 0 {
 		return nil, cacheadapters.ErrInvalidTTL
 	}
 
-	return &InMemoryAdapter{
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := config.maxEntries > 0 || config.maxBytes > 0
+	if config.shards == 0 {
+		config.shards = defaultShards
+		if limited {
+			config.shards = 1
+		}
+	}
+
+	if config.objectMode == DeepCopies && config.cloner == nil {
+		return nil, ErrClonerRequired
+	}
+
+	if config.objectMode != EncodedValues && (config.maxBytes > 0 || config.maxItemBytes > 0) {
+		return nil, ErrObjectModeByteLimits
+	}
+
+	if (config.maxEntries > 0 && config.shards > config.maxEntries) || (config.maxBytes > 0 && int64(config.shards) > config.maxBytes) {
+		return nil, ErrTooManyShards
+	}
+
+	if config.maxBytes > 0 && config.maxItemBytes > config.maxBytes/int64(config.shards) {
+		return nil, ErrMaxItemBytesTooLarge
+	}
+
+	result := &InMemoryAdapter{
 		defaultTTL: defaultTTL,
-		data:       make(cacheData),
-	}, nil
+		codec:      config.codec,
+		objectMode: config.objectMode,
+		cloner:     config.cloner,
+		shards:     newShards(config),
+		clock:      config.clock,
+
+		janitorInterval:   config.janitorInterval,
+		janitorSampleSize: config.janitorSampleSize,
+	}
+
+	if config.janitorInterval > 0 {
+		result.StartJanitor()
+	}
+
+	return result, nil
+}
+
+// Stats returns a snapshot of the statistics of the adapter,
+// summing the ones of all the shards.
+func (ima *InMemoryAdapter) Stats() Stats {
+	var result Stats
+
+	for _, s := range ima.shards {
+		s.mutex.RLock()
+		result.Entries += len(s.data)
+		result.Bytes += s.usedBytes
+		result.Evictions += s.evictions
+		result.Rejections += s.rejections
+		result.TaggedKeys += len(s.keyTags)
+		s.mutex.RUnlock()
+	}
+
+	return result
+}
+
+// shardFor returns the shard containing the key.
+func (ima *InMemoryAdapter) shardFor(key string) *shard {
+	if len(ima.shards) == 1 {
+		return ima.shards[0]
+	}
+
+	return ima.shards[shardIndex(key, len(ima.shards))]
 }
 
 // OpenSession opens a new Cache Session.
-// Returns the same adapter because the
-// session with the memory is always open.
+// Returns a session sharing the data of the adapter,
+// because the session with the memory is always open.
 func (ima *InMemoryAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
-	return ima, nil
+	return ima.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext opens a new Cache Session, honoring the
+// cancellation and the deadline of the given context.
+// Returns a session sharing the data of the adapter,
+// because the session with the memory is always open.
+func (ima *InMemoryAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &inMemorySessionAdapter{ima}, nil
+}
+
+// Close closes the adapter, stopping the janitor if running.
+// The data in cache stays available.
+func (ima *InMemoryAdapter) Close() error {
+	ima.StopJanitor()
+	return nil
+}
+
+// inMemorySessionAdapter is the session returned by OpenSession,
+// sharing the data of the adapter.
+type inMemorySessionAdapter struct {
+	*InMemoryAdapter
 }
 
 // Close closes the Cache Session.
 // Returns nil because the session with
 // the memory is always on and does not
 // need to be closed.
-func (ima *InMemoryAdapter) Close() error {
+func (imsa *inMemorySessionAdapter) Close() error {
 	return nil
 }
 
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (ima *InMemoryAdapter) Get(key string, resultRef interface{}) error {
+	return ima.GetContext(context.Background(), key, resultRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) GetContext(ctx context.Context, key string, resultRef interface{}) error {
+	_, err := ima.GetWithVersionContext(ctx, key, resultRef)
+	return err
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (ima *InMemoryAdapter) GetWithVersion(key string, resultRef interface{}) (cacheadapters.Version, error) {
+	return ima.GetWithVersionContext(context.Background(), key, resultRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (ima *InMemoryAdapter) GetWithVersionContext(ctx context.Context, key string, resultRef interface{}) (cacheadapters.Version, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	if resultRef == nil {
-		return cacheadapters.ErrGetRequiresObjectReference
+		return "", cacheadapters.ErrGetRequiresObjectReference
 	}
 
-	ima.mutex.Lock()
-	valueFromMemory, exists := ima.data[key]
-	ima.mutex.Unlock()
+	s := ima.shardFor(key)
+	s.readLock()
+	valueFromMemory, exists := s.data[key]
+	s.recordAccess(key, exists)
+	s.readUnlock()
 	if !exists {
-		return cacheadapters.ErrNotFound
+		return "", cacheadapters.ErrNotFound
 	}
 
-	now := time.Now()
-	if valueFromMemory.expiresAt.UnixNano() < now.UnixNano() {
-		ima.Delete(key)
-		return cacheadapters.ErrNotFound
+	now := ima.clock.Now()
+	if valueFromMemory.isExpired(now) {
+		// the key may have been written again after
+		// the read, so it is removed only if still expired.
+		s.mutex.Lock()
+		s.removeIfExpired(key, now)
+		s.mutex.Unlock()
+		return "", cacheadapters.ErrNotFound
 	}
 
-	err := json.Unmarshal(valueFromMemory.item, resultRef)
+	err := ima.decode(valueFromMemory, resultRef)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return formatVersion(valueFromMemory.version), nil
 }
 
 // Set sets a value represented by the object parameter into the cache,
 // with the specified key.
 func (ima *InMemoryAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
+	return ima.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	return ima.SetWithTagsContext(ctx, key, object, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+//
+// The tags belong to the value: writing the key again replaces them,
+// while Increment, Decrement and SetTTL keep them.
+func (ima *InMemoryAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return ima.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (ima *InMemoryAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if TTL == nil {
 		TTL = new(time.Duration)
 		*TTL = ima.defaultTTL
@@ -106,58 +302,687 @@ func (ima *InMemoryAdapter) Set(key string, object interface{}, TTL *time.Durati
 		return cacheadapters.ErrInvalidTTL
 	}
 
-	now := time.Now()
+	now := ima.clock.Now()
 	expiresAt := now.Add(*TTL)
 
-	content, err := json.Marshal(object)
+	item, err := ima.newItem(object)
 	if err != nil {
 		return err
 	}
 
-	ima.mutex.Lock()
-	ima.data[key] = cacheItem{
-		item:      content,
-		expiresAt: expiresAt,
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	item.expiresAt = expiresAt
+	item.version = ima.nextVersion()
+
+	err = s.store(key, item)
+	if err != nil {
+		return err
 	}
-	ima.mutex.Unlock()
 
+	s.tag(key, tags)
 	return nil
 }
 
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (ima *InMemoryAdapter) SetTTL(key string, newTTL time.Duration) error {
+	return ima.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if newTTL <= cacheadapters.TTLExpired {
-		return ima.Delete(key)
+		return ima.DeleteContext(ctx, key)
 	}
 
-	ima.mutex.Lock()
-	valueFromMemory, exists := ima.data[key]
-	ima.mutex.Unlock()
+	now := ima.clock.Now()
+
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	valueFromMemory, exists := s.data[key]
 	if !exists {
 		return cacheadapters.ErrNotFound
 	}
 
-	now := time.Now()
-	newExpiresAt := now.Add(newTTL)
-
-	if valueFromMemory.expiresAt.UnixNano() < now.UnixNano() {
-		return ima.Delete(key)
+	if s.removeIfExpired(key, now) {
+		return nil
 	}
 
-	valueFromMemory.expiresAt = newExpiresAt
-
-	ima.mutex.Lock()
-	ima.data[key] = valueFromMemory
-	ima.mutex.Unlock()
+	// only the expiration changes, so the item is updated in
+	// place, keeping its version and its cost, and the change
+	// is not tracked as an access by the eviction policy.
+	valueFromMemory.expiresAt = now.Add(newTTL)
+	s.data[key] = valueFromMemory
 
 	return nil
 }
 
 // Delete deletes a key from the cache.
 func (ima *InMemoryAdapter) Delete(key string) error {
-	ima.mutex.Lock()
-	delete(ima.data, key)
-	ima.mutex.Unlock()
+	return ima.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) DeleteContext(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	s.remove(key)
+	s.mutex.Unlock()
+	return nil
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (ima *InMemoryAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return ima.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// The lock of each shard is acquired only once for all its keys.
+func (ima *InMemoryAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(cacheadapters.BatchResult, len(objectRefs))
+	valuesFromMemory := make(map[string]cacheItem, len(objectRefs))
+	now := ima.clock.Now()
+
+	for s, keys := range ima.groupByShard(mapKeys(objectRefs)) {
+		var expiredKeys []string
+
+		s.readLock()
+		for _, key := range keys {
+			valueFromMemory, exists := s.data[key]
+			s.recordAccess(key, exists)
+			if !exists {
+				continue
+			}
+
+			if valueFromMemory.isExpired(now) {
+				expiredKeys = append(expiredKeys, key)
+				continue
+			}
+
+			valuesFromMemory[key] = valueFromMemory
+		}
+		s.readUnlock()
+
+		if len(expiredKeys) > 0 {
+			s.mutex.Lock()
+			for _, key := range expiredKeys {
+				s.removeIfExpired(key, now)
+			}
+			s.mutex.Unlock()
+		}
+	}
+
+	for key, objectRef := range objectRefs {
+		valueFromMemory, exists := valuesFromMemory[key]
+		if !exists {
+			result[key] = cacheadapters.ErrNotFound
+			continue
+		}
+
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		result[key] = ima.decode(valueFromMemory, objectRef)
+	}
+
+	return result, nil
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (ima *InMemoryAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return ima.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// The lock of each shard is acquired only once for all its keys.
+func (ima *InMemoryAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if TTL == nil {
+		TTL = new(time.Duration)
+		*TTL = ima.defaultTTL
+	} else if *TTL <= 0 {
+		return nil, cacheadapters.ErrInvalidTTL
+	}
+
+	result := make(cacheadapters.BatchResult, len(objects))
+	items := make(map[string]cacheItem, len(objects))
+	expiresAt := ima.clock.Now().Add(*TTL)
+
+	for key, object := range objects {
+		item, err := ima.newItem(object)
+		result[key] = err
+		if err != nil {
+			continue
+		}
+
+		item.expiresAt = expiresAt
+		items[key] = item
+	}
+
+	for s, keys := range ima.groupByShard(mapKeys(items)) {
+		s.mutex.Lock()
+		for _, key := range keys {
+			item := items[key]
+			item.version = ima.nextVersion()
+			result[key] = s.store(key, item)
+		}
+		s.mutex.Unlock()
+	}
+
+	return result, nil
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (ima *InMemoryAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return ima.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// The lock of each shard is acquired only once for all its keys.
+func (ima *InMemoryAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(cacheadapters.BatchResult, len(keys))
+
+	for s, shardKeys := range ima.groupByShard(keys) {
+		s.mutex.Lock()
+		for _, key := range shardKeys {
+			s.remove(key)
+			result[key] = nil
+		}
+		s.mutex.Unlock()
+	}
+
+	return result, nil
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (ima *InMemoryAdapter) Exists(key string) (bool, error) {
+	return ima.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	_, err := ima.TTLContext(ctx, key)
+	if err == cacheadapters.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (ima *InMemoryAdapter) TTL(key string) (time.Duration, error) {
+	return ima.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s := ima.shardFor(key)
+	s.mutex.RLock()
+	valueFromMemory, exists := s.data[key]
+	s.mutex.RUnlock()
+	if !exists {
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	now := ima.clock.Now()
+	if valueFromMemory.isExpired(now) {
+		// the key may have been written again after
+		// the read, so it is removed only if still expired.
+		s.mutex.Lock()
+		s.removeIfExpired(key, now)
+		s.mutex.Unlock()
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	return valueFromMemory.expiresAt.Sub(now), nil
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// If the key is not present or expired, the counter starts from
+// zero and the given TTL (or the default one if nil) is applied,
+// otherwise the TTL of the counter is left untouched.
+func (ima *InMemoryAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ima.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if TTL == nil {
+		TTL = &ima.defaultTTL
+	} else if *TTL <= 0 {
+		return 0, cacheadapters.ErrInvalidTTL
+	}
+
+	now := ima.clock.Now()
+
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var counter int64
+
+	// the tags of a counter are kept by its increments.
+	tags := s.keyTags[key]
+
+	valueFromMemory, exists := s.data[key]
+	if !exists || valueFromMemory.isExpired(now) {
+		valueFromMemory = cacheItem{
+			expiresAt: now.Add(*TTL),
+		}
+		tags = nil
+	} else if valueFromMemory.isObject {
+		var isCounter bool
+
+		counter, isCounter = objectCounter(valueFromMemory.object)
+		if !isCounter {
+			return 0, cacheadapters.ErrInvalidCounter
+		}
+	} else {
+		var err error
+
+		counter, err = strconv.ParseInt(string(valueFromMemory.item), 10, 64)
+		if err != nil {
+			return 0, cacheadapters.ErrInvalidCounter
+		}
+	}
+
+	// counters are always stored as plain integers,
+	// decoded by the codec even in the object modes.
+	counter += delta
+	valueFromMemory.item = strconv.AppendInt(nil, counter, 10)
+	valueFromMemory.object, valueFromMemory.isObject = nil, false
+	valueFromMemory.version = ima.nextVersion()
+	err := s.store(key, valueFromMemory)
+	if err != nil {
+		return 0, err
+	}
+
+	s.tag(key, tags)
+	return counter, nil
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (ima *InMemoryAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ima.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ima.IncrementContext(ctx, key, -delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (ima *InMemoryAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ima.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ima.setIf(ctx, key, object, TTL, false)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (ima *InMemoryAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ima.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ima.setIf(ctx, key, object, TTL, true)
+}
+
+// setIf sets the value only if the presence of the key, with
+// expired keys treated as absent, matches the wanted one.
+func (ima *InMemoryAdapter) setIf(ctx context.Context, key string, object interface{}, TTL *time.Duration, wantPresent bool) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if TTL == nil {
+		TTL = &ima.defaultTTL
+	} else if *TTL <= 0 {
+		return false, cacheadapters.ErrInvalidTTL
+	}
+
+	item, err := ima.newItem(object)
+	if err != nil {
+		return false, err
+	}
+
+	now := ima.clock.Now()
+
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	valueFromMemory, exists := s.data[key]
+	present := exists && !valueFromMemory.isExpired(now)
+	if present != wantPresent {
+		return false, nil
+	}
+
+	item.expiresAt = now.Add(*TTL)
+	item.version = ima.nextVersion()
+
+	err = s.store(key, item)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (ima *InMemoryAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return ima.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (ima *InMemoryAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if TTL == nil {
+		TTL = &ima.defaultTTL
+	} else if *TTL <= 0 {
+		return cacheadapters.ErrInvalidTTL
+	}
+
+	item, err := ima.newItem(newObject)
+	if err != nil {
+		return err
+	}
+
+	now := ima.clock.Now()
+
+	s := ima.shardFor(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	valueFromMemory, exists := s.data[key]
+	if !exists || valueFromMemory.isExpired(now) || formatVersion(valueFromMemory.version) != version {
+		return cacheadapters.ErrVersionConflict
+	}
+
+	item.expiresAt = now.Add(*TTL)
+	item.version = ima.nextVersion()
+
+	return s.store(key, item)
+}
+
+// nextVersion returns a new version for an item in cache.
+//
+// Versions are unique in the adapter, so that a version obtained
+// before a Delete cannot match the item written after it, even if
+// the key is written again.
+func (ima *InMemoryAdapter) nextVersion() uint64 {
+	return atomic.AddUint64(&ima.lastVersion, 1)
+}
+
+// formatVersion converts the version of an item
+// in cache into its opaque representation.
+func formatVersion(version uint64) cacheadapters.Version {
+	return cacheadapters.Version(strconv.FormatUint(version, 10))
+}
+
+// groupByShard groups the keys by the shard containing them.
+func (ima *InMemoryAdapter) groupByShard(keys []string) map[*shard][]string {
+	result := make(map[*shard][]string, len(ima.shards))
+	for _, key := range keys {
+		s := ima.shardFor(key)
+		result[s] = append(result[s], key)
+	}
+
+	return result
+}
+
+// mapKeys returns the keys of the map.
+func mapKeys[T any](m map[string]T) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+//
+// Keys which have been written again without the tag,
+// after being set with it, are not deleted.
+func (ima *InMemoryAdapter) InvalidateTag(tag string) error {
+	return ima.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (ima *InMemoryAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range ima.shards {
+		s.mutex.Lock()
+		for key := range s.tags[tag] {
+			s.remove(key)
+		}
+
+		delete(s.tags, tag)
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// Scan returns an iterator over the keys of the cache starting
+// with the given prefix, or over all the keys if it is empty.
+//
+// The iterator works on a snapshot of the keys taken by Scan, sorted
+// and without the expired ones.
+func (ima *InMemoryAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return ima.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := ima.clock.Now()
+	keys := make([]string, 0)
+
+	for _, s := range ima.shards {
+		s.mutex.RLock()
+		for key, valueFromMemory := range s.data {
+			if strings.HasPrefix(key, prefix) && !valueFromMemory.isExpired(now) {
+				keys = append(keys, key)
+			}
+		}
+		s.mutex.RUnlock()
+	}
+
+	sort.Strings(keys)
+
+	return &snapshotKeyIterator{
+		ctx:   ctx,
+		keys:  keys,
+		index: -1,
+	}, nil
+}
+
+// Clear deletes all the entries of the cache.
+//
+// The entries of every shard are dropped at once by replacing
+// the map containing them, together with the index of the tags.
+func (ima *InMemoryAdapter) Clear() error {
+	return ima.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (ima *InMemoryAdapter) ClearContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range ima.shards {
+		s.mutex.Lock()
+		s.clear()
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// ClearPrefix deletes all the entries whose key starts with the
+// given prefix, or all the entries of the cache if it is empty.
+func (ima *InMemoryAdapter) ClearPrefix(prefix string) error {
+	return ima.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (ima *InMemoryAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	if prefix == "" {
+		return ima.ClearContext(ctx)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range ima.shards {
+		s.mutex.Lock()
+		for key := range s.data {
+			if strings.HasPrefix(key, prefix) {
+				s.remove(key)
+			}
+		}
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// snapshotKeyIterator is the KeyIterator
+// over a snapshot of the keys in cache.
+type snapshotKeyIterator struct {
+	ctx   context.Context // The context of the Scan operation.
+	keys  []string        // The snapshot of the keys.
+	index int             // The index of the current key.
+	err   error           // The error occurred during the iteration.
+}
+
+// Next advances the iterator to the next key, returns false
+// when there are no more keys or an error occurred.
+func (ski *snapshotKeyIterator) Next() bool {
+	if ski.err != nil {
+		return false
+	}
+
+	if err := ski.ctx.Err(); err != nil {
+		ski.err = err
+		return false
+	}
+
+	if ski.index+1 >= len(ski.keys) {
+		return false
+	}
+
+	ski.index++
+	return true
+}
+
+// Key returns the current key of the iterator.
+func (ski *snapshotKeyIterator) Key() string {
+	if ski.index < 0 || ski.index >= len(ski.keys) {
+		return ""
+	}
+
+	return ski.keys[ski.index]
+}
+
+// Err returns the error occurred during the iteration, if any.
+func (ski *snapshotKeyIterator) Err() error {
+	return ski.err
+}
+
+// Close releases the snapshot of the keys.
+func (ski *snapshotKeyIterator) Close() error {
+	ski.keys = nil
+	ski.index = -1
 	return nil
 }
@@ -38,21 +38,31 @@ func testSleepFunc() func(time.Duration) {
 	}
 }
 
-func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+func newTestAdapterFunc(defaultTTL time.Duration, opts ...inmemorycacheadapters.Option) func() (cacheadapters.CacheAdapter, error) {
 	return func() (cacheadapters.CacheAdapter, error) {
-		return inmemorycacheadapters.New(defaultTTL)
+		adapter, err := inmemorycacheadapters.New(defaultTTL, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter, nil
 	}
 }
 
-func newTestSessionFunc(t *testing.T, defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+func newTestSessionFunc(t *testing.T, defaultTTL time.Duration, opts ...inmemorycacheadapters.Option) func() (cacheadapters.CacheSessionAdapter, error) {
 	return func() (cacheadapters.CacheSessionAdapter, error) {
-		adapter, err := inmemorycacheadapters.New(defaultTTL)
-		return adapter.(cacheadapters.CacheSessionAdapter), err
+		adapter, err := inmemorycacheadapters.New(defaultTTL, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter, nil
 	}
 }
 
-// newInMemoryTestSuite creates a new test suite with tests for In-Memory adapters and sessions.
-func newInMemoryTestSuite(t *testing.T, defaultTTL time.Duration) *InMemoryAdapterTestSuite {
+// newInMemoryTestSuite creates a new test suite with tests for In-Memory adapters and sessions,
+// created with the given options.
+func newInMemoryTestSuite(t *testing.T, defaultTTL time.Duration, opts ...inmemorycacheadapters.Option) *InMemoryAdapterTestSuite {
 	var suite suite.Suite
 
 	return &InMemoryAdapterTestSuite{
@@ -60,8 +70,8 @@ func newInMemoryTestSuite(t *testing.T, defaultTTL time.Duration) *InMemoryAdapt
 		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
 			Suite:      &suite,
 			DefaultTTL: defaultTTL,
-			NewAdapter: newTestAdapterFunc(defaultTTL),
-			NewSession: newTestSessionFunc(t, defaultTTL),
+			NewAdapter: newTestAdapterFunc(defaultTTL, opts...),
+			NewSession: newTestSessionFunc(t, defaultTTL, opts...),
 			SleepFunc:  testSleepFunc(),
 		},
 	}
@@ -72,6 +82,15 @@ func TestInMemoryAdapterSuite(t *testing.T) {
 	suite.Run(t, newInMemoryTestSuite(t, defaultTTL))
 }
 
+func TestInMemoryAdapterSuite_Bounded(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newInMemoryTestSuite(t, defaultTTL,
+		inmemorycacheadapters.WithMaxEntries(1000),
+		inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.LFU),
+		inmemorycacheadapters.WithAdmission(),
+	))
+}
+
 func (suite *InMemoryAdapterTestSuite) TestNew_NegativeTTL() {
 	adapter, err := inmemorycacheadapters.New(-time.Second)
 	suite.Require().Nil(adapter, "Should be nil on negative time duration for TTL")
@@ -195,3 +214,47 @@ func (suite *InMemoryAdapterTestSuite) TestTTL_SetOverExpired() {
 	err = adapter.SetTTL(testutil.TestKeyForSetTTL, (*duration)*2)
 	suite.Require().ErrorIs(err, nil, "Should not error on setting TTL over expired key, since it's removed")
 }
+
+func (suite *InMemoryAdapterTestSuite) TestTTL_SetKeepsValueAndVersion() {
+	adapter, err := suite.NewAdapter()
+	suite.Require().NoError(err, "Should not error on creating a new valid adapter.")
+
+	err = adapter.Set(testutil.TestKeyForSetTTL, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual testutil.TestStruct
+	version, err := adapter.GetWithVersion(testutil.TestKeyForSetTTL, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	err = adapter.SetTTL(testutil.TestKeyForSetTTL, time.Hour)
+	suite.Require().NoError(err, "Should not error on valid SetTTL")
+
+	remainingTTL, err := adapter.TTL(testutil.TestKeyForSetTTL)
+	suite.Require().NoError(err, "Should not error on valid TTL")
+	suite.Require().Greater(remainingTTL, time.Minute, "Should apply the new TTL")
+
+	err = adapter.CompareAndSwap(testutil.TestKeyForSetTTL, version, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should keep the version of the value when changing only its TTL")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_NilCodec() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithCodec(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_WithCodec() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithCodec(cacheadapters.GobCodec{}))
+	suite.Require().NoError(err, "Should not give error on valid codec")
+
+	// complex numbers cannot be encoded as JSON,
+	// so this only works if the codec is used.
+	expected := complex(1, 2)
+	err = adapter.Set(testutil.TestKeyForSet, expected, nil)
+	suite.Require().NoError(err, "Should encode the value with the codec")
+
+	var actual complex128
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should decode the value with the codec")
+	suite.Require().Equal(expected, actual, "Should be equal to the value set")
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+)
+
+// The parameters of the benchmarks.
+const (
+	benchmarkKeys       = 4096 // The number of keys read and written.
+	benchmarkGoroutines = 64   // The number of goroutines accessing the adapter.
+)
+
+// benchmarkValue is the value read and written by the benchmarks,
+// big enough to make its encoding and decoding noticeable.
+type benchmarkValue struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Tags     []string          `json:"tags"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// benchmarkCache is the part of the adapter used by the benchmarks.
+type benchmarkCache interface {
+	Get(key string, resultRef interface{}) error
+	Set(key string, object interface{}, TTL *time.Duration) error
+}
+
+// benchmarkCacheConfig builds an adapter compared by the benchmarks.
+type benchmarkCacheConfig struct {
+	name     string
+	newCache func() (benchmarkCache, error)
+}
+
+// adapterConfig returns a benchmarkCacheConfig building an
+// InMemoryAdapter with the given options.
+func adapterConfig(name string, opts ...inmemorycacheadapters.Option) benchmarkCacheConfig {
+	return benchmarkCacheConfig{name, func() (benchmarkCache, error) {
+		adapter, err := inmemorycacheadapters.New(time.Hour, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter, nil
+	}}
+}
+
+// runCacheBenchmark runs a mix of reads and writes on the cache, with
+// the given percentage of reads, from benchmarkGoroutines goroutines.
+func runCacheBenchmark(b *testing.B, readPercent int, config benchmarkCacheConfig) {
+	cache, err := config.newCache()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	keys := make([]string, benchmarkKeys)
+	value := benchmarkValue{
+		Name:     "a benchmark value",
+		Tags:     []string{"first", "second", "third"},
+		Metadata: map[string]string{"first": "1", "second": "2"},
+	}
+
+	for i := range keys {
+		keys[i] = fmt.Sprintf("benchmark:key:%d", i)
+		value.ID = i
+		if err := cache.Set(keys[i], value, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var seed int64
+	parallelism := benchmarkGoroutines / runtime.GOMAXPROCS(0)
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	b.SetParallelism(parallelism)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		random := rand.New(rand.NewSource(atomic.AddInt64(&seed, 1)))
+
+		var result benchmarkValue
+		for pb.Next() {
+			key := keys[random.Intn(len(keys))]
+			if random.Intn(100) < readPercent {
+				cache.Get(key, &result)
+			} else {
+				cache.Set(key, value, nil)
+			}
+		}
+	})
+}
+
+// BenchmarkInMemoryAdapter uses only the options available before the
+// entries were sharded, so that this file can be copied into a checkout
+// of that version to compare it with the current one (see the README).
+func BenchmarkInMemoryAdapter(b *testing.B) {
+	runCacheBenchmarks(b,
+		adapterConfig("Unlimited"),
+		adapterConfig("LRU", inmemorycacheadapters.WithMaxEntries(2*benchmarkKeys)),
+	)
+}
+
+// runCacheBenchmarks runs every mix of reads and writes on every config.
+func runCacheBenchmarks(b *testing.B, configs ...benchmarkCacheConfig) {
+	mixes := []struct {
+		name        string
+		readPercent int
+	}{
+		{"ReadHeavy", 90},
+		{"WriteHeavy", 10},
+	}
+
+	for _, mix := range mixes {
+		for _, config := range configs {
+			b.Run(mix.name+"/"+config.name, func(b *testing.B) {
+				runCacheBenchmark(b, mix.readPercent, config)
+			})
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// testEvictionKey returns the i-th key used in the eviction tests.
+func testEvictionKey(i int) string {
+	return fmt.Sprintf("%s:%d", testutil.TestKeyForMany, i)
+}
+
+// testValueOfSize returns a JSON value whose encoding has the given length.
+func testValueOfSize(size int) cacheadapters.RawMessage {
+	return cacheadapters.RawMessage(`"` + strings.Repeat("a", size-2) + `"`)
+}
+
+// newBoundedAdapter creates an InMemoryAdapter
+// holding at most the given number of entries.
+func (suite *InMemoryAdapterTestSuite) newBoundedAdapter(maxEntries int, opts ...inmemorycacheadapters.Option) *inmemorycacheadapters.InMemoryAdapter {
+	opts = append([]inmemorycacheadapters.Option{inmemorycacheadapters.WithMaxEntries(maxEntries)}, opts...)
+
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, opts...)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	return adapter
+}
+
+// requirePresent checks which of the keys are in cache.
+func (suite *InMemoryAdapterTestSuite) requirePresent(adapter cacheadapters.CacheAdapter, expected map[string]bool) {
+	for key, present := range expected {
+		exists, err := adapter.Exists(key)
+		suite.Require().NoError(err, "Should not error on valid Exists")
+		suite.Require().Equal(present, exists, "Should find the key %s only if not evicted", key)
+	}
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_InvalidEvictionOptions() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithMaxEntries(0))
+	suite.Require().Nil(adapter, "Should be nil on non-positive max entries")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidMaxEntries, "Should give error on non-positive max entries")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.EvictionPolicy(42)))
+	suite.Require().Nil(adapter, "Should be nil on unknown eviction policy")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrUnknownEvictionPolicy, "Should give error on unknown eviction policy")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_LRU() {
+	adapter := suite.newBoundedAdapter(2)
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testutil.TestValue, nil), "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	suite.Require().NoError(adapter.Get(testEvictionKey(1), &actual), "Should find the first key")
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), "Should not error on valid Set")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(2): false,
+		testEvictionKey(3): true,
+	})
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 2, Evictions: 1}, adapter.Stats(), "Should count the evictions")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_FIFO() {
+	adapter := suite.newBoundedAdapter(2, inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.FIFO))
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testutil.TestValue, nil), "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	suite.Require().NoError(adapter.Get(testEvictionKey(1), &actual), "Should find the first key")
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), "Should not error on valid Set")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): false,
+		testEvictionKey(2): true,
+		testEvictionKey(3): true,
+	})
+	suite.Require().Equal(uint64(1), adapter.Stats().Evictions, "Should count the evictions")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_LFU() {
+	adapter := suite.newBoundedAdapter(2, inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.LFU))
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testutil.TestValue, nil), "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	suite.Require().NoError(adapter.Get(testEvictionKey(1), &actual), "Should find the first key")
+	suite.Require().NoError(adapter.Get(testEvictionKey(1), &actual), "Should find the first key")
+	suite.Require().NoError(adapter.Get(testEvictionKey(2), &actual), "Should find the second key")
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), "Should not error on valid Set")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(2): false,
+		testEvictionKey(3): true,
+	})
+
+	// the new key is the least frequently used now.
+	suite.Require().NoError(adapter.Set(testEvictionKey(4), testutil.TestValue, nil), "Should not error on valid Set")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(3): false,
+		testEvictionKey(4): true,
+	})
+	suite.Require().Equal(uint64(2), adapter.Stats().Evictions, "Should count the evictions")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_OnlyNewKeys() {
+	adapter := suite.newBoundedAdapter(2)
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on overwriting Set")
+
+	_, err := adapter.Increment(testEvictionKey(2)+":counter", 1, nil)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(uint64(1), adapter.Stats().Evictions, "Should evict only when writing a new key in a full cache")
+
+	suite.Require().NoError(adapter.Delete(testEvictionKey(1)), "Should not error on valid Delete")
+	suite.Require().NoError(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().Equal(uint64(1), adapter.Stats().Evictions, "Should not evict when a deleted key made room")
+
+	suite.Require().NoError(adapter.Clear(), "Should not error on valid Clear")
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 2, Evictions: 1}, adapter.Stats(), "Should not evict after Clear made room")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_Admission() {
+	adapter := suite.newBoundedAdapter(2, inmemorycacheadapters.WithAdmission())
+
+	var actual testutil.TestStruct
+	for i := 1; i <= 2; i++ {
+		suite.Require().NoError(adapter.Set(testEvictionKey(i), testutil.TestValue, nil), "Should not error on valid Set")
+		suite.Require().NoError(adapter.Get(testEvictionKey(i), &actual), "Should find the key")
+		suite.Require().NoError(adapter.Get(testEvictionKey(i), &actual), "Should find the key")
+	}
+
+	// a key used only once does not replace the popular ones.
+	suite.Require().ErrorIs(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), inmemorycacheadapters.ErrRejected, "Should report the rejected Set")
+
+	written, err := adapter.SetIfAbsent(testEvictionKey(4), testutil.TestValue, nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrRejected, "Should report the rejected SetIfAbsent")
+	suite.Require().False(written, "Should report the rejected write")
+
+	_, err = adapter.Increment(testEvictionKey(5), 1, nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrRejected, "Should not return a counter which has not been stored")
+
+	result, err := adapter.SetMany(map[string]interface{}{testEvictionKey(6): testutil.TestValue}, nil)
+	suite.Require().NoError(err, "Should not error on SetMany")
+	suite.Require().ErrorIs(result[testEvictionKey(6)], inmemorycacheadapters.ErrRejected, "Should report the rejected key of SetMany")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(2): true,
+		testEvictionKey(3): false,
+		testEvictionKey(5): false,
+	})
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 2, Rejections: 4}, adapter.Stats(), "Should count the rejections")
+
+	// the misses count as accesses as well.
+	for i := 0; i < 5; i++ {
+		suite.Require().ErrorIs(adapter.Get(testEvictionKey(3), &actual), cacheadapters.ErrNotFound, "Should not find the rejected key")
+	}
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(3), testutil.TestValue, nil), "Should not error on valid Set")
+	suite.requirePresent(adapter, map[string]bool{testEvictionKey(3): true})
+	suite.Require().Equal(uint64(1), adapter.Stats().Evictions, "Should admit the key once popular")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_AdmissionComparesEveryVictim() {
+	// every value costs 10 bytes, the popular one 20.
+	adapter := suite.newBudgetedAdapter(30, inmemorycacheadapters.WithAdmission())
+
+	var actual string
+	for i := 1; i <= 3; i++ {
+		suite.Require().NoError(adapter.Set(testEvictionKey(i), "12345678", nil), "Should not error on valid Set")
+	}
+
+	for i := 0; i < 5; i++ {
+		suite.Require().NoError(adapter.Get(testEvictionKey(2), &actual), "Should find the key")
+		suite.Require().NoError(adapter.Get(testEvictionKey(3), &actual), "Should find the key")
+	}
+
+	// the new key is more popular than the first
+	// victim, but not than the second one.
+	for i := 0; i < 2; i++ {
+		suite.Require().ErrorIs(adapter.Get(testEvictionKey(4), &actual), cacheadapters.ErrNotFound, "Should not find the new key")
+	}
+
+	err := adapter.Set(testEvictionKey(4), "123456789012345678", nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrRejected, "Should not evict a popular entry for the new key")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(2): true,
+		testEvictionKey(3): true,
+		testEvictionKey(4): false,
+	})
+	suite.Require().Zero(adapter.Stats().Evictions, "Should not evict anything for a rejected write")
+}
+
+// newBudgetedAdapter creates an InMemoryAdapter with the given byte budget.
+func (suite *InMemoryAdapterTestSuite) newBudgetedAdapter(maxBytes int64, opts ...inmemorycacheadapters.Option) *inmemorycacheadapters.InMemoryAdapter {
+	opts = append([]inmemorycacheadapters.Option{inmemorycacheadapters.WithMaxBytes(maxBytes)}, opts...)
+
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, opts...)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	return adapter
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_InvalidBudgetOptions() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithMaxBytes(0))
+	suite.Require().Nil(adapter, "Should be nil on non-positive max bytes")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidMaxBytes, "Should give error on non-positive max bytes")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithMaxItemBytes(-1))
+	suite.Require().Nil(adapter, "Should be nil on non-positive max item bytes")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidMaxBytes, "Should give error on non-positive max item bytes")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithCostFunc(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil cost function")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrNilCostFunc, "Should give error on nil cost function")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestBudget_EvictsUntilFits() {
+	adapter := suite.newBudgetedAdapter(100)
+
+	for i := 1; i <= 3; i++ {
+		suite.Require().NoError(adapter.Set(testEvictionKey(i), testValueOfSize(40), nil), "Should not error on valid Set")
+	}
+
+	suite.requirePresent(adapter, map[string]bool{testEvictionKey(1): false})
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 2, Bytes: 80, Evictions: 1}, adapter.Stats(), "Should evict to stay in the budget")
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(4), testValueOfSize(90), nil), "Should not error on valid Set")
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(2): false,
+		testEvictionKey(3): false,
+		testEvictionKey(4): true,
+	})
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 1, Bytes: 90, Evictions: 3}, adapter.Stats(), "Should evict many entries for a big value")
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(4), testValueOfSize(100), nil), "Should not error on overwriting Set")
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 1, Bytes: 100, Evictions: 3}, adapter.Stats(), "Should count the cost of the new value only")
+
+	suite.Require().NoError(adapter.Delete(testEvictionKey(4)), "Should not error on valid Delete")
+	suite.Require().Equal(int64(0), adapter.Stats().Bytes, "Should release the cost of the deleted entries")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestBudget_OverwriteOldest() {
+	adapter := suite.newBudgetedAdapter(100, inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.FIFO))
+
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testValueOfSize(40), nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(2), testValueOfSize(40), nil), "Should not error on valid Set")
+	suite.Require().NoError(adapter.Set(testEvictionKey(1), testValueOfSize(70), nil), "Should not error on overwriting Set")
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(1): true,
+		testEvictionKey(2): false,
+	})
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 1, Bytes: 70, Evictions: 1}, adapter.Stats(), "Should not count the overwritten value as evicted")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestBudget_ItemTooLarge() {
+	adapter := suite.newBudgetedAdapter(100, inmemorycacheadapters.WithMaxItemBytes(50))
+
+	err := adapter.Set(testEvictionKey(1), testValueOfSize(60), nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrItemTooLarge, "Should reject the values over the item maximum")
+
+	result, err := adapter.SetMany(map[string]interface{}{
+		testEvictionKey(1): testValueOfSize(60),
+		testEvictionKey(2): testValueOfSize(40),
+	}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+	suite.Require().ErrorIs(result[testEvictionKey(1)], inmemorycacheadapters.ErrItemTooLarge, "Should reject the values over the item maximum")
+	suite.Require().NoError(result[testEvictionKey(2)], "Should write the other values")
+
+	written, err := adapter.SetIfAbsent(testEvictionKey(3), testValueOfSize(60), nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrItemTooLarge, "Should reject the values over the item maximum")
+	suite.Require().False(written, "Should not write the rejected value")
+
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 1, Bytes: 40}, adapter.Stats(), "Should not store the rejected values")
+
+	unlimitedItems := suite.newBudgetedAdapter(100)
+	err = unlimitedItems.Set(testEvictionKey(1), testValueOfSize(101), nil)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrItemTooLarge, "Should reject the values over the whole budget")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestBudget_CostFunc() {
+	adapter := suite.newBudgetedAdapter(2, inmemorycacheadapters.WithCostFunc(func(key string, content []byte) int64 {
+		return 1
+	}))
+
+	for i := 1; i <= 3; i++ {
+		suite.Require().NoError(adapter.Set(testEvictionKey(i), testValueOfSize(40), nil), "Should not error on valid Set")
+	}
+
+	suite.Require().Equal(inmemorycacheadapters.Stats{Entries: 2, Bytes: 2, Evictions: 1}, adapter.Stats(), "Should use the cost function")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestEviction_SetTTLIsNotAnAccess() {
+	adapter := suite.newBoundedAdapter(2)
+
+	adapter.Set(testEvictionKey(0), testutil.TestValue, nil)
+	adapter.Set(testEvictionKey(1), testutil.TestValue, nil)
+
+	err := adapter.SetTTL(testEvictionKey(0), time.Hour)
+	suite.Require().NoError(err, "Should not error on valid SetTTL")
+
+	adapter.Set(testEvictionKey(2), testutil.TestValue, nil)
+
+	suite.requirePresent(adapter, map[string]bool{
+		testEvictionKey(0): false,
+		testEvictionKey(1): true,
+		testEvictionKey(2): true,
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// fakeClock is a Clock whose time changes only when advanced,
+// and whose tickers tick only when told to.
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// fakeTicker is the Ticker of the fakeClock.
+type fakeTicker struct {
+	c       chan time.Time
+	stopped chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.now
+}
+
+func (fc *fakeClock) NewTicker(interval time.Duration) inmemorycacheadapters.Ticker {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	// unbuffered, so that a tick is delivered only
+	// when the janitor is waiting for it.
+	ticker := &fakeTicker{c: make(chan time.Time), stopped: make(chan struct{})}
+	fc.tickers = append(fc.tickers, ticker)
+
+	return ticker
+}
+
+// advance moves the time of the clock forward.
+func (fc *fakeClock) advance(duration time.Duration) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.now = fc.now.Add(duration)
+}
+
+// ticker returns the last ticker created by the clock.
+func (fc *fakeClock) ticker() *fakeTicker {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.tickers[len(fc.tickers)-1]
+}
+
+func (ft *fakeTicker) C() <-chan time.Time {
+	return ft.c
+}
+
+func (ft *fakeTicker) Stop() {
+	close(ft.stopped)
+}
+
+// tick delivers a tick, returns false if nobody is waiting for it.
+func (ft *fakeTicker) tick() bool {
+	select {
+	case ft.c <- time.Time{}:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+// newJanitorAdapter creates an InMemoryAdapter
+// with a janitor and the given clock.
+func (suite *InMemoryAdapterTestSuite) newJanitorAdapter(clock inmemorycacheadapters.Clock, opts ...inmemorycacheadapters.Option) *inmemorycacheadapters.InMemoryAdapter {
+	opts = append([]inmemorycacheadapters.Option{
+		inmemorycacheadapters.WithClock(clock),
+		inmemorycacheadapters.WithJanitor(time.Minute),
+	}, opts...)
+
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, opts...)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	return adapter
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_InvalidJanitorOptions() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithClock(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil clock")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrNilClock, "Should give error on nil clock")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithJanitor(0))
+	suite.Require().Nil(adapter, "Should be nil on non-positive janitor interval")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidJanitorInterval, "Should give error on non-positive janitor interval")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithJanitorSampling(0))
+	suite.Require().Nil(adapter, "Should be nil on non-positive sample size")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidSampleSize, "Should give error on non-positive sample size")
+
+	adapter, _ = inmemorycacheadapters.New(time.Second)
+	err = adapter.StartJanitor()
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrJanitorNotConfigured, "Should not start a janitor never configured")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestClock_Expiration() {
+	clock := newFakeClock()
+	adapter, _ := inmemorycacheadapters.New(time.Minute, inmemorycacheadapters.WithClock(clock))
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	clock.advance(30 * time.Second)
+
+	remainingTTL, err := adapter.TTL(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should find the key not expired yet")
+	suite.Require().Equal(30*time.Second, remainingTTL, "Should compute the TTL with the clock")
+
+	clock.advance(time.Minute)
+
+	exists, err := adapter.Exists(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+	suite.Require().False(exists, "Should expire the key with the clock")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestClock_ExpirationBoundary() {
+	clock := newFakeClock()
+	adapter, _ := inmemorycacheadapters.New(time.Minute, inmemorycacheadapters.WithClock(clock))
+
+	// every operation reads its own key, not removed by the others.
+	for i := 1; i <= 5; i++ {
+		err := adapter.Set(testEvictionKey(i), testutil.TestValue, nil)
+		suite.Require().NoError(err, "Should not error on valid Set")
+	}
+
+	clock.advance(time.Minute - time.Nanosecond)
+
+	var actual testutil.TestStruct
+	err := adapter.Get(testEvictionKey(1), &actual)
+	suite.Require().NoError(err, "Should find the key before its expiration time")
+
+	clock.advance(time.Nanosecond)
+
+	err = adapter.Get(testEvictionKey(1), &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should expire the key at its expiration time on Get")
+
+	result, err := adapter.GetMany(map[string]interface{}{testEvictionKey(2): &actual})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().ErrorIs(result[testEvictionKey(2)], cacheadapters.ErrNotFound, "Should expire the key at its expiration time on GetMany")
+
+	_, err = adapter.TTL(testEvictionKey(3))
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should expire the key at its expiration time on TTL")
+
+	written, err := adapter.SetIfPresent(testEvictionKey(4), testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().False(written, "Should expire the key at its expiration time on SetIfPresent")
+
+	iterator, err := adapter.Scan(testEvictionKey(5))
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+	suite.Require().False(iterator.Next(), "Should expire the key at its expiration time on Scan")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestJanitor_RemovesExpired() {
+	clock := newFakeClock()
+	adapter := suite.newJanitorAdapter(clock)
+	defer adapter.Close()
+
+	shortTTL := time.Second
+	for i := 1; i <= 3; i++ {
+		err := adapter.Set(testEvictionKey(i), testutil.TestValue, &shortTTL)
+		suite.Require().NoError(err, "Should not error on valid Set")
+	}
+
+	longTTL := time.Hour
+	err := adapter.Set(testEvictionKey(4), testutil.TestValue, &longTTL)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	clock.advance(time.Minute)
+	suite.Require().True(clock.ticker().tick(), "Should run the janitor")
+	// the second tick is delivered only after the first run.
+	suite.Require().True(clock.ticker().tick(), "Should run the janitor")
+
+	suite.Require().Equal(1, adapter.Stats().Entries, "Should remove the expired entries never read")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestJanitor_Sampling() {
+	clock := newFakeClock()
+	adapter := suite.newJanitorAdapter(clock, inmemorycacheadapters.WithJanitorSampling(2))
+	defer adapter.Close()
+
+	shortTTL := time.Second
+	for i := 1; i <= 20; i++ {
+		err := adapter.Set(testEvictionKey(i), testutil.TestValue, &shortTTL)
+		suite.Require().NoError(err, "Should not error on valid Set")
+	}
+
+	clock.advance(time.Minute)
+	suite.Require().True(clock.ticker().tick(), "Should run the janitor")
+	suite.Require().True(clock.ticker().tick(), "Should run the janitor")
+
+	// every sample is fully expired, so the janitor keeps sampling.
+	suite.Require().Equal(0, adapter.Stats().Entries, "Should remove the expired entries in samples")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestJanitor_StartStop() {
+	clock := newFakeClock()
+	adapter := suite.newJanitorAdapter(clock)
+
+	first := clock.ticker()
+
+	session, err := adapter.OpenSession()
+	suite.Require().NoError(err, "Should not error on valid OpenSession")
+	suite.Require().NoError(session.Close(), "Should not error on valid Close")
+	suite.Require().True(first.tick(), "Should not stop the janitor closing a session")
+
+	adapter.StopJanitor()
+	<-first.stopped
+	suite.Require().False(first.tick(), "Should stop the janitor goroutine")
+
+	adapter.StopJanitor()
+
+	suite.Require().NoError(adapter.StartJanitor(), "Should restart the janitor")
+	suite.Require().NoError(adapter.StartJanitor(), "Should not start the janitor twice")
+	suite.Require().Len(clock.tickers, 2, "Should start a single janitor")
+
+	second := clock.ticker()
+	suite.Require().True(second.tick(), "Should run the restarted janitor")
+
+	suite.Require().NoError(adapter.Close(), "Should not error on valid Close")
+	<-second.stopped
+	suite.Require().False(second.tick(), "Should stop the janitor goroutine closing the adapter")
+}
+
+// janitorGoroutines returns the number of running janitor goroutines,
+// recognized by the function which started them.
+func janitorGoroutines() int {
+	stacks := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(stacks, true)
+		if n < len(stacks) {
+			result := 0
+			for _, line := range strings.Split(string(stacks[:n]), "\n") {
+				if strings.HasPrefix(line, "created by ") && strings.Contains(line, ".(*InMemoryAdapter).StartJanitor") {
+					result++
+				}
+			}
+
+			return result
+		}
+
+		stacks = make([]byte, 2*len(stacks))
+	}
+}
+
+func (suite *InMemoryAdapterTestSuite) TestJanitor_NoGoroutineLeak() {
+	before := janitorGoroutines()
+
+	adapters := make([]*inmemorycacheadapters.InMemoryAdapter, 10)
+	for i := range adapters {
+		adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, inmemorycacheadapters.WithJanitor(time.Millisecond))
+		suite.Require().NoError(err, "Should not error on valid options")
+		adapters[i] = adapter
+	}
+
+	suite.Require().Equal(before+len(adapters), janitorGoroutines(), "Should start a janitor goroutine for each adapter")
+
+	for _, adapter := range adapters {
+		suite.Require().NoError(adapter.Close(), "Should not error on valid Close")
+	}
+
+	suite.Require().Equal(before, janitorGoroutines(), "Should not leak the janitor goroutines after Close")
+}
@@ -0,0 +1,275 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// testObject is the value stored in the object mode tests.
+type testObject struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// cloneTestObject is the Cloner of the object mode tests, returning
+// a deep copy of a *testObject and any other object as it is.
+func cloneTestObject(object interface{}) (interface{}, error) {
+	original, ok := object.(*testObject)
+	if !ok {
+		return object, nil
+	}
+
+	return &testObject{
+		Name: original.Name,
+		Tags: append([]string(nil), original.Tags...),
+	}, nil
+}
+
+// newObjectTestSuite creates a new test suite for In-Memory
+// adapters and sessions in the given object mode.
+func newObjectTestSuite(t *testing.T, mode inmemorycacheadapters.ObjectMode) *InMemoryAdapterTestSuite {
+	result := newInMemoryTestSuite(t, 1*time.Second,
+		inmemorycacheadapters.WithObjectMode(mode),
+		inmemorycacheadapters.WithCloner(cloneTestObject),
+	)
+	result.StoresObjects = true
+
+	return result
+}
+
+func TestInMemoryAdapterSuite_SharedObjects(t *testing.T) {
+	suite.Run(t, newObjectTestSuite(t, inmemorycacheadapters.SharedObjects))
+}
+
+func TestInMemoryAdapterSuite_ShallowCopies(t *testing.T) {
+	suite.Run(t, newObjectTestSuite(t, inmemorycacheadapters.ShallowCopies))
+}
+
+func TestInMemoryAdapterSuite_DeepCopies(t *testing.T) {
+	suite.Run(t, newObjectTestSuite(t, inmemorycacheadapters.DeepCopies))
+}
+
+// newObjectAdapter creates an InMemoryAdapter in the given object mode.
+func (suite *InMemoryAdapterTestSuite) newObjectAdapter(mode inmemorycacheadapters.ObjectMode) cacheadapters.CacheAdapter {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL,
+		inmemorycacheadapters.WithObjectMode(mode),
+		inmemorycacheadapters.WithCloner(cloneTestObject),
+	)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	return adapter
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_InvalidObjectOptions() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithObjectMode(-1))
+	suite.Require().Nil(adapter, "Should be nil on unknown object mode")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrUnknownObjectMode, "Should give error on unknown object mode")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithCloner(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil cloner")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrNilCloner, "Should give error on nil cloner")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithObjectMode(inmemorycacheadapters.DeepCopies))
+	suite.Require().Nil(adapter, "Should be nil on deep copies without cloner")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrClonerRequired, "Should give error on deep copies without cloner")
+
+	adapter, err = inmemorycacheadapters.New(time.Second,
+		inmemorycacheadapters.WithObjectMode(inmemorycacheadapters.SharedObjects),
+		inmemorycacheadapters.WithMaxBytes(1024),
+	)
+	suite.Require().Nil(adapter, "Should be nil on object mode with byte limits")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrObjectModeByteLimits, "Should give error on object mode with byte limits")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_Shared() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.SharedObjects)
+
+	original := &testObject{Name: "original", Tags: []string{"first"}}
+	err := adapter.Set(testutil.TestKeyForSet, original, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual *testObject
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Same(original, actual, "Should share the pointer")
+
+	var actualValue testObject
+	err = adapter.Get(testutil.TestKeyForSet, &actualValue)
+	suite.Require().NoError(err, "Should not error on Get into the pointed type")
+	suite.Require().Equal(*original, actualValue, "Should assign the pointed value")
+
+	channel := make(chan int)
+	err = adapter.Set(testutil.TestKeyForSet, channel, nil)
+	suite.Require().NoError(err, "Should store values which cannot be encoded")
+
+	var actualChannel chan int
+	err = adapter.Get(testutil.TestKeyForSet, &actualChannel)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal(channel, actualChannel, "Should get the same channel")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_ShallowCopies() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.ShallowCopies)
+
+	original := &testObject{Name: "original", Tags: []string{"first"}}
+	err := adapter.Set(testutil.TestKeyForSet, original, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	original.Name = "changed after Set"
+
+	var actual *testObject
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().NotSame(original, actual, "Should not share the pointer")
+	suite.Require().Equal("original", actual.Name, "Should store a copy of the value")
+
+	actual.Name = "changed after Get"
+	actual.Tags[0] = "shared"
+
+	var again *testObject
+	err = adapter.Get(testutil.TestKeyForSet, &again)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal("original", again.Name, "Should return a copy of the value")
+	suite.Require().Equal([]string{"shared"}, again.Tags, "Should share what the value references")
+
+	tags := []string{"first"}
+	err = adapter.Set(testutil.TestKeyForSet, tags, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	tags[0] = "changed after Set"
+
+	var actualTags []string
+	err = adapter.Get(testutil.TestKeyForSet, &actualTags)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal([]string{"first"}, actualTags, "Should store a copy of the elements of a slice")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_DeepCopies() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.DeepCopies)
+
+	original := &testObject{Name: "original", Tags: []string{"first"}}
+	err := adapter.Set(testutil.TestKeyForSet, original, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	original.Tags[0] = "changed after Set"
+
+	var actual *testObject
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal([]string{"first"}, actual.Tags, "Should store a deep copy of the value")
+
+	actual.Tags[0] = "changed after Get"
+
+	var again *testObject
+	err = adapter.Get(testutil.TestKeyForSet, &again)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal([]string{"first"}, again.Tags, "Should return a deep copy of the value")
+
+	failingAdapter, _ := inmemorycacheadapters.New(testutil.DummyTTL,
+		inmemorycacheadapters.WithObjectMode(inmemorycacheadapters.DeepCopies),
+		inmemorycacheadapters.WithCloner(func(interface{}) (interface{}, error) {
+			return nil, testutil.ErrTestingFailureCheck
+		}),
+	)
+
+	err = failingAdapter.Set(testutil.TestKeyForSet, original, nil)
+	suite.Require().ErrorIs(err, testutil.ErrTestingFailureCheck, "Should give the error of the cloner")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_TypeMismatch() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.SharedObjects)
+
+	err := adapter.Set(testutil.TestKeyForSet, testObject{Name: "original"}, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual string
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrTypeMismatch, "Should give error on a reference of a different type")
+
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForSet: &actual})
+	suite.Require().NoError(err, "Should not error on the whole GetMany")
+	suite.Require().ErrorIs(result[testutil.TestKeyForSet], inmemorycacheadapters.ErrTypeMismatch, "Should give error on a reference of a different type")
+
+	err = adapter.Get(testutil.TestKeyForSet, actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrGetRequiresObjectReference, "Should give error on a value instead of a reference")
+
+	var anything interface{}
+	err = adapter.Get(testutil.TestKeyForSet, &anything)
+	suite.Require().NoError(err, "Should assign any value to an interface")
+	suite.Require().Equal(testObject{Name: "original"}, anything, "Should assign the value to the interface")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_RawMessages() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.SharedObjects)
+
+	err := adapter.Set(testutil.TestKeyForSet, &testObject{Name: "original"}, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var raw cacheadapters.RawMessage
+	err = adapter.Get(testutil.TestKeyForSet, &raw)
+	suite.Require().NoError(err, "Should not error on Get into a RawMessage")
+	suite.Require().JSONEq(`{"name":"original","tags":null}`, string(raw), "Should encode the object with the codec")
+
+	err = adapter.Set(testutil.TestKeyForSet, cacheadapters.RawMessage(`{"name":"raw"}`), nil)
+	suite.Require().NoError(err, "Should not error on Set of a RawMessage")
+
+	var actual testObject
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on Get of a RawMessage")
+	suite.Require().Equal("raw", actual.Name, "Should decode the RawMessage with the codec")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestObjects_Counters() {
+	adapter := suite.newObjectAdapter(inmemorycacheadapters.SharedObjects)
+
+	err := adapter.Set(testutil.TestKeyForSet, 5, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	counter, err := adapter.Increment(testutil.TestKeyForSet, 1, nil)
+	suite.Require().NoError(err, "Should increment an integer object")
+	suite.Require().Equal(int64(6), counter, "Should start from the integer object")
+
+	var actual int64
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on Get of a counter")
+	suite.Require().Equal(int64(6), actual, "Should get the counter")
+
+	err = adapter.Set(testutil.TestKeyForSet, testObject{}, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	_, err = adapter.Increment(testutil.TestKeyForSet, 1, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidCounter, "Should not increment a non integer object")
+
+	err = adapter.Set(testutil.TestKeyForSet, uint64(math.MaxInt64), nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	counter, err = adapter.Increment(testutil.TestKeyForSet, -1, nil)
+	suite.Require().NoError(err, "Should increment an unsigned integer object fitting in an int64")
+	suite.Require().Equal(int64(math.MaxInt64-1), counter, "Should start from the unsigned integer object")
+
+	err = adapter.Set(testutil.TestKeyForSet, uint64(math.MaxInt64)+1, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	_, err = adapter.Increment(testutil.TestKeyForSet, -1, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidCounter, "Should not increment an unsigned integer object overflowing an int64")
+}
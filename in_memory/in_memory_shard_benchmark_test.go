@@ -0,0 +1,32 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"testing"
+
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+)
+
+func BenchmarkInMemoryAdapterShards(b *testing.B) {
+	runCacheBenchmarks(b,
+		adapterConfig("SingleShard", inmemorycacheadapters.WithShards(1)),
+		adapterConfig("Sharded", inmemorycacheadapters.WithShards(16)),
+		adapterConfig("SingleShardLRU", inmemorycacheadapters.WithShards(1), inmemorycacheadapters.WithMaxEntries(2*benchmarkKeys)),
+		adapterConfig("ShardedLRU", inmemorycacheadapters.WithShards(16), inmemorycacheadapters.WithMaxEntries(2*benchmarkKeys)),
+		adapterConfig("ShardedFIFO", inmemorycacheadapters.WithShards(16), inmemorycacheadapters.WithMaxEntries(2*benchmarkKeys), inmemorycacheadapters.WithEvictionPolicy(inmemorycacheadapters.FIFO)),
+		adapterConfig("ShardedShallowCopies", inmemorycacheadapters.WithShards(16), inmemorycacheadapters.WithObjectMode(inmemorycacheadapters.ShallowCopies)),
+	)
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestInMemoryAdapterSuite_Sharded(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newInMemoryTestSuite(t, defaultTTL,
+		inmemorycacheadapters.WithShards(8),
+		inmemorycacheadapters.WithMaxEntries(1000),
+		inmemorycacheadapters.WithMaxBytes(1<<20),
+	))
+}
+
+func (suite *InMemoryAdapterTestSuite) TestNew_InvalidShardOptions() {
+	adapter, err := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithShards(0))
+	suite.Require().Nil(adapter, "Should be nil on non-positive shards")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrInvalidShards, "Should give error on non-positive shards")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithShards(4), inmemorycacheadapters.WithMaxEntries(3))
+	suite.Require().Nil(adapter, "Should be nil on more shards than entries")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrTooManyShards, "Should give error on more shards than entries")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithShards(4), inmemorycacheadapters.WithMaxBytes(3))
+	suite.Require().Nil(adapter, "Should be nil on more shards than bytes")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrTooManyShards, "Should give error on more shards than bytes")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithShards(4), inmemorycacheadapters.WithMaxBytes(1000), inmemorycacheadapters.WithMaxItemBytes(251))
+	suite.Require().Nil(adapter, "Should be nil on items larger than the budget of a shard")
+	suite.Require().ErrorIs(err, inmemorycacheadapters.ErrMaxItemBytesTooLarge, "Should give error on items larger than the budget of a shard")
+
+	adapter, err = inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithShards(4), inmemorycacheadapters.WithMaxBytes(1000), inmemorycacheadapters.WithMaxItemBytes(250))
+	suite.Require().NoError(err, "Should not error on items fitting in the budget of a shard")
+	suite.Require().NotNil(adapter, "Should create the adapter")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestShards_LimitsSplit() {
+	const maxEntries = 10
+
+	adapter := suite.newBoundedAdapter(maxEntries, inmemorycacheadapters.WithShards(4))
+
+	for i := 0; i < 100; i++ {
+		err := adapter.Set(testEvictionKey(i), testutil.TestValue, nil)
+		suite.Require().NoError(err, "Should not error on valid Set")
+	}
+
+	stats := adapter.Stats()
+	suite.Require().LessOrEqual(stats.Entries, maxEntries, "Should not exceed the maximum entries summed over the shards")
+	suite.Require().Equal(uint64(100-stats.Entries), stats.Evictions, "Should count the evictions of all the shards")
+
+	err := adapter.Set(testEvictionKey(100), testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+	suite.requirePresent(adapter, map[string]bool{testEvictionKey(100): true})
+}
+
+func (suite *InMemoryAdapterTestSuite) TestShards_TagsAndClear() {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, inmemorycacheadapters.WithShards(4))
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	for i := 0; i < 20; i++ {
+		tag := "even"
+		if i%2 == 1 {
+			tag = "odd"
+		}
+
+		err := adapter.SetWithTags(testEvictionKey(i), testutil.TestValue, nil, tag)
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err = adapter.InvalidateTag("odd")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+
+	for i := 0; i < 20; i++ {
+		suite.requirePresent(adapter, map[string]bool{testEvictionKey(i): i%2 == 0})
+	}
+
+	iterator, err := adapter.Scan(testutil.TestKeyForMany)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	var scanned []string
+	for iterator.Next() {
+		scanned = append(scanned, iterator.Key())
+	}
+
+	suite.Require().Len(scanned, 10, "Should scan the keys of all the shards")
+
+	err = adapter.Clear()
+	suite.Require().NoError(err, "Should not error on valid Clear")
+	suite.Require().Zero(adapter.Stats().Entries, "Should clear all the shards")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestTags_SetWithoutTagsDetachesThem() {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, inmemorycacheadapters.WithShards(4))
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	err = adapter.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, "tag")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.Set(testutil.TestKeyForTags, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+	suite.Require().Zero(adapter.Stats().TaggedKeys, "Should detach the tags of the previous value")
+
+	err = adapter.InvalidateTag("tag")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+	suite.requirePresent(adapter, map[string]bool{testutil.TestKeyForTags: true})
+}
+
+func (suite *InMemoryAdapterTestSuite) TestTags_DeleteEmptiesTheIndex() {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL, inmemorycacheadapters.WithShards(4))
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	for i := 0; i < 20; i++ {
+		err := adapter.SetWithTags(testEvictionKey(i), testutil.TestValue, nil, "first", "second")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	suite.Require().Equal(20, adapter.Stats().TaggedKeys, "Should count the tagged keys")
+
+	for i := 0; i < 20; i++ {
+		err := adapter.Delete(testEvictionKey(i))
+		suite.Require().NoError(err, "Should not error on valid Delete")
+	}
+
+	suite.Require().Zero(adapter.Stats().TaggedKeys, "Should detach the tags of the deleted keys")
+
+	err = adapter.Set(testEvictionKey(0), testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = adapter.InvalidateTag("first")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+	suite.requirePresent(adapter, map[string]bool{testEvictionKey(0): true})
+}
+
+func (suite *InMemoryAdapterTestSuite) TestTags_IncrementKeepsThem() {
+	adapter, err := inmemorycacheadapters.New(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	err = adapter.SetWithTags(testutil.TestKeyForTags, 1, nil, "tag")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	_, err = adapter.Increment(testutil.TestKeyForTags, 1, nil)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+
+	err = adapter.InvalidateTag("tag")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+	suite.requirePresent(adapter, map[string]bool{testutil.TestKeyForTags: false})
+}
+
+func (suite *InMemoryAdapterTestSuite) TestShards_ConcurrentAccess() {
+	adapter := suite.newBoundedAdapter(100, inmemorycacheadapters.WithShards(4), inmemorycacheadapters.WithAdmission())
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("%s:%d", testutil.TestKeyForMany, (g*7+i)%150)
+
+				var value string
+				if adapter.Get(key, &value) != nil {
+					adapter.Set(key, testutil.TestValue, nil)
+				}
+
+				adapter.Increment(key+":counter", 1, nil)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	suite.Require().LessOrEqual(adapter.Stats().Entries, 100, "Should not exceed the maximum entries under concurrent writes")
+}
+
+func (suite *InMemoryAdapterTestSuite) TestShards_Distribution() {
+	// 50 keys per shard on average, so no shard
+	// should be full unless the keys are skewed.
+	adapter := suite.newBoundedAdapter(1600, inmemorycacheadapters.WithShards(16))
+
+	for i := 0; i < 800; i++ {
+		err := adapter.Set(testEvictionKey(i), testutil.TestValue, nil)
+		suite.Require().NoError(err, "Should not error on valid Set")
+	}
+
+	suite.Require().Zero(adapter.Stats().Evictions, "Should spread similar keys among the shards")
+}
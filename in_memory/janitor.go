@@ -0,0 +1,145 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+// The parameters of the adaptive sampling of the janitor.
+const (
+	janitorMaxRounds    = 16 // The maximum number of samples checked at every tick.
+	janitorExpiredRatio = 4  // The inverse of the ratio of expired keys in a sample over which another sample is checked.
+)
+
+// janitor removes the expired entries of an InMemoryAdapter in
+// background, until stopped.
+type janitor struct {
+	stop chan struct{} // Closed to stop the janitor.
+	done chan struct{} // Closed when the janitor has stopped.
+}
+
+// StartJanitor starts the janitor configured with WithJanitor, if
+// not running yet: it removes the expired entries in background,
+// until StopJanitor or Close is called.
+//
+// The janitor is started by New, so you need this
+// only to restart it after stopping it.
+func (ima *InMemoryAdapter) StartJanitor() error {
+	if ima.janitorInterval <= 0 {
+		return ErrJanitorNotConfigured
+	}
+
+	ima.janitorMutex.Lock()
+	defer ima.janitorMutex.Unlock()
+
+	if ima.janitor != nil {
+		return nil
+	}
+
+	ima.janitor = &janitor{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go ima.runJanitor(ima.clock.NewTicker(ima.janitorInterval), ima.janitor)
+
+	return nil
+}
+
+// StopJanitor stops the janitor, if running, and waits for its
+// goroutine to exit. The expired entries are still removed when read.
+func (ima *InMemoryAdapter) StopJanitor() {
+	ima.janitorMutex.Lock()
+	defer ima.janitorMutex.Unlock()
+
+	if ima.janitor == nil {
+		return
+	}
+
+	close(ima.janitor.stop)
+	<-ima.janitor.done
+	ima.janitor = nil
+}
+
+// runJanitor removes the expired entries at every tick,
+// until the janitor is stopped.
+func (ima *InMemoryAdapter) runJanitor(ticker Ticker, janitor *janitor) {
+	defer close(janitor.done)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-janitor.stop:
+			return
+		case <-ticker.C():
+			if ima.janitorSampleSize > 0 {
+				ima.removeExpiredSamples()
+			} else {
+				ima.removeExpired()
+			}
+		}
+	}
+}
+
+// removeExpired removes all the expired entries,
+// locking a shard at a time.
+func (ima *InMemoryAdapter) removeExpired() {
+	now := ima.clock.Now()
+
+	for _, s := range ima.shards {
+		s.mutex.Lock()
+		for key := range s.data {
+			s.removeIfExpired(key, now)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// removeExpiredSamples removes the expired entries among samples of
+// janitorSampleSize keys, split evenly among the shards: every shard
+// checks another sample as long as more than a quarter of the keys
+// in its last one were expired.
+//
+// This bounds the time spent holding the lock, while the ratio of
+// expired entries in cache stays low.
+func (ima *InMemoryAdapter) removeExpiredSamples() {
+	sampleSize := ima.janitorSampleSize / len(ima.shards)
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+
+	for _, s := range ima.shards {
+		for round := 0; round < janitorMaxRounds; round++ {
+			sampled, expired := 0, 0
+			now := ima.clock.Now()
+
+			s.mutex.Lock()
+			// the iteration order of maps is random, so this
+			// takes a sample of the keys starting at a random one.
+			for key := range s.data {
+				if sampled >= sampleSize {
+					break
+				}
+
+				sampled++
+				if s.removeIfExpired(key, now) {
+					expired++
+				}
+			}
+			s.mutex.Unlock()
+
+			if expired*janitorExpiredRatio <= sampled {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import (
+	"math"
+	"reflect"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// ObjectMode chooses how the InMemoryAdapter stores
+// the values, see WithObjectMode.
+type ObjectMode int
+
+// The available object modes.
+const (
+	EncodedValues ObjectMode = iota // Stores the values encoded by the codec, the default.
+	SharedObjects                   // Stores the values as they are, sharing the pointers, maps and slices with the callers.
+	ShallowCopies                   // Stores a shallow copy of the values, and returns a shallow copy of them.
+	DeepCopies                      // Stores a copy of the values made by the Cloner, and returns a copy of them.
+)
+
+// Cloner returns a deep copy of the object, used by the DeepCopies
+// object mode. The copy must have the same type of the object.
+type Cloner func(object interface{}) (interface{}, error)
+
+// newItem creates the item in cache holding the object,
+// encoded or stored as it is depending on the object mode.
+func (ima *InMemoryAdapter) newItem(object interface{}) (cacheItem, error) {
+	switch raw := object.(type) {
+	case cacheadapters.RawMessage:
+		return cacheItem{item: raw}, nil
+	case *cacheadapters.RawMessage:
+		if raw != nil {
+			return cacheItem{item: *raw}, nil
+		}
+	}
+
+	if ima.objectMode == EncodedValues {
+		content, err := cacheadapters.Marshal(ima.codec, object)
+		if err != nil {
+			return cacheItem{}, err
+		}
+
+		return cacheItem{item: content}, nil
+	}
+
+	object, err := ima.copyObject(object)
+	if err != nil {
+		return cacheItem{}, err
+	}
+
+	return cacheItem{object: object, isObject: true}, nil
+}
+
+// decode fills the object reference with the value of the item.
+//
+// Objects are assigned to the reference through reflection, and a
+// *cacheadapters.RawMessage is filled with the object encoded by the
+// codec, while the encoded values are always decoded by the codec.
+func (ima *InMemoryAdapter) decode(item cacheItem, objectRef interface{}) error {
+	if !item.isObject {
+		return cacheadapters.Unmarshal(ima.codec, item.item, objectRef)
+	}
+
+	if _, ok := objectRef.(*cacheadapters.RawMessage); ok {
+		content, err := ima.codec.Marshal(item.object)
+		if err != nil {
+			return err
+		}
+
+		return cacheadapters.Unmarshal(ima.codec, content, objectRef)
+	}
+
+	target := reflect.ValueOf(objectRef)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return cacheadapters.ErrGetRequiresObjectReference
+	}
+
+	object, err := ima.copyObject(item.object)
+	if err != nil {
+		return err
+	}
+
+	return assignObject(object, target.Elem())
+}
+
+// copyObject returns the object to store or to return,
+// copied as required by the object mode.
+func (ima *InMemoryAdapter) copyObject(object interface{}) (interface{}, error) {
+	switch ima.objectMode {
+	case ShallowCopies:
+		return shallowCopy(object), nil
+	case DeepCopies:
+		if object == nil {
+			return nil, nil
+		}
+
+		return ima.cloner(object)
+	default:
+		return object, nil
+	}
+}
+
+// shallowCopy returns a copy of the value pointed by a pointer, or of
+// the elements of a slice or a map, sharing what they reference.
+// Any other object is returned as it is, since it is already copied
+// when assigned.
+func shallowCopy(object interface{}) interface{} {
+	value := reflect.ValueOf(object)
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return object
+		}
+
+		result := reflect.New(value.Elem().Type())
+		result.Elem().Set(value.Elem())
+		return result.Interface()
+	case reflect.Slice:
+		if value.IsNil() {
+			return object
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		reflect.Copy(result, value)
+		return result.Interface()
+	case reflect.Map:
+		if value.IsNil() {
+			return object
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			result.SetMapIndex(iterator.Key(), iterator.Value())
+		}
+
+		return result.Interface()
+	default:
+		return object
+	}
+}
+
+// assignObject assigns the object to the target, which must have the
+// type of the object, or the one of the value it points to, otherwise
+// returns ErrTypeMismatch. A nil object sets the target to its zero
+// value.
+func assignObject(object interface{}, target reflect.Value) error {
+	if object == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	value := reflect.ValueOf(object)
+	if value.Type().AssignableTo(target.Type()) {
+		target.Set(value)
+		return nil
+	}
+
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Type().AssignableTo(target.Type()) {
+		target.Set(value.Elem())
+		return nil
+	}
+
+	return ErrTypeMismatch
+}
+
+// objectCounter returns the integer counter stored as an object,
+// if it is an integer fitting in an int64.
+func objectCounter(object interface{}) (int64, bool) {
+	value := reflect.ValueOf(object)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(value.Uint()), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import (
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// Option configures an InMemoryAdapter when passed to New.
+type Option func(*settings) error
+
+// settings contains the configuration of an InMemoryAdapter.
+type settings struct {
+	codec          cacheadapters.Codec // The codec of the values in cache.
+	maxEntries     int                 // The maximum number of entries, zero for no limit.
+	evictionPolicy EvictionPolicy      // The policy choosing the entries to evict.
+	admission      bool                // Whether the TinyLFU admission policy is enabled.
+	maxBytes       int64               // The maximum cost of all the entries, zero for no limit.
+	maxItemBytes   int64               // The maximum cost of an entry, zero for no limit.
+	costFunc       CostFunc            // The function computing the cost of the entries.
+	clock          Clock               // The clock giving the current time.
+	shards         int                 // The number of shards, zero to choose it from the limits.
+	objectMode     ObjectMode          // How the values are stored in cache.
+	cloner         Cloner              // The function copying the values in the DeepCopies object mode.
+
+	janitorInterval   time.Duration // The interval between the runs of the janitor, zero if disabled.
+	janitorSampleSize int           // The number of keys sampled by the janitor, zero to check all the keys.
+}
+
+// CostFunc computes the cost of an entry, counted in the
+// byte budget set with WithMaxBytes and WithMaxItemBytes.
+//
+// The content is the value encoded by the codec.
+type CostFunc func(key string, content []byte) int64
+
+// contentLength is the default CostFunc,
+// the length of the encoded value.
+func contentLength(key string, content []byte) int64 {
+	return int64(len(content))
+}
+
+// newSettings creates the configuration of an InMemoryAdapter,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{
+		codec:    cacheadapters.JSONCodec{},
+		costFunc: contentLength,
+		clock:    systemClock{},
+	}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithCodec sets the codec used to encode the values in cache,
+// cacheadapters.JSONCodec is used if not set.
+func WithCodec(codec cacheadapters.Codec) Option {
+	return func(s *settings) error {
+		if codec == nil {
+			return cacheadapters.ErrNilCodec
+		}
+
+		s.codec = codec
+		return nil
+	}
+}
+
+// WithMaxEntries limits the number of entries in cache: when full,
+// an entry is evicted before writing a new key, chosen by the policy
+// set with WithEvictionPolicy. There is no limit if not set.
+func WithMaxEntries(maxEntries int) Option {
+	return func(s *settings) error {
+		if maxEntries <= 0 {
+			return ErrInvalidMaxEntries
+		}
+
+		s.maxEntries = maxEntries
+		return nil
+	}
+}
+
+// WithEvictionPolicy sets the policy choosing the entries to evict
+// when the cache is full, LRU is used if not set.
+//
+// It has effect only together with WithMaxEntries or WithMaxBytes.
+func WithEvictionPolicy(policy EvictionPolicy) Option {
+	return func(s *settings) error {
+		switch policy {
+		case LRU, LFU, FIFO:
+		default:
+			return ErrUnknownEvictionPolicy
+		}
+
+		s.evictionPolicy = policy
+		return nil
+	}
+}
+
+// WithAdmission enables the TinyLFU admission policy: when the cache
+// is full, a new key is written only if it has been accessed (read or
+// written) more frequently, in the recent past, than each of the entries
+// which would be evicted to make room for it. This keeps the popular
+// entries in cache when many keys are used only once (e.g. during a scan).
+//
+// Rejected writes fail with ErrRejected, leaving the cache untouched,
+// and are counted in the Rejections statistic.
+//
+// It has effect only together with WithMaxEntries or WithMaxBytes.
+func WithAdmission() Option {
+	return func(s *settings) error {
+		s.admission = true
+		return nil
+	}
+}
+
+// WithMaxBytes limits the cost of all the entries in cache, by default
+// the length of their encoded values (see WithCostFunc): before
+// writing a value, entries are evicted until it fits, chosen by the
+// policy set with WithEvictionPolicy. There is no limit if not set.
+//
+// The budget is split evenly among the shards (see WithShards), and
+// values costing more than the budget of their shard are rejected
+// with ErrItemTooLarge.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(s *settings) error {
+		if maxBytes <= 0 {
+			return ErrInvalidMaxBytes
+		}
+
+		s.maxBytes = maxBytes
+		return nil
+	}
+}
+
+// WithMaxItemBytes rejects with ErrItemTooLarge the values
+// costing more than the given maximum (see WithCostFunc).
+// There is no limit if not set.
+//
+// Together with WithMaxBytes, the maximum cannot exceed the
+// budget of a shard (the one set with WithMaxBytes divided by
+// the number of shards), otherwise New returns
+// ErrMaxItemBytesTooLarge.
+func WithMaxItemBytes(maxItemBytes int64) Option {
+	return func(s *settings) error {
+		if maxItemBytes <= 0 {
+			return ErrInvalidMaxBytes
+		}
+
+		s.maxItemBytes = maxItemBytes
+		return nil
+	}
+}
+
+// WithCostFunc sets the function computing the cost of the entries,
+// counted by WithMaxBytes and WithMaxItemBytes. The length of the
+// encoded value is used if not set.
+func WithCostFunc(costFunc CostFunc) Option {
+	return func(s *settings) error {
+		if costFunc == nil {
+			return ErrNilCostFunc
+		}
+
+		s.costFunc = costFunc
+		return nil
+	}
+}
+
+// WithClock sets the clock giving the current time to the adapter and
+// the ticks to its janitor, the system one is used if not set.
+//
+// Use it to test the expiration of the entries deterministically.
+func WithClock(clock Clock) Option {
+	return func(s *settings) error {
+		if clock == nil {
+			return ErrNilClock
+		}
+
+		s.clock = clock
+		return nil
+	}
+}
+
+// WithJanitor starts a janitor removing the expired entries in
+// background every interval, otherwise they are removed only when
+// read. The janitor is started by New and stopped by Close, see
+// StartJanitor and StopJanitor.
+//
+// By default the janitor checks all the keys, holding the lock of
+// each shard meanwhile, use WithJanitorSampling for big caches.
+func WithJanitor(interval time.Duration) Option {
+	return func(s *settings) error {
+		if interval <= 0 {
+			return ErrInvalidJanitorInterval
+		}
+
+		s.janitorInterval = interval
+		return nil
+	}
+}
+
+// WithJanitorSampling makes the janitor check samples of the given
+// number of keys at every run, split evenly among the shards, instead
+// of all of them: every shard checks another sample as long as more
+// than a quarter of the keys in its last one were expired. This bounds
+// the time spent holding the locks.
+//
+// It has effect only together with WithJanitor.
+func WithJanitorSampling(sampleSize int) Option {
+	return func(s *settings) error {
+		if sampleSize <= 0 {
+			return ErrInvalidSampleSize
+		}
+
+		s.janitorSampleSize = sampleSize
+		return nil
+	}
+}
+
+// WithShards partitions the entries in the given number of shards,
+// each one with its own lock, so that operations on keys of different
+// shards do not wait for each other. Reads of the same shard share its
+// lock only if the adapter is not limited, or evicts with FIFO without
+// admission: LRU, LFU and the admission policy record every read, so
+// with them reads lock the shard exclusively, like writes.
+//
+// The limits set with WithMaxEntries and WithMaxBytes are split evenly
+// among the shards, and every shard evicts its own entries: with more
+// than one shard the evicted entry is the one chosen by the policy in
+// the shard of the written key, and values costing more than the
+// share of the budget of a shard are rejected with ErrItemTooLarge,
+// even if they would fit in the whole budget.
+//
+// If not set, the adapter uses 16 shards, or a single one
+// (evicting exactly as chosen by the policy) if limited: so a limited
+// adapter using LRU, LFU or the admission policy serializes all its
+// operations, reads included, unless this option is set.
+func WithShards(shards int) Option {
+	return func(s *settings) error {
+		if shards <= 0 {
+			return ErrInvalidShards
+		}
+
+		s.shards = shards
+		return nil
+	}
+}
+
+// WithObjectMode stores the values as they are, instead of encoding
+// them with the codec, saving the time spent encoding and decoding
+// them, with the given semantics:
+//
+//   - SharedObjects stores the values as they are, so the values
+//     pointed by a pointer, and the elements of maps and slices, are
+//     shared between the cache and the callers of Set and Get, which
+//     must not change them;
+//   - ShallowCopies stores a copy of the value pointed by a pointer,
+//     or of the elements of a map or a slice, and returns a copy of it
+//     in Get, sharing only what they reference;
+//   - DeepCopies stores the copy made by the Cloner set with WithCloner,
+//     and returns a copy of it in Get.
+//
+// Get assigns the value through reflection to the object reference,
+// whose type must be the type of the value or, if the value is a
+// pointer, the type of the value it points to, otherwise it returns
+// ErrTypeMismatch. Values written as cacheadapters.RawMessage, and
+// counters, are still decoded by the codec, and values read into a
+// *cacheadapters.RawMessage are encoded by it, so the adapter can
+// still exchange encoded values with other adapters.
+//
+// Since values are not encoded, the object modes cannot be used
+// together with WithMaxBytes and WithMaxItemBytes.
+func WithObjectMode(mode ObjectMode) Option {
+	return func(s *settings) error {
+		switch mode {
+		case EncodedValues, SharedObjects, ShallowCopies, DeepCopies:
+		default:
+			return ErrUnknownObjectMode
+		}
+
+		s.objectMode = mode
+		return nil
+	}
+}
+
+// WithCloner sets the function copying the values
+// in the DeepCopies object mode, which requires it.
+//
+// It has effect only together with WithObjectMode(DeepCopies).
+func WithCloner(cloner Cloner) Option {
+	return func(s *settings) error {
+		if cloner == nil {
+			return ErrNilCloner
+		}
+
+		s.cloner = cloner
+		return nil
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemorycacheadapters
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultShards is the number of shards of an adapter without
+// limits, when not set with WithShards.
+const defaultShards = 16
+
+// shard is a partition of the entries of an InMemoryAdapter, with
+// its own lock and its own share of the limits. Every key belongs
+// to a single shard, chosen by the hash of the key.
+type shard struct {
+	mutex        sync.RWMutex   // The mutex locking the operations on the shard.
+	data         cacheData      // The entries of the shard.
+	tags         tagIndex       // The keys of the shard which have been set with each tag.
+	keyTags      keyTags        // The tags of each tagged key of the shard.
+	maxEntries   int            // The maximum number of entries, zero for no limit.
+	maxBytes     int64          // The maximum cost of all the entries, zero for no limit.
+	maxItemBytes int64          // The maximum cost of an entry, zero for no limit.
+	costFunc     CostFunc       // The function computing the cost of the entries, nil if there is no byte limit.
+	usedBytes    int64          // The cost of all the entries.
+	eviction     evictionPolicy // The policy choosing the entries to evict, nil if there is no limit.
+	admission    *tinyLFU       // The policy admitting the new entries when full, if enabled.
+	evictions    uint64         // The number of evicted entries.
+	rejections   uint64         // The number of writes rejected by the admission policy.
+
+	exclusiveReads bool // Whether the reads update the policies, so they lock the shard exclusively.
+}
+
+// newShards creates the shards of an adapter from its configuration,
+// splitting the limits evenly among them.
+func newShards(config *settings) []*shard {
+	result := make([]*shard, config.shards)
+
+	for i := range result {
+		s := &shard{
+			data:         make(cacheData),
+			tags:         make(tagIndex),
+			keyTags:      make(keyTags),
+			maxEntries:   splitLimit(config.maxEntries, config.shards, i),
+			maxBytes:     splitLimit(config.maxBytes, config.shards, i),
+			maxItemBytes: config.maxItemBytes,
+		}
+
+		if config.maxBytes > 0 || config.maxItemBytes > 0 {
+			s.costFunc = config.costFunc
+		}
+
+		if config.maxEntries > 0 || config.maxBytes > 0 {
+			s.eviction = newEvictionPolicy(config.evictionPolicy)
+
+			if config.admission {
+				s.admission = newTinyLFU(s.maxEntries)
+			}
+
+			// FIFO ignores the reads, unlike LRU, LFU and the admission.
+			s.exclusiveReads = config.evictionPolicy != FIFO || config.admission
+		}
+
+		result[i] = s
+	}
+
+	return result
+}
+
+// splitLimit returns the share of the limit of the i-th of the
+// given number of shards, spreading the remainder on the first ones.
+func splitLimit[T int | int64](limit T, shards int, i int) T {
+	result := limit / T(shards)
+	if T(i) < limit%T(shards) {
+		result++
+	}
+
+	return result
+}
+
+// shardIndex returns the index of the shard of the key.
+//
+// The hash is mixed with the finalizer of MurmurHash3, since the
+// high bits of FNV-1a barely change among keys differing only in the
+// last bytes (e.g. "user:1" and "user:2"), and then its high bits are
+// used, since the low ones index the counters of the admission policy.
+func shardIndex(key string, shards int) int {
+	hash, _ := hashKey(key)
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+
+	return int((hash >> 32) % uint64(shards))
+}
+
+// readLock locks the shard for reading the entries.
+//
+// Reads are tracked by the LRU and LFU eviction policies and by the
+// admission policy, so they lock the shard exclusively if any of them
+// is used, otherwise they run concurrently.
+func (s *shard) readLock() {
+	if s.exclusiveReads {
+		s.mutex.Lock()
+	} else {
+		s.mutex.RLock()
+	}
+}
+
+// readUnlock unlocks the shard locked by readLock.
+func (s *shard) readUnlock() {
+	if s.exclusiveReads {
+		s.mutex.Unlock()
+	} else {
+		s.mutex.RUnlock()
+	}
+}
+
+// store writes the item in the shard, evicting entries until it fits
+// in the limits. Returns ErrRejected if the write has been rejected by
+// the admission policy, or ErrItemTooLarge if the item can never fit.
+// The caller must hold the mutex.
+func (s *shard) store(key string, item cacheItem) error {
+	if s.costFunc != nil {
+		item.cost = s.costFunc(key, item.item)
+		if (s.maxItemBytes > 0 && item.cost > s.maxItemBytes) || (s.maxBytes > 0 && item.cost > s.maxBytes) {
+			return ErrItemTooLarge
+		}
+	}
+
+	if s.eviction == nil {
+		s.put(key, item)
+		return nil
+	}
+
+	if s.admission != nil {
+		s.admission.record(key)
+	}
+
+	previous, exists := s.data[key]
+	if exists {
+		s.eviction.access(key)
+	} else if s.admission != nil && s.exceedsLimits(1, item.cost) && !s.admits(key, item.cost) {
+		s.rejections++
+		return ErrRejected
+	}
+
+	for {
+		newEntries, addedBytes := 1, item.cost
+		if exists {
+			newEntries, addedBytes = 0, item.cost-previous.cost
+		}
+
+		if !s.exceedsLimits(newEntries, addedBytes) {
+			break
+		}
+
+		victim, found := s.eviction.victim()
+		if !found {
+			break
+		}
+
+		if victim == key {
+			// the previous value of the key is
+			// being replaced, it is not an eviction.
+			s.remove(key)
+			exists = false
+			continue
+		}
+
+		s.remove(victim)
+		s.evictions++
+	}
+
+	if !exists {
+		s.eviction.add(key)
+	}
+
+	s.put(key, item)
+	return nil
+}
+
+// admits checks if the admission policy prefers the new key over
+// each of the entries which would be evicted to make room for it.
+// The caller must hold the mutex.
+func (s *shard) admits(key string, cost int64) bool {
+	entries, usedBytes := len(s.data)+1, s.usedBytes+cost
+	admitted := true
+
+	s.eviction.victims(func(victim string) bool {
+		if !s.exceeds(entries, usedBytes) {
+			return false
+		}
+
+		if !s.admission.admit(key, victim) {
+			admitted = false
+			return false
+		}
+
+		entries, usedBytes = entries-1, usedBytes-s.data[victim].cost
+		return true
+	})
+
+	return admitted
+}
+
+// exceedsLimits checks if adding the given number of entries and
+// bytes to the shard exceeds the maximum entries or bytes.
+// The caller must hold the mutex.
+func (s *shard) exceedsLimits(newEntries int, addedBytes int64) bool {
+	return s.exceeds(len(s.data)+newEntries, s.usedBytes+addedBytes)
+}
+
+// exceeds checks if the given number of entries and
+// bytes exceeds the maximum entries or bytes.
+func (s *shard) exceeds(entries int, usedBytes int64) bool {
+	return (s.maxEntries > 0 && entries > s.maxEntries) ||
+		(s.maxBytes > 0 && usedBytes > s.maxBytes)
+}
+
+// put writes the item in the shard, tracking its cost. The tags
+// of the previous value of the key are detached from it.
+// The caller must hold the mutex.
+func (s *shard) put(key string, item cacheItem) {
+	s.untag(key)
+	s.usedBytes += item.cost - s.data[key].cost
+	s.data[key] = item
+}
+
+// remove deletes the key from the shard, if present.
+// The caller must hold the mutex.
+func (s *shard) remove(key string) {
+	valueFromMemory, exists := s.data[key]
+	if !exists {
+		return
+	}
+
+	s.untag(key)
+	s.usedBytes -= valueFromMemory.cost
+	delete(s.data, key)
+	if s.eviction != nil {
+		s.eviction.remove(key)
+	}
+}
+
+// removeIfExpired deletes the key from the shard,
+// if present and expired at the given time.
+// The caller must hold the mutex.
+func (s *shard) removeIfExpired(key string, now time.Time) bool {
+	valueFromMemory, exists := s.data[key]
+	if !exists || !valueFromMemory.isExpired(now) {
+		return false
+	}
+
+	s.remove(key)
+	return true
+}
+
+// recordAccess tracks the read of the key, for the eviction
+// and the admission policies.
+// The caller must hold the mutex, as locked by readLock.
+func (s *shard) recordAccess(key string, exists bool) {
+	if !s.exclusiveReads {
+		return
+	}
+
+	if s.admission != nil {
+		s.admission.record(key)
+	}
+
+	if exists && s.eviction != nil {
+		s.eviction.access(key)
+	}
+}
+
+// tag attaches the tags to the key, replacing the ones it had.
+// The caller must hold the mutex.
+func (s *shard) tag(key string, tags []string) {
+	s.untag(key)
+	if len(tags) == 0 {
+		return
+	}
+
+	s.keyTags[key] = append([]string(nil), tags...)
+	for _, tag := range tags {
+		taggedKeys, exists := s.tags[tag]
+		if !exists {
+			taggedKeys = make(map[string]struct{})
+			s.tags[tag] = taggedKeys
+		}
+
+		taggedKeys[key] = struct{}{}
+	}
+}
+
+// untag detaches all the tags from the key, dropping
+// the sets of the tags which remain empty.
+// The caller must hold the mutex.
+func (s *shard) untag(key string) {
+	for _, tag := range s.keyTags[key] {
+		taggedKeys := s.tags[tag]
+		delete(taggedKeys, key)
+		if len(taggedKeys) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+
+	delete(s.keyTags, key)
+}
+
+// clear deletes all the entries of the shard, dropping at once
+// the map containing them, together with the index of the tags.
+// The caller must hold the mutex.
+func (s *shard) clear() {
+	s.data = make(cacheData)
+	s.tags = make(tagIndex)
+	s.keyTags = make(keyTags)
+	s.usedBytes = 0
+	if s.eviction != nil {
+		s.eviction.clear()
+	}
+}
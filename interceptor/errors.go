@@ -0,0 +1,32 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters
+
+import "fmt"
+
+var (
+	// ErrNilAdapter will come out if you try to pass a nil adapter
+	// or session when creating a new InterceptedAdapter or a session.
+	ErrNilAdapter = fmt.Errorf("you must pass a valid adapter to intercept, nil found")
+	// ErrNilInterceptor will come out if you try to pass
+	// a nil interceptor in the chain.
+	ErrNilInterceptor = fmt.Errorf("you must pass valid interceptors, nil found")
+	// ErrUnknownOperation will come out if an interceptor
+	// changes the name of an operation into an unknown one.
+	ErrUnknownOperation = fmt.Errorf("the operation is not supported by the adapter")
+	// ErrMismatchedValues will come out if an interceptor changes the keys
+	// or the values of a batch operation, leaving them with different lengths.
+	ErrMismatchedValues = fmt.Errorf("the keys and the values of a batch operation must have the same length")
+)
@@ -0,0 +1,73 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters
+
+import (
+	"context"
+	"reflect"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// InterceptedAdapter is a cache adapter which passes every operation
+// through an ordered chain of interceptors before performing it
+// on the wrapped adapter.
+type InterceptedAdapter struct {
+	interceptedOperator
+}
+
+// New creates a new InterceptedAdapter which passes the operations
+// on the given adapter through the interceptors, in the given order:
+// the first interceptor is the outermost one.
+//
+// The sessions opened by the InterceptedAdapter are intercepted
+// by the same chain.
+func New(adapter cacheadapters.CacheAdapter, interceptors ...Interceptor) (*InterceptedAdapter, error) {
+	if value := reflect.ValueOf(adapter); adapter == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	operator, err := newInterceptedOperator(cacheadapters.AdapterAsSession(adapter), adapter, interceptors)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InterceptedAdapter{operator}, nil
+}
+
+// OpenSession opens a new Cache Session on the wrapped adapter,
+// intercepted by the same chain.
+func (ia *InterceptedAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
+	return ia.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext is the same as OpenSession, but honors the
+// cancellation and the deadline of the given context.
+//
+// If an interceptor fails after the session has been opened, the
+// session is closed before returning the error.
+func (ia *InterceptedAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	op := &Operation{Name: OperationOpenSession}
+	err := ia.invoker(ctx, op)
+	if err != nil {
+		if op.Session != nil {
+			op.Session.Close()
+		}
+
+		return nil, err
+	}
+
+	return op.Session, nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+const testKeyPrefix = "rewritten:"
+
+var errTestingInvalidKey = fmt.Errorf("TESTING INVALID KEY")
+
+// InterceptedAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type InterceptedAdapterTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL time.Duration
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+// passThrough is an interceptor which only calls the next one.
+func passThrough(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	return next(ctx, op)
+}
+
+// prefixKeys is an interceptor rewriting the keys, the prefixes and
+// the tags, and converting the keys of the batch results back.
+func prefixKeys(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	op.Key = testKeyPrefix + op.Key
+	op.Prefix = testKeyPrefix + op.Prefix
+	for i, key := range op.Keys {
+		op.Keys[i] = testKeyPrefix + key
+	}
+
+	err := next(ctx, op)
+
+	if op.BatchResult != nil {
+		result := make(cacheadapters.BatchResult, len(op.BatchResult))
+		for key, keyErr := range op.BatchResult {
+			result[strings.TrimPrefix(key, testKeyPrefix)] = keyErr
+		}
+
+		op.BatchResult = result
+	}
+
+	return err
+}
+
+// rejectEmptyKeys is an interceptor validating the keys.
+func rejectEmptyKeys(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	if op.Name == interceptorcacheadapters.OperationGet && op.Key == "" {
+		return errTestingInvalidKey
+	}
+
+	return next(ctx, op)
+}
+
+// recorder is an interceptor recording the names of the operations.
+type recorder struct {
+	mutex sync.Mutex
+	names []interceptorcacheadapters.OperationName
+}
+
+func (r *recorder) intercept(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	r.mutex.Lock()
+	r.names = append(r.names, op.Name)
+	r.mutex.Unlock()
+
+	return next(ctx, op)
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return interceptorcacheadapters.New(inMemoryAdapter, passThrough, (&recorder{}).intercept)
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		adapter, err := newTestAdapterFunc(defaultTTL)()
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter.OpenSession()
+	}
+}
+
+// newInterceptedTestSuite creates a new test suite with tests for
+// Intercepted adapters and sessions, backed by an In-Memory adapter.
+func newInterceptedTestSuite(defaultTTL time.Duration) *InterceptedAdapterTestSuite {
+	var suite suite.Suite
+
+	return &InterceptedAdapterTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestInterceptedAdapterSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newInterceptedTestSuite(defaultTTL))
+}
+
+func (suite *InterceptedAdapterTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+func (suite *InterceptedAdapterTestSuite) TestNew_NilAdapter() {
+	var nilAdapter *inmemorycacheadapters.InMemoryAdapter
+
+	adapter, err := interceptorcacheadapters.New(nil)
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+
+	adapter, err = interceptorcacheadapters.New(nilAdapter)
+	suite.Require().Nil(adapter, "Should be nil on typed nil adapter")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on typed nil adapter")
+
+	session, err := interceptorcacheadapters.NewSession(nil)
+	suite.Require().Nil(session, "Should be nil on nil session")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil session")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestNew_NilInterceptor() {
+	adapter, err := interceptorcacheadapters.New(suite.newInMemoryAdapter(), passThrough, nil)
+	suite.Require().Nil(adapter, "Should be nil on nil interceptor")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilInterceptor, "Should give error on nil interceptor")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestChain_Order() {
+	var calls []string
+	tracing := func(name string) interceptorcacheadapters.Interceptor {
+		return func(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+			calls = append(calls, name+":before")
+			err := next(ctx, op)
+			calls = append(calls, name+":after")
+
+			return err
+		}
+	}
+
+	adapter, err := interceptorcacheadapters.New(suite.newInMemoryAdapter(), tracing("first"), tracing("second"))
+	suite.Require().NoError(err, "Should not error on valid interceptors")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+	suite.Require().Equal([]string{"first:before", "second:before", "second:after", "first:after"}, calls, "Should call the interceptors in order")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestKeyRewriting() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := interceptorcacheadapters.New(inMemoryAdapter, prefixKeys)
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = inMemoryAdapter.Get(testKeyPrefix+testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should store the value in the rewritten key")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForSet: &actual})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().NoError(result[testutil.TestKeyForSet], "Should find the key, reported without rewriting")
+
+	iterator, err := adapter.Scan(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	suite.Require().True(iterator.Next(), "Should find the rewritten key")
+	suite.Require().Equal(testKeyPrefix+testutil.TestKeyForSet, iterator.Key(), "Should scan the rewritten prefix")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestKeyRewriting_BatchResultByOriginalKeys() {
+	prefixOnly := func(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+		for i, key := range op.Keys {
+			op.Keys[i] = testKeyPrefix + key
+		}
+
+		return next(ctx, op)
+	}
+
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := interceptorcacheadapters.New(inMemoryAdapter, prefixOnly)
+
+	result, err := adapter.SetMany(map[string]interface{}{testutil.TestKeyForMany: testutil.TestValue}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+	suite.Require().Equal(cacheadapters.BatchResult{testutil.TestKeyForMany: nil}, result, "Should report the original keys")
+
+	var actual testutil.TestStruct
+	result, err = adapter.GetMany(map[string]interface{}{testutil.TestKeyForMany: &actual, testutil.TestKeyForGet: &actual})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().NoError(result[testutil.TestKeyForMany], "Should find the key, reported without rewriting")
+	suite.Require().ErrorIs(result[testutil.TestKeyForGet], cacheadapters.ErrNotFound, "Should miss the key, reported without rewriting")
+	suite.Require().Len(result, 2, "Should report only the original keys")
+
+	result, err = adapter.DeleteMany([]string{testutil.TestKeyForMany})
+	suite.Require().NoError(err, "Should not error on valid DeleteMany")
+	suite.Require().Equal(cacheadapters.BatchResult{testutil.TestKeyForMany: nil}, result, "Should report the original keys")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestValidation() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+	adapter, _ := interceptorcacheadapters.New(inMemoryAdapter, rejectEmptyKeys)
+
+	var actual testutil.TestStruct
+	err := adapter.Get("", &actual)
+	suite.Require().ErrorIs(err, errTestingInvalidKey, "Should stop invalid operations")
+
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should perform valid operations")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestUnknownOperation() {
+	renaming := func(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+		op.Name = "Unknown"
+		return next(ctx, op)
+	}
+
+	adapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), renaming)
+
+	err := adapter.Delete(testutil.TestKeyForDelete)
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrUnknownOperation, "Should error on unknown operations")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestMismatchedValues() {
+	dropping := func(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+		op.Values = nil
+		return next(ctx, op)
+	}
+
+	adapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), dropping)
+
+	_, err := adapter.SetMany(map[string]interface{}{testutil.TestKeyForMany: testutil.TestValue}, nil)
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrMismatchedValues, "Should error on mismatched keys and values")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestOpenSession_Intercepted() {
+	operations := &recorder{}
+	adapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), operations.intercept)
+
+	session, err := adapter.OpenSession()
+	suite.Require().NoError(err, "Should not error on valid OpenSession")
+
+	err = session.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = session.Close()
+	suite.Require().NoError(err, "Should not error on valid Close")
+
+	suite.Require().Equal([]interceptorcacheadapters.OperationName{
+		interceptorcacheadapters.OperationOpenSession,
+		interceptorcacheadapters.OperationSet,
+		interceptorcacheadapters.OperationClose,
+	}, operations.names, "Should intercept the session and its operations")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestOpenSession_InterceptorFailsAfterNext() {
+	failing := func(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+		err := next(ctx, op)
+		if op.Name == interceptorcacheadapters.OperationOpenSession {
+			return errTestingInvalidKey
+		}
+
+		return err
+	}
+
+	operations := &recorder{}
+	adapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), failing, operations.intercept)
+
+	session, err := adapter.OpenSession()
+	suite.Require().ErrorIs(err, errTestingInvalidKey, "Should return the error of the interceptor")
+	suite.Require().Nil(session, "Should be nil on failed OpenSession")
+
+	suite.Require().Equal([]interceptorcacheadapters.OperationName{
+		interceptorcacheadapters.OperationOpenSession,
+		interceptorcacheadapters.OperationClose,
+	}, operations.names, "Should close the session opened before the failure")
+}
+
+func (suite *InterceptedAdapterTestSuite) TestMultiCache_Intercepted() {
+	multiCacheAdapter, err := multicacheadapters.New(suite.newInMemoryAdapter(), suite.newInMemoryAdapter())
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+
+	operations := &recorder{}
+	adapter, _ := interceptorcacheadapters.New(multiCacheAdapter, operations.intercept)
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+
+	suite.Require().Equal([]interceptorcacheadapters.OperationName{
+		interceptorcacheadapters.OperationSet,
+		interceptorcacheadapters.OperationGet,
+	}, operations.names, "Should intercept the operations of the multi cache adapter")
+}
@@ -0,0 +1,438 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters
+
+import (
+	"context"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// interceptedOperator implements the operations shared between
+// InterceptedAdapter and InterceptedSessionAdapter.
+type interceptedOperator struct {
+	inner        cacheadapters.CacheSessionAdapter // The wrapped adapter or session.
+	adapter      cacheadapters.CacheAdapter        // The wrapped adapter, nil for sessions.
+	interceptors []Interceptor                     // The chain of interceptors, also used for the opened sessions.
+	invoker      Invoker                           // The chain of interceptors ending with the call to the wrapped adapter.
+}
+
+// newInterceptedOperator creates the operator passing every
+// operation through the interceptors.
+func newInterceptedOperator(inner cacheadapters.CacheSessionAdapter, adapter cacheadapters.CacheAdapter, interceptors []Interceptor) (interceptedOperator, error) {
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			return interceptedOperator{}, ErrNilInterceptor
+		}
+	}
+
+	result := interceptedOperator{
+		inner:        inner,
+		adapter:      adapter,
+		interceptors: append([]Interceptor(nil), interceptors...),
+	}
+	result.invoker = chain(result.interceptors, result.call)
+
+	return result, nil
+}
+
+// Get obtains a value from the cache using a key, then tries to unmarshal
+// it into the object reference passed as parameter.
+func (io interceptedOperator) Get(key string, objectRef interface{}) error {
+	return io.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	return io.invoker(ctx, &Operation{Name: OperationGet, Key: key, Value: objectRef})
+}
+
+// Set sets a value represented by the object parameter into the cache, with the specified key.
+func (io interceptedOperator) Set(key string, object interface{}, TTL *time.Duration) error {
+	return io.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	return io.invoker(ctx, &Operation{Name: OperationSet, Key: key, Value: object, TTL: TTL})
+}
+
+// SetTTL marks the specified key new expiration, deletes it via using
+// cacheadapters.TTLExpired or negative duration.
+func (io interceptedOperator) SetTTL(key string, newTTL time.Duration) error {
+	return io.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	return io.invoker(ctx, &Operation{Name: OperationSetTTL, Key: key, TTL: &newTTL})
+}
+
+// Delete deletes a key from the cache.
+func (io interceptedOperator) Delete(key string) error {
+	return io.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) DeleteContext(ctx context.Context, key string) error {
+	return io.invoker(ctx, &Operation{Name: OperationDelete, Key: key})
+}
+
+// GetMany obtains multiple values from the cache at once, unmarshalling
+// each of them into the object reference mapped to its key.
+func (io interceptedOperator) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return io.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	keys, values := splitObjects(objectRefs)
+
+	op := &Operation{Name: OperationGetMany, Keys: append([]string(nil), keys...), Values: values}
+	err := io.invoker(ctx, op)
+	return originalBatchResult(keys, op), err
+}
+
+// SetMany sets multiple values into the cache at once, with the same TTL.
+func (io interceptedOperator) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return io.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	keys, values := splitObjects(objects)
+
+	op := &Operation{Name: OperationSetMany, Keys: append([]string(nil), keys...), Values: values, TTL: TTL}
+	err := io.invoker(ctx, op)
+	return originalBatchResult(keys, op), err
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (io interceptedOperator) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return io.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	op := &Operation{Name: OperationDeleteMany, Keys: append([]string(nil), keys...)}
+	err := io.invoker(ctx, op)
+	return originalBatchResult(keys, op), err
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (io interceptedOperator) Exists(key string) (bool, error) {
+	return io.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) ExistsContext(ctx context.Context, key string) (bool, error) {
+	op := &Operation{Name: OperationExists, Key: key}
+	err := io.invoker(ctx, op)
+	return op.Exists, err
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (io interceptedOperator) TTL(key string) (time.Duration, error) {
+	return io.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	op := &Operation{Name: OperationTTL, Key: key}
+	err := io.invoker(ctx, op)
+	return op.RemainingTTL, err
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+func (io interceptedOperator) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return io.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	op := &Operation{Name: OperationIncrement, Key: key, Delta: delta, TTL: TTL}
+	err := io.invoker(ctx, op)
+	return op.Counter, err
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+func (io interceptedOperator) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return io.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	op := &Operation{Name: OperationDecrement, Key: key, Delta: delta, TTL: TTL}
+	err := io.invoker(ctx, op)
+	return op.Counter, err
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (io interceptedOperator) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return io.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	op := &Operation{Name: OperationSetIfAbsent, Key: key, Value: object, TTL: TTL}
+	err := io.invoker(ctx, op)
+	return op.Written, err
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (io interceptedOperator) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return io.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	op := &Operation{Name: OperationSetIfPresent, Key: key, Value: object, TTL: TTL}
+	err := io.invoker(ctx, op)
+	return op.Written, err
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (io interceptedOperator) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return io.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (io interceptedOperator) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	op := &Operation{Name: OperationGetWithVersion, Key: key, Value: objectRef}
+	err := io.invoker(ctx, op)
+	return op.Version, err
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (io interceptedOperator) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return io.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (io interceptedOperator) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return io.invoker(ctx, &Operation{Name: OperationCompareAndSwap, Key: key, Version: version, Value: newObject, TTL: TTL})
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+func (io interceptedOperator) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return io.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (io interceptedOperator) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return io.invoker(ctx, &Operation{Name: OperationSetWithTags, Key: key, Value: object, TTL: TTL, Tags: append([]string(nil), tags...)})
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+func (io interceptedOperator) InvalidateTag(tag string) error {
+	return io.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (io interceptedOperator) InvalidateTagContext(ctx context.Context, tag string) error {
+	return io.invoker(ctx, &Operation{Name: OperationInvalidateTag, Tag: tag})
+}
+
+// Scan returns an iterator over the keys starting with the
+// given prefix, or over all the keys if it is empty.
+func (io interceptedOperator) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return io.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	op := &Operation{Name: OperationScan, Prefix: prefix}
+	err := io.invoker(ctx, op)
+	return op.Iterator, err
+}
+
+// Clear deletes all the entries of the wrapped adapter.
+func (io interceptedOperator) Clear() error {
+	return io.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (io interceptedOperator) ClearContext(ctx context.Context) error {
+	return io.invoker(ctx, &Operation{Name: OperationClear})
+}
+
+// ClearPrefix deletes all the entries whose key starts
+// with the given prefix, or all of them if it is empty.
+func (io interceptedOperator) ClearPrefix(prefix string) error {
+	return io.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (io interceptedOperator) ClearPrefixContext(ctx context.Context, prefix string) error {
+	return io.invoker(ctx, &Operation{Name: OperationClearPrefix, Prefix: prefix})
+}
+
+// call is the last Invoker of the chain, performing the
+// operation on the wrapped adapter or session.
+func (io interceptedOperator) call(ctx context.Context, op *Operation) error {
+	var err error
+
+	switch op.Name {
+	case OperationGet:
+		return io.inner.GetContext(ctx, op.Key, op.Value)
+	case OperationSet:
+		return io.inner.SetContext(ctx, op.Key, op.Value, op.TTL)
+	case OperationSetTTL:
+		if op.TTL == nil {
+			return cacheadapters.ErrInvalidTTL
+		}
+
+		return io.inner.SetTTLContext(ctx, op.Key, *op.TTL)
+	case OperationDelete:
+		return io.inner.DeleteContext(ctx, op.Key)
+	case OperationGetMany, OperationSetMany:
+		if len(op.Keys) != len(op.Values) {
+			return ErrMismatchedValues
+		}
+
+		objects := make(map[string]interface{}, len(op.Keys))
+		for i, key := range op.Keys {
+			objects[key] = op.Values[i]
+		}
+
+		if op.Name == OperationGetMany {
+			op.BatchResult, err = io.inner.GetManyContext(ctx, objects)
+		} else {
+			op.BatchResult, err = io.inner.SetManyContext(ctx, objects, op.TTL)
+		}
+	case OperationDeleteMany:
+		op.BatchResult, err = io.inner.DeleteManyContext(ctx, op.Keys)
+	case OperationExists:
+		op.Exists, err = io.inner.ExistsContext(ctx, op.Key)
+	case OperationTTL:
+		op.RemainingTTL, err = io.inner.TTLContext(ctx, op.Key)
+	case OperationIncrement:
+		op.Counter, err = io.inner.IncrementContext(ctx, op.Key, op.Delta, op.TTL)
+	case OperationDecrement:
+		op.Counter, err = io.inner.DecrementContext(ctx, op.Key, op.Delta, op.TTL)
+	case OperationSetIfAbsent:
+		op.Written, err = io.inner.SetIfAbsentContext(ctx, op.Key, op.Value, op.TTL)
+	case OperationSetIfPresent:
+		op.Written, err = io.inner.SetIfPresentContext(ctx, op.Key, op.Value, op.TTL)
+	case OperationGetWithVersion:
+		op.Version, err = io.inner.GetWithVersionContext(ctx, op.Key, op.Value)
+	case OperationCompareAndSwap:
+		return io.inner.CompareAndSwapContext(ctx, op.Key, op.Version, op.Value, op.TTL)
+	case OperationSetWithTags:
+		return io.inner.SetWithTagsContext(ctx, op.Key, op.Value, op.TTL, op.Tags...)
+	case OperationInvalidateTag:
+		return io.inner.InvalidateTagContext(ctx, op.Tag)
+	case OperationScan:
+		op.Iterator, err = io.inner.ScanContext(ctx, op.Prefix)
+	case OperationClear:
+		return io.inner.ClearContext(ctx)
+	case OperationClearPrefix:
+		return io.inner.ClearPrefixContext(ctx, op.Prefix)
+	case OperationOpenSession:
+		if io.adapter == nil {
+			return ErrUnknownOperation
+		}
+
+		session, err := io.adapter.OpenSessionContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		interceptedSession, err := NewSession(session, io.interceptors...)
+		if err != nil {
+			session.Close()
+			return err
+		}
+
+		op.Session = interceptedSession
+		return nil
+	case OperationClose:
+		return io.inner.Close()
+	default:
+		return ErrUnknownOperation
+	}
+
+	return err
+}
+
+// splitObjects splits the objects of a batch
+// operation into keys and values in the same order.
+func splitObjects(objects map[string]interface{}) ([]string, []interface{}) {
+	keys := make([]string, 0, len(objects))
+	values := make([]interface{}, 0, len(objects))
+	for key, object := range objects {
+		keys = append(keys, key)
+		values = append(values, object)
+	}
+
+	return keys, values
+}
+
+// originalBatchResult returns the BatchResult of the batch operation
+// by the keys passed by the caller, which may have been rewritten by
+// the interceptors: the outcome of every key is the one of the key in
+// the same position of op.Keys, or the one of the key itself if the
+// interceptors already mapped the BatchResult back.
+func originalBatchResult(keys []string, op *Operation) cacheadapters.BatchResult {
+	if op.BatchResult == nil || len(op.Keys) != len(keys) {
+		return op.BatchResult
+	}
+
+	result := make(cacheadapters.BatchResult, len(keys))
+	for i, key := range keys {
+		if keyErr, exists := op.BatchResult[op.Keys[i]]; exists {
+			result[key] = keyErr
+		} else if keyErr, exists := op.BatchResult[key]; exists {
+			result[key] = keyErr
+		}
+	}
+
+	return result
+}
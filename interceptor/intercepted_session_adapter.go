@@ -0,0 +1,51 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters
+
+import (
+	"context"
+	"reflect"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// InterceptedSessionAdapter is a cache session adapter which passes
+// every operation through an ordered chain of interceptors before
+// performing it on the wrapped session.
+type InterceptedSessionAdapter struct {
+	interceptedOperator
+}
+
+// NewSession creates a new InterceptedSessionAdapter which passes
+// the operations on the given session through the interceptors,
+// in the given order: the first interceptor is the outermost one.
+func NewSession(session cacheadapters.CacheSessionAdapter, interceptors ...Interceptor) (*InterceptedSessionAdapter, error) {
+	if value := reflect.ValueOf(session); session == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	operator, err := newInterceptedOperator(session, nil, interceptors)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InterceptedSessionAdapter{operator}, nil
+}
+
+// Close closes the wrapped Cache Session, passing
+// the operation through the interceptors.
+func (isa *InterceptedSessionAdapter) Close() error {
+	return isa.invoker(context.Background(), &Operation{Name: OperationClose})
+}
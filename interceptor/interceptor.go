@@ -0,0 +1,116 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptorcacheadapters
+
+import (
+	"context"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// OperationName identifies the operation being intercepted.
+type OperationName string
+
+// The names of the intercepted operations. The plain and the
+// context-aware variants of an operation share the same name.
+const (
+	OperationGet            OperationName = "Get"
+	OperationSet            OperationName = "Set"
+	OperationSetTTL         OperationName = "SetTTL"
+	OperationDelete         OperationName = "Delete"
+	OperationGetMany        OperationName = "GetMany"
+	OperationSetMany        OperationName = "SetMany"
+	OperationDeleteMany     OperationName = "DeleteMany"
+	OperationExists         OperationName = "Exists"
+	OperationTTL            OperationName = "TTL"
+	OperationIncrement      OperationName = "Increment"
+	OperationDecrement      OperationName = "Decrement"
+	OperationSetIfAbsent    OperationName = "SetIfAbsent"
+	OperationSetIfPresent   OperationName = "SetIfPresent"
+	OperationGetWithVersion OperationName = "GetWithVersion"
+	OperationCompareAndSwap OperationName = "CompareAndSwap"
+	OperationSetWithTags    OperationName = "SetWithTags"
+	OperationInvalidateTag  OperationName = "InvalidateTag"
+	OperationScan           OperationName = "Scan"
+	OperationClear          OperationName = "Clear"
+	OperationClearPrefix    OperationName = "ClearPrefix"
+	OperationOpenSession    OperationName = "OpenSession"
+	OperationClose          OperationName = "Close"
+)
+
+// Operation describes an operation passing through the interceptors.
+//
+// Only the fields used by the operation are set. Interceptors can change
+// the arguments before calling the next Invoker (e.g. to rewrite the
+// keys), and read or change the results after it returns.
+//
+// Interceptors rewriting the Keys of a batch operation must keep their
+// number and order: the BatchResult is mapped back to the keys passed
+// by the caller by their position in Keys.
+type Operation struct {
+	Name OperationName // The name of the operation.
+
+	Key     string                // The key of the single-key operations.
+	Keys    []string              // The keys of GetMany, SetMany and DeleteMany.
+	Prefix  string                // The prefix of Scan and ClearPrefix.
+	Tag     string                // The tag of InvalidateTag.
+	Tags    []string              // The tags of SetWithTags.
+	Value   interface{}           // The value written, or the object reference filled, by the single-key operations.
+	Values  []interface{}         // The values written, or the object references filled, by GetMany and SetMany, in the order of Keys.
+	TTL     *time.Duration        // The TTL of the write operations, or the new TTL of SetTTL.
+	Delta   int64                 // The delta of Increment and Decrement.
+	Version cacheadapters.Version // The version expected by CompareAndSwap, or the one obtained by GetWithVersion.
+
+	BatchResult  cacheadapters.BatchResult         // The result of GetMany, SetMany and DeleteMany, by key.
+	Exists       bool                              // The result of Exists.
+	RemainingTTL time.Duration                     // The result of TTL.
+	Counter      int64                             // The result of Increment and Decrement.
+	Written      bool                              // The result of SetIfAbsent and SetIfPresent.
+	Iterator     cacheadapters.KeyIterator         // The result of Scan.
+	Session      cacheadapters.CacheSessionAdapter // The result of OpenSession, already intercepted.
+}
+
+// Invoker performs the operation, filling its results.
+type Invoker func(ctx context.Context, op *Operation) error
+
+// Interceptor is called around every operation, and must call
+// next to continue the chain, unless it wants to stop the
+// operation (e.g. because it is invalid) returning an error.
+//
+// Example of an interceptor logging the failed operations:
+//
+//	    func logFailures(ctx context.Context, op *Operation, next Invoker) error {
+//	        err := next(ctx, op)
+//	        if err != nil && !errors.Is(err, cacheadapters.ErrNotFound) {
+//	            log.Printf("cache %s %q failed: %s", op.Name, op.Key, err)
+//	        }
+//
+//	        return err
+//	    }
+type Interceptor func(ctx context.Context, op *Operation, next Invoker) error
+
+// chain creates an Invoker calling the interceptors in the given
+// order, the first one being the outermost, and then the invoker.
+func chain(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, op *Operation) error {
+			return interceptor(ctx, op, next)
+		}
+	}
+
+	return invoker
+}
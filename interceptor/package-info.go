@@ -0,0 +1,20 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interceptorcacheadapters contains a wrapper of the
+// cacheadapters.CacheAdapter and cacheadapters.CacheSessionAdapter
+// interfaces passing every operation through an ordered chain of
+// interceptors, to add cross-cutting behaviors (like logging,
+// metrics, key rewriting and validation) to any adapter.
+package interceptorcacheadapters
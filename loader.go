@@ -0,0 +1,273 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultNegativeKeyPrefix is the prefix of the keys used to remember
+// the negative results of the loaders, if not set with
+// UseNegativeKeyPrefix.
+const defaultNegativeKeyPrefix = "loader:negative:"
+
+// LoaderFunc obtains a value from the source of truth when it is
+// not found in the cache.
+//
+// Return ErrNotFound to signal that the value does not exist, so
+// that it can be remembered as a negative result, if enabled.
+type LoaderFunc func() (interface{}, error)
+
+// LoaderContextFunc is the same as LoaderFunc, but receives a context.
+//
+// The context carries the values of the context of the caller which
+// started the load, but not its cancellation nor its deadline, since
+// the result is shared with the other callers waiting for the same key.
+type LoaderContextFunc func(ctx context.Context) (interface{}, error)
+
+// Loader adds the GetOrSet operation to any CacheAdapter, collapsing
+// the concurrent misses for the same key into a single call to the
+// loader function.
+type Loader struct {
+	adapter           cacheOperator      // The adapter, or session, used to access the cache.
+	group             singleflight.Group // The group used to collapse the concurrent loads.
+	mutex             sync.RWMutex       // mutex to handle the settings.
+	codec             Codec              // The codec used to copy the loaded values not read back from the cache.
+	negativeTTL       time.Duration      // The TTL of negative results, zero if disabled.
+	negativeKeyPrefix string             // The prefix of the keys of the negative results.
+}
+
+// loadResult is the result of a load shared
+// among the concurrent callers.
+type loadResult struct {
+	codec  Codec       // The codec used to copy the loaded value.
+	object interface{} // The loaded value.
+	setErr error       // The error occurred while storing the value in cache.
+}
+
+// NewLoader creates a new Loader on top of the given adapter.
+func NewLoader(adapter CacheAdapter) (*Loader, error) {
+	if adapter == nil {
+		return nil, ErrInvalidLoaderAdapter
+	}
+
+	return newLoader(adapter), nil
+}
+
+// newLoader creates a new Loader on top of the operator.
+func newLoader(operator cacheOperator) *Loader {
+	return &Loader{
+		adapter:           operator,
+		codec:             JSONCodec{},
+		negativeKeyPrefix: defaultNegativeKeyPrefix,
+	}
+}
+
+// UseCodec sets the codec used to copy the loaded value into the
+// object reference of every caller when it cannot be read back from
+// the cache, e.g. because it could not be stored (JSONCodec by default).
+//
+// Otherwise, the loaded value is read back from the cache, so that it
+// is decoded by the codec of the adapter, as it would be by any Get.
+func (l *Loader) UseCodec(codec Codec) error {
+	if codec == nil {
+		return ErrNilCodec
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.codec = codec
+	return nil
+}
+
+// EnableNegativeResults makes the Loader remember, for the given TTL,
+// the keys for which the loader function returned ErrNotFound, so that
+// the following GetOrSet operations return ErrNotFound without calling
+// the loader function again.
+func (l *Loader) EnableNegativeResults(TTL time.Duration) error {
+	if TTL <= 0 {
+		return ErrInvalidTTL
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.negativeTTL = TTL
+	return nil
+}
+
+// DisableNegativeResults stops remembering negative results.
+func (l *Loader) DisableNegativeResults() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.negativeTTL = 0
+}
+
+// UseNegativeKeyPrefix sets the prefix of the keys used to remember the
+// negative results, "loader:negative:" by default. The keys are stored
+// with the adapter like any other key, so they are returned by Scan and
+// deleted by Clear and ClearPrefix.
+func (l *Loader) UseNegativeKeyPrefix(prefix string) error {
+	if prefix == "" {
+		return ErrInvalidNegativeKeyPrefix
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.negativeKeyPrefix = prefix
+	return nil
+}
+
+// GetOrSet obtains a value from the cache using a key, then tries to
+// unmarshal it into the object reference passed as parameter.
+//
+// If the key is not found, the value is obtained from the loader
+// function and stored in the cache with the given TTL (or the default
+// one if nil). Concurrent calls for the same key wait for a single call
+// of the loader function and receive a copy of its result.
+//
+// Errors of the loader function are returned to every waiting caller.
+// If the loaded value cannot be stored, it is still unmarshalled into
+// the object reference and the error of the Set is returned. If a
+// negative result cannot be stored, the error of the Set is returned
+// along with ErrNotFound.
+func (l *Loader) GetOrSet(key string, objectRef interface{}, TTL *time.Duration, loader LoaderFunc) error {
+	return l.GetOrSetContext(context.Background(), key, objectRef, TTL, func(ctx context.Context) (interface{}, error) {
+		return loader()
+	})
+}
+
+// GetOrSetContext is the same as GetOrSet, but honors the cancellation
+// and the deadline of the given context, and passes it to the loader.
+//
+// The shared load is not bound to the cancellation and the deadline of
+// the caller which started it, so it is not aborted when that caller
+// stops waiting: every caller stops waiting when its own context is done.
+func (l *Loader) GetOrSetContext(ctx context.Context, key string, objectRef interface{}, TTL *time.Duration, loader LoaderContextFunc) error {
+	if objectRef == nil {
+		return ErrGetRequiresObjectReference
+	}
+
+	err := l.adapter.GetContext(ctx, key, objectRef)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	resultChan := l.group.DoChan(key, func() (interface{}, error) {
+		return l.load(detachedContext{ctx}, key, TTL, loader)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-resultChan:
+		if result.Err != nil {
+			return result.Err
+		}
+
+		loaded := result.Val.(loadResult)
+		if loaded.setErr == nil {
+			// The value is read back from the cache, so that it is
+			// decoded by the codec of the adapter, as by any Get.
+			err := l.adapter.GetContext(ctx, key, objectRef)
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		content, err := Marshal(loaded.codec, loaded.object)
+		if err != nil {
+			return err
+		}
+
+		err = Unmarshal(loaded.codec, content, objectRef)
+		if err != nil {
+			return err
+		}
+
+		return loaded.setErr
+	}
+}
+
+// load calls the loader function and stores its result in the cache.
+func (l *Loader) load(ctx context.Context, key string, TTL *time.Duration, loader LoaderContextFunc) (interface{}, error) {
+	l.mutex.RLock()
+	codec, negativeTTL, negativeKey := l.codec, l.negativeTTL, l.negativeKeyPrefix+key
+	l.mutex.RUnlock()
+
+	if negativeTTL > 0 {
+		// An error while looking for the negative
+		// result only makes the loader function run.
+		isNegative, err := l.adapter.ExistsContext(ctx, negativeKey)
+		if err == nil && isNegative {
+			return nil, ErrNotFound
+		}
+	}
+
+	object, err := loader(ctx)
+	if errors.Is(err, ErrNotFound) && negativeTTL > 0 {
+		setErr := l.adapter.SetContext(ctx, negativeKey, true, &negativeTTL)
+		if setErr != nil {
+			return nil, multierror.Append(err, setErr)
+		}
+
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loadResult{
+		codec:  codec,
+		object: object,
+		setErr: l.adapter.SetContext(ctx, key, object, TTL),
+	}, nil
+}
+
+// detachedContext is a context carrying the values of
+// its parent, but never cancelled and without deadline.
+type detachedContext struct {
+	parent context.Context // The context carrying the values.
+}
+
+// Deadline returns no deadline.
+func (dc detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done returns a nil channel, since the context is never cancelled.
+func (dc detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err returns nil, since the context is never cancelled.
+func (dc detachedContext) Err() error {
+	return nil
+}
+
+// Value returns the value of the parent context for the key.
+func (dc detachedContext) Value(key interface{}) interface{} {
+	return dc.parent.Value(key)
+}
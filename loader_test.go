@@ -0,0 +1,283 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// codecTestValue is a value which is not copied
+// entirely by the JSON codec, but is by the Gob one.
+type codecTestValue struct {
+	Value  string
+	Hidden string `json:"-"`
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderTestSuite))
+}
+
+// LoaderTestSuite contains all methods to run tests in a
+// isolated suite.
+type LoaderTestSuite struct {
+	suite.Suite
+	adapter cacheadapters.CacheAdapter
+	loader  *cacheadapters.Loader
+}
+
+func (suite *LoaderTestSuite) SetupTest() {
+	suite.adapter, _ = inmemorycacheadapters.New(time.Second)
+	suite.loader, _ = cacheadapters.NewLoader(suite.adapter)
+}
+
+func (suite *LoaderTestSuite) TestNewLoader_NilAdapter() {
+	loader, err := cacheadapters.NewLoader(nil)
+	suite.Nil(loader, "Should be nil if NewLoader is without adapter")
+	suite.ErrorIs(err, cacheadapters.ErrInvalidLoaderAdapter, "Should give ErrInvalidLoaderAdapter on NewLoader without adapter")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_Hit() {
+	err := suite.adapter.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual testutil.TestStruct
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, func() (interface{}, error) {
+		suite.FailNow("Should not call the loader on a hit")
+		return nil, nil
+	})
+	suite.NoError(err, "Should not error on a hit")
+	suite.Equal(testutil.TestValue, actual, "Should be equal to the cached value")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_Miss() {
+	var actual testutil.TestStruct
+	err := suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, &testutil.DummyTTL, func() (interface{}, error) {
+		return testutil.TestValue, nil
+	})
+	suite.NoError(err, "Should not error on a miss")
+	suite.Equal(testutil.TestValue, actual, "Should be equal to the loaded value")
+
+	var cached testutil.TestStruct
+	err = suite.adapter.Get(testutil.TestKeyForGet, &cached)
+	suite.NoError(err, "The loaded value should be stored in cache")
+	suite.Equal(testutil.TestValue, cached, "The cached value should be equal to the loaded one")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_NilReference() {
+	err := suite.loader.GetOrSet(testutil.TestKeyForGet, nil, nil, func() (interface{}, error) {
+		return testutil.TestValue, nil
+	})
+	suite.ErrorIs(err, cacheadapters.ErrGetRequiresObjectReference, "Should error on nil object reference")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_CoalescesConcurrentMisses() {
+	var calls int32
+
+	loaderFunc := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond)
+		return testutil.TestValue, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]testutil.TestStruct, 20)
+	errs := make([]error, len(results))
+
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = suite.loader.GetOrSet(testutil.TestKeyForGet, &results[i], nil, loaderFunc)
+		}(i)
+	}
+
+	wg.Wait()
+
+	suite.Equal(int32(1), atomic.LoadInt32(&calls), "The loader should be called once")
+	for i := range results {
+		suite.NoError(errs[i], "Should not error on a coalesced miss")
+		suite.Equal(testutil.TestValue, results[i], "Every caller should receive the loaded value")
+	}
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_LoaderError() {
+	var actual testutil.TestStruct
+	err := suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, func() (interface{}, error) {
+		return nil, testutil.ErrTestingFailureCheck
+	})
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should return the error of the loader")
+
+	err = suite.adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Nothing should be stored on loader error")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_NegativeResults() {
+	err := suite.loader.EnableNegativeResults(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid EnableNegativeResults")
+
+	var calls int32
+
+	loaderFunc := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, cacheadapters.ErrNotFound
+	}
+
+	var actual testutil.TestStruct
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the negative result of the loader")
+
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the remembered negative result")
+
+	suite.Equal(int32(1), atomic.LoadInt32(&calls), "The loader should not be called for a remembered negative result")
+
+	suite.loader.DisableNegativeResults()
+
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the negative result of the loader")
+	suite.Equal(int32(2), atomic.LoadInt32(&calls), "The loader should be called when negative results are disabled")
+}
+
+func (suite *LoaderTestSuite) TestEnableNegativeResults_InvalidTTL() {
+	err := suite.loader.EnableNegativeResults(testutil.InvalidTTL)
+	suite.ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not enable negative results with an invalid TTL")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_FirstCallerGivesUp() {
+	started := make(chan struct{})
+
+	loaderFunc := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		return testutil.TestValue, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+
+	go func() {
+		var actual testutil.TestStruct
+		firstErr <- suite.loader.GetOrSetContext(ctx, testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	}()
+
+	<-started
+	cancel()
+	suite.ErrorIs(<-firstErr, context.Canceled, "The first caller should stop waiting when its context is done")
+
+	var actual testutil.TestStruct
+	err := suite.loader.GetOrSetContext(context.Background(), testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	suite.NoError(err, "The shared load should not be aborted by the first caller")
+	suite.Equal(testutil.TestValue, actual, "Should receive the loaded value")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_ContextValues() {
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+
+	var actual testutil.TestStruct
+	err := suite.loader.GetOrSetContext(ctx, testutil.TestKeyForGet, &actual, nil, func(ctx context.Context) (interface{}, error) {
+		suite.Equal("value", ctx.Value(contextKey{}), "The loader should receive the values of the context")
+		return testutil.TestValue, nil
+	})
+	suite.NoError(err, "Should not error on a miss")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_NegativeResultNotStored() {
+	adapter, _ := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithMaxItemBytes(1))
+	loader, _ := cacheadapters.NewLoader(adapter)
+
+	err := loader.EnableNegativeResults(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid EnableNegativeResults")
+
+	var actual testutil.TestStruct
+	err = loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, func() (interface{}, error) {
+		return nil, cacheadapters.ErrNotFound
+	})
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the negative result of the loader")
+	suite.ErrorIs(err, inmemorycacheadapters.ErrItemTooLarge, "Should return the error of the Set of the negative result")
+}
+
+func (suite *LoaderTestSuite) TestUseCodec() {
+	err := suite.loader.UseCodec(nil)
+	suite.ErrorIs(err, cacheadapters.ErrNilCodec, "Should not use a nil codec")
+
+	err = suite.loader.UseCodec(cacheadapters.GobCodec{})
+	suite.Require().NoError(err, "Should not error on valid UseCodec")
+
+	var actual testutil.TestStruct
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, func() (interface{}, error) {
+		return testutil.TestValue, nil
+	})
+	suite.NoError(err, "Should copy the loaded value with the codec")
+	suite.Equal(testutil.TestValue, actual, "Should be equal to the loaded value")
+}
+
+func (suite *LoaderTestSuite) TestGetOrSet_AdapterCodec() {
+	adapter, _ := inmemorycacheadapters.New(time.Second, inmemorycacheadapters.WithCodec(cacheadapters.GobCodec{}))
+	loader, _ := cacheadapters.NewLoader(adapter)
+
+	expected := codecTestValue{Value: "value", Hidden: "hidden"}
+
+	var actual codecTestValue
+	err := loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, func() (interface{}, error) {
+		return expected, nil
+	})
+	suite.NoError(err, "Should not error on a miss")
+	suite.Equal(expected, actual, "Should decode the loaded value with the codec of the adapter")
+
+	var cached codecTestValue
+	err = adapter.Get(testutil.TestKeyForGet, &cached)
+	suite.NoError(err, "The loaded value should be stored in cache")
+	suite.Equal(actual, cached, "Should receive the same value of a later Get")
+}
+
+func (suite *LoaderTestSuite) TestUseNegativeKeyPrefix() {
+	err := suite.loader.UseNegativeKeyPrefix("")
+	suite.ErrorIs(err, cacheadapters.ErrInvalidNegativeKeyPrefix, "Should not use an empty prefix")
+
+	err = suite.loader.EnableNegativeResults(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid EnableNegativeResults")
+
+	loaderFunc := func() (interface{}, error) {
+		return nil, cacheadapters.ErrNotFound
+	}
+
+	var actual testutil.TestStruct
+	err = suite.loader.GetOrSet(testutil.TestKeyForGet, &actual, nil, loaderFunc)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the negative result of the loader")
+
+	exists, err := suite.adapter.Exists("loader:negative:" + testutil.TestKeyForGet)
+	suite.NoError(err, "Should not error on valid Exists")
+	suite.True(exists, "Should remember the negative result in a key with the default prefix")
+
+	err = suite.loader.UseNegativeKeyPrefix("custom:")
+	suite.Require().NoError(err, "Should not error on valid UseNegativeKeyPrefix")
+
+	err = suite.loader.GetOrSet(testutil.TestKeyForSet, &actual, nil, loaderFunc)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should return the negative result of the loader")
+
+	exists, err = suite.adapter.Exists("custom:" + testutil.TestKeyForSet)
+	suite.NoError(err, "Should not error on valid Exists")
+	suite.True(exists, "Should remember the negative result in a key with the given prefix")
+}
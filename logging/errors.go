@@ -0,0 +1,31 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters
+
+import "fmt"
+
+var (
+	// ErrNilLogger will come out if you try to pass a nil logger.
+	ErrNilLogger = fmt.Errorf("you must pass a valid logger, not a nil one")
+	// ErrInvalidAdapterName will come out if you try to pass an
+	// empty name to WithAdapterName.
+	ErrInvalidAdapterName = fmt.Errorf("you must pass a non-empty adapter name")
+	// ErrNegativeThreshold will come out if you try to pass a
+	// negative duration to WithSlowThreshold.
+	ErrNegativeThreshold = fmt.Errorf("you must pass a non-negative slow operation threshold")
+	// ErrNilKeyRedactor will come out if you try to pass a nil
+	// function to WithKeyRedactor.
+	ErrNilKeyRedactor = fmt.Errorf("you must pass a valid key redactor, not a nil one")
+)
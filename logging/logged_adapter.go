@@ -0,0 +1,42 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters
+
+import (
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+)
+
+// New wraps the adapter, and the sessions it opens, logging their
+// failed, warned and slow operations with the given logger.
+func New(adapter cacheadapters.CacheAdapter, logger Logger, opts ...Option) (*interceptorcacheadapters.InterceptedAdapter, error) {
+	operationLogger, err := newOperationLogger(logger, adapterType(adapter), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return interceptorcacheadapters.New(adapter, operationLogger.Intercept)
+}
+
+// NewSession wraps the session, logging its failed,
+// warned and slow operations with the given logger.
+func NewSession(session cacheadapters.CacheSessionAdapter, logger Logger, opts ...Option) (*interceptorcacheadapters.InterceptedSessionAdapter, error) {
+	operationLogger, err := newOperationLogger(logger, adapterType(session), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return interceptorcacheadapters.NewSession(session, operationLogger.Intercept)
+}
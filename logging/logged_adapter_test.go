@@ -0,0 +1,268 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	loggingcacheadapters "github.com/tryvium-travels/golang-cache-adapters/logging"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// LoggedAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type LoggedAdapterTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL time.Duration
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+// entry is a log entry written to the recorder.
+type entry struct {
+	level  loggingcacheadapters.Level
+	msg    string
+	fields map[string]interface{}
+}
+
+// recorder is a Logger recording the entries.
+type recorder struct {
+	mutex   sync.Mutex
+	entries []entry
+}
+
+func (r *recorder) Log(ctx context.Context, level loggingcacheadapters.Level, msg string, fields ...loggingcacheadapters.Field) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := entry{level: level, msg: msg, fields: make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+
+	r.entries = append(r.entries, entry)
+}
+
+// failing is an interceptor failing every operation.
+func failing(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	return testutil.ErrTestingFailureCheck
+}
+
+// sleeping is an interceptor slowing down every operation.
+func sleeping(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	time.Sleep(5 * time.Millisecond)
+	return next(ctx, op)
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return loggingcacheadapters.New(inMemoryAdapter, &recorder{}, loggingcacheadapters.WithSlowThreshold(time.Second))
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := inMemoryAdapter.OpenSession()
+		if err != nil {
+			return nil, err
+		}
+
+		return loggingcacheadapters.NewSession(session, &recorder{})
+	}
+}
+
+// newLoggedTestSuite creates a new test suite with tests for logged
+// adapters and sessions, backed by an In-Memory adapter.
+func newLoggedTestSuite(defaultTTL time.Duration) *LoggedAdapterTestSuite {
+	var suite suite.Suite
+
+	return &LoggedAdapterTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestLoggedAdapterSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newLoggedTestSuite(defaultTTL))
+}
+
+func (suite *LoggedAdapterTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+func (suite *LoggedAdapterTestSuite) newBrokenAdapter() cacheadapters.CacheAdapter {
+	brokenAdapter, err := interceptorcacheadapters.New(suite.newInMemoryAdapter(), failing)
+	suite.Require().NoError(err, "Should not fail to create the broken adapter")
+
+	return brokenAdapter
+}
+
+func (suite *LoggedAdapterTestSuite) TestNew_InvalidArguments() {
+	adapter, err := loggingcacheadapters.New(nil, &recorder{})
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+
+	adapter, err = loggingcacheadapters.New(suite.newInMemoryAdapter(), nil)
+	suite.Require().Nil(adapter, "Should be nil on nil logger")
+	suite.Require().ErrorIs(err, loggingcacheadapters.ErrNilLogger, "Should give error on nil logger")
+
+	adapter, err = loggingcacheadapters.New(suite.newInMemoryAdapter(), &recorder{}, loggingcacheadapters.WithAdapterName(""))
+	suite.Require().Nil(adapter, "Should be nil on empty adapter name")
+	suite.Require().ErrorIs(err, loggingcacheadapters.ErrInvalidAdapterName, "Should give error on empty adapter name")
+
+	adapter, err = loggingcacheadapters.New(suite.newInMemoryAdapter(), &recorder{}, loggingcacheadapters.WithSlowThreshold(-time.Second))
+	suite.Require().Nil(adapter, "Should be nil on negative threshold")
+	suite.Require().ErrorIs(err, loggingcacheadapters.ErrNegativeThreshold, "Should give error on negative threshold")
+
+	adapter, err = loggingcacheadapters.New(suite.newInMemoryAdapter(), &recorder{}, loggingcacheadapters.WithKeyRedactor(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil key redactor")
+	suite.Require().ErrorIs(err, loggingcacheadapters.ErrNilKeyRedactor, "Should give error on nil key redactor")
+
+	session, err := loggingcacheadapters.NewSession(nil, &recorder{})
+	suite.Require().Nil(session, "Should be nil on nil session")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil session")
+}
+
+func (suite *LoggedAdapterTestSuite) TestLog_Failures() {
+	logger := &recorder{}
+	adapter, _ := loggingcacheadapters.New(suite.newBrokenAdapter(), logger, loggingcacheadapters.WithAdapterName("broken"))
+
+	err := adapter.Delete(testutil.TestKeyForDelete)
+	suite.Require().ErrorIs(err, testutil.ErrTestingFailureCheck, "Should return the error of the operation")
+
+	suite.Require().Len(logger.entries, 1, "Should log the failure")
+	suite.Require().Equal(loggingcacheadapters.LevelError, logger.entries[0].level, "Should log the failure as an error")
+	suite.Require().Equal(loggingcacheadapters.MessageFailed, logger.entries[0].msg, "Should describe the failure")
+	suite.Require().Equal("Delete", logger.entries[0].fields["operation"], "Should log the operation")
+	suite.Require().Equal("broken", logger.entries[0].fields["adapter"], "Should log the adapter")
+	suite.Require().Equal(testutil.TestKeyForDelete, logger.entries[0].fields["key"], "Should log the key")
+	suite.Require().Equal(testutil.ErrTestingFailureCheck, logger.entries[0].fields["error"], "Should log the error")
+}
+
+func (suite *LoggedAdapterTestSuite) TestLog_NoEntries() {
+	logger := &recorder{}
+	adapter, _ := loggingcacheadapters.New(suite.newInMemoryAdapter(), logger, loggingcacheadapters.WithSlowThreshold(time.Minute))
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should miss the key never set")
+
+	suite.Require().Empty(logger.entries, "Should not log the successful operations and the misses")
+}
+
+func (suite *LoggedAdapterTestSuite) TestLog_MultiCacheWarnings() {
+	multiCacheAdapter, err := multicacheadapters.New(suite.newBrokenAdapter(), suite.newInMemoryAdapter())
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+	multiCacheAdapter.EnableWarnings()
+
+	logger := &recorder{}
+	adapter, _ := loggingcacheadapters.New(multiCacheAdapter, logger)
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn about the failing sub-adapter")
+
+	suite.Require().Len(logger.entries, 1, "Should log the warning")
+	suite.Require().Equal(loggingcacheadapters.LevelWarn, logger.entries[0].level, "Should log the warning as a warning")
+	suite.Require().Equal(loggingcacheadapters.MessageWarning, logger.entries[0].msg, "Should describe the warning")
+	suite.Require().Equal("multicacheadapters.MultiCacheAdapter", logger.entries[0].fields["adapter"], "Should use the type of the adapter by default")
+	suite.Require().ErrorIs(logger.entries[0].fields["error"].(error), testutil.ErrTestingFailureCheck, "Should log the error of the sub-adapter")
+}
+
+func (suite *LoggedAdapterTestSuite) TestLog_SlowOperations() {
+	slowAdapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), sleeping)
+
+	logger := &recorder{}
+	adapter, _ := loggingcacheadapters.New(slowAdapter, logger, loggingcacheadapters.WithSlowThreshold(time.Millisecond))
+
+	_, err := adapter.Exists(testutil.TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+
+	suite.Require().Len(logger.entries, 1, "Should log the slow operation")
+	suite.Require().Equal(loggingcacheadapters.LevelWarn, logger.entries[0].level, "Should log the slow operation as a warning")
+	suite.Require().Equal(loggingcacheadapters.MessageSlow, logger.entries[0].msg, "Should describe the slow operation")
+	suite.Require().Equal(true, logger.entries[0].fields["slow"], "Should flag the slow operation")
+	suite.Require().GreaterOrEqual(logger.entries[0].fields["duration"], 5*time.Millisecond, "Should log the duration")
+}
+
+func (suite *LoggedAdapterTestSuite) TestLog_KeyRedaction() {
+	logger := &recorder{}
+	adapter, _ := loggingcacheadapters.New(suite.newBrokenAdapter(), logger, loggingcacheadapters.WithKeyRedactor(loggingcacheadapters.RedactKey))
+
+	_, err := adapter.DeleteMany([]string{testutil.TestKeyForMany})
+	suite.Require().Error(err, "Should return the error of the operation")
+
+	err = adapter.Delete(testutil.TestKeyForDelete)
+	suite.Require().Error(err, "Should return the error of the operation")
+
+	suite.Require().Len(logger.entries, 2, "Should log the failures")
+	suite.Require().Equal([]string{loggingcacheadapters.RedactedKey}, logger.entries[0].fields["keys"], "Should redact the keys")
+	suite.Require().Equal(loggingcacheadapters.RedactedKey, logger.entries[1].fields["key"], "Should redact the key")
+
+	suite.Require().Equal(loggingcacheadapters.HashKey(testutil.TestKeyForDelete), loggingcacheadapters.HashKey(testutil.TestKeyForDelete), "Should hash the same key in the same way")
+	suite.Require().NotEqual(testutil.TestKeyForDelete, loggingcacheadapters.HashKey(testutil.TestKeyForDelete), "Should hide the key")
+}
+
+func (suite *LoggedAdapterTestSuite) TestStdLogger() {
+	var buffer bytes.Buffer
+	logger := loggingcacheadapters.NewStdLogger(log.New(&buffer, "", 0))
+
+	adapter, _ := loggingcacheadapters.New(suite.newBrokenAdapter(), logger, loggingcacheadapters.WithAdapterName("broken"))
+
+	err := adapter.Delete(testutil.TestKeyForDelete)
+	suite.Require().Error(err, "Should return the error of the operation")
+
+	suite.Require().Contains(buffer.String(), `ERROR cache operation failed operation="Delete" adapter="broken" key="test:key:for-delete:1234" duration=`, "Should write the fields")
+	suite.Require().Contains(buffer.String(), fmt.Sprintf("error=%q\n", testutil.ErrTestingFailureCheck.Error()), "Should write the error")
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Level is the severity of a log entry.
+//
+// The levels have the same values of the ones of log/slog, so they
+// can be converted with slog.Level(level).
+type Level int
+
+// The levels of the log entries.
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// Field is a key-value pair attached to a log entry.
+type Field struct {
+	Key   string      // The name of the field.
+	Value interface{} // The value of the field.
+}
+
+// Logger writes the log entries.
+//
+// Implement it over your logging library, for example with log/slog:
+//
+//	type slogLogger struct {
+//	    logger *slog.Logger
+//	}
+//
+//	func (l slogLogger) Log(ctx context.Context, level loggingcacheadapters.Level, msg string, fields ...loggingcacheadapters.Field) {
+//	    attrs := make([]slog.Attr, 0, len(fields))
+//	    for _, field := range fields {
+//	        attrs = append(attrs, slog.Any(field.Key, field.Value))
+//	    }
+//
+//	    l.logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+//	}
+type Logger interface {
+	// Log writes a log entry with the given level, message and fields.
+	Log(ctx context.Context, level Level, msg string, fields ...Field)
+}
+
+// LoggerFunc is a function implementing Logger.
+type LoggerFunc func(ctx context.Context, level Level, msg string, fields ...Field)
+
+// Log calls the function.
+func (f LoggerFunc) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	f(ctx, level, msg, fields...)
+}
+
+// stdLogger is a Logger writing to a log.Logger.
+type stdLogger struct {
+	logger *log.Logger // The logger writing the entries.
+}
+
+// NewStdLogger creates a Logger writing the entries to the given
+// log.Logger, or to the standard one if nil, in the form:
+//
+//	WARN slow cache operation operation=Get adapter=redis key="a:key" duration=120ms
+func NewStdLogger(logger *log.Logger) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return stdLogger{logger: logger}
+}
+
+// Log writes the entry to the log.Logger.
+func (l stdLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	var builder strings.Builder
+
+	builder.WriteString(level.String())
+	builder.WriteString(" ")
+	builder.WriteString(msg)
+
+	for _, field := range fields {
+		builder.WriteString(" ")
+		builder.WriteString(field.Key)
+		builder.WriteString("=")
+		builder.WriteString(formatValue(field.Value))
+	}
+
+	l.logger.Print(builder.String())
+}
+
+// formatValue formats the value of a field, quoting the strings
+// and the errors so that the entries can be parsed.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case error:
+		return fmt.Sprintf("%q", v.Error())
+	case []string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+)
+
+// The messages of the log entries.
+const (
+	MessageFailed  = "cache operation failed"                  // The message of the failed operations, logged with LevelError.
+	MessageWarning = "cache operation succeeded with warnings" // The message of the operations of a MultiCacheAdapter succeeded with a warning, logged with LevelWarn.
+	MessageSlow    = "slow cache operation"                    // The message of the slow operations, logged with LevelWarn.
+)
+
+// OperationLogger logs the failed, the warned and the slow
+// operations passing through its Intercept function.
+type OperationLogger struct {
+	logger        Logger        // The logger writing the entries.
+	adapterName   string        // The name of the adapter, if any.
+	slowThreshold time.Duration // The duration over which an operation is slow, zero to disable.
+	keyRedactor   KeyRedactor   // The function converting the keys, if any.
+}
+
+// NewOperationLogger creates a new OperationLogger.
+//
+// Use New or NewSession instead to log the operations of an adapter,
+// unless you want to add the Intercept function to your own chain of
+// interceptors.
+func NewOperationLogger(logger Logger, opts ...Option) (*OperationLogger, error) {
+	return newOperationLogger(logger, "", opts)
+}
+
+// newOperationLogger creates a new OperationLogger, with the given
+// adapter name unless set by the options.
+func newOperationLogger(logger Logger, adapterName string, opts []Option) (*OperationLogger, error) {
+	if logger == nil {
+		return nil, ErrNilLogger
+	}
+
+	settings, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.adapterName != "" {
+		adapterName = settings.adapterName
+	}
+
+	return &OperationLogger{
+		logger:        logger,
+		adapterName:   adapterName,
+		slowThreshold: settings.slowThreshold,
+		keyRedactor:   settings.keyRedactor,
+	}, nil
+}
+
+// Intercept is an interceptorcacheadapters.Interceptor logging
+// the operation if it fails, succeeds with the warnings of a
+// MultiCacheAdapter or lasts more than the slow threshold.
+//
+// Misses (cacheadapters.ErrNotFound) and version conflicts
+// (cacheadapters.ErrVersionConflict) are not logged as failures.
+func (ol *OperationLogger) Intercept(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	start := time.Now()
+	err := next(ctx, op)
+	duration := time.Since(start)
+
+	slow := ol.slowThreshold > 0 && duration > ol.slowThreshold
+
+	switch {
+	case errors.Is(err, multicacheadapters.ErrMultiCacheWarning):
+		ol.log(ctx, LevelWarn, MessageWarning, op, err, duration, slow)
+	case isFailure(err):
+		ol.log(ctx, LevelError, MessageFailed, op, err, duration, slow)
+	case slow:
+		ol.log(ctx, LevelWarn, MessageSlow, op, nil, duration, slow)
+	}
+
+	return err
+}
+
+// log writes an entry for the operation, with its fields.
+func (ol *OperationLogger) log(ctx context.Context, level Level, msg string, op *interceptorcacheadapters.Operation, err error, duration time.Duration, slow bool) {
+	fields := []Field{{Key: "operation", Value: string(op.Name)}}
+
+	if ol.adapterName != "" {
+		fields = append(fields, Field{Key: "adapter", Value: ol.adapterName})
+	}
+
+	if op.Key != "" {
+		fields = append(fields, Field{Key: "key", Value: ol.redact(op.Key)})
+	}
+
+	if op.Keys != nil {
+		keys := make([]string, len(op.Keys))
+		for i, key := range op.Keys {
+			keys[i] = ol.redact(key)
+		}
+
+		fields = append(fields, Field{Key: "keys", Value: keys})
+	}
+
+	if op.Prefix != "" {
+		fields = append(fields, Field{Key: "prefix", Value: ol.redact(op.Prefix)})
+	}
+
+	if op.Tag != "" {
+		fields = append(fields, Field{Key: "tag", Value: op.Tag})
+	}
+
+	fields = append(fields, Field{Key: "duration", Value: duration})
+
+	if slow {
+		fields = append(fields, Field{Key: "slow", Value: true})
+	}
+
+	if err != nil {
+		fields = append(fields, Field{Key: "error", Value: err})
+	}
+
+	ol.logger.Log(ctx, level, msg, fields...)
+}
+
+// redact converts the key with the KeyRedactor, if any.
+func (ol *OperationLogger) redact(key string) string {
+	if ol.keyRedactor == nil {
+		return key
+	}
+
+	return ol.keyRedactor(key)
+}
+
+// adapterType returns the type of the adapter, used as
+// its name if not set with WithAdapterName.
+func adapterType(adapter interface{}) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", adapter), "*")
+}
+
+// isFailure checks if the error reports a failure of the operation,
+// rather than one of its expected outcomes, like a miss.
+func isFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, cacheadapters.ErrNotFound) &&
+		!errors.Is(err, cacheadapters.ErrVersionConflict)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingcacheadapters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// KeyRedactor converts a key before writing it in the logs,
+// (e.g. to hide the personal data it may contain).
+type KeyRedactor func(key string) string
+
+// RedactedKey is the key written in the logs by RedactKey.
+const RedactedKey = "[REDACTED]"
+
+// RedactKey is a KeyRedactor hiding the whole key.
+func RedactKey(key string) string {
+	return RedactedKey
+}
+
+// HashKey is a KeyRedactor replacing the key with its hash, so that
+// the entries of the same key can still be found.
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:8])
+}
+
+// Option configures an OperationLogger when passed to
+// NewOperationLogger, New or NewSession.
+type Option func(*settings) error
+
+// settings contains the configuration of an OperationLogger.
+type settings struct {
+	adapterName   string        // The name of the adapter, written in the adapter field.
+	slowThreshold time.Duration // The duration over which an operation is slow, zero to disable.
+	keyRedactor   KeyRedactor   // The function converting the keys, nil to write them as they are.
+}
+
+// newSettings creates the configuration of an OperationLogger,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithAdapterName sets the name of the adapter, written in the
+// adapter field of the entries (e.g. "redis"). The type of the
+// adapter is used if not set.
+func WithAdapterName(name string) Option {
+	return func(s *settings) error {
+		if name == "" {
+			return ErrInvalidAdapterName
+		}
+
+		s.adapterName = name
+		return nil
+	}
+}
+
+// WithSlowThreshold logs the operations lasting more than the given
+// duration, zero (the default) disables the detection of the slow
+// operations.
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(s *settings) error {
+		if threshold < 0 {
+			return ErrNegativeThreshold
+		}
+
+		s.slowThreshold = threshold
+		return nil
+	}
+}
+
+// WithKeyRedactor sets the function converting the keys and the
+// prefixes before writing them in the logs (e.g. RedactKey or
+// HashKey). The keys are written as they are if not set.
+func WithKeyRedactor(redactor KeyRedactor) Option {
+	return func(s *settings) error {
+		if redactor == nil {
+			return ErrNilKeyRedactor
+		}
+
+		s.keyRedactor = redactor
+		return nil
+	}
+}
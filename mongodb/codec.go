@@ -0,0 +1,37 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcacheadapters
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BSONCodec is the Codec using BSON, which is the default
+// of the MongoDB adapters.
+//
+// The values encoded by BSONCodec are stored as embedded documents,
+// so they can be queried, while the values encoded by the other codecs
+// are stored as binary data. Only structs and maps can be encoded.
+type BSONCodec struct{}
+
+// Marshal encodes the object as a BSON document.
+func (BSONCodec) Marshal(object interface{}) ([]byte, error) {
+	return bson.Marshal(&object)
+}
+
+// Unmarshal decodes the BSON document into the object reference.
+func (BSONCodec) Unmarshal(content []byte, objectRef interface{}) error {
+	return bson.Unmarshal(content, objectRef)
+}
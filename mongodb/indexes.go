@@ -0,0 +1,42 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcacheadapters
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// cacheIndexes are the indexes of the cache collection:
+//   - the unique index on the key, used by every operation (including the prefix queries
+//     of Scan), which also prevents concurrent upserts from storing the same key twice;
+//   - the TTL index on the expiration time, so MongoDB removes the expired documents;
+//   - the index on the tags, used by InvalidateTag.
+var cacheIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+	{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	{Keys: bson.D{{Key: "tags", Value: 1}}},
+}
+
+// EnsureIndexes creates the indexes used by the adapter on the cache
+// collection, if they do not exist yet. Call it once when the
+// application starts, before using NewSession on the collection.
+func EnsureIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateMany(ctx, cacheIndexes)
+	return err
+}
@@ -15,6 +15,7 @@
 package mongodbcacheadapters
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -22,16 +23,17 @@ import (
 )
 
 type MongoDBAdapter struct {
-	client         MongoClient   // The MongoDB client interface to interact with the collection.
-	databaseName   string        // The name of the database used in MongoDB to cache data.
-	collectionName string        // The name of the collection used in MongoDB to cache data.
-	defaultTTL     time.Duration // The defaultTTL of the Set operations.
+	client         MongoClient         // The MongoDB client interface to interact with the collection.
+	databaseName   string              // The name of the database used in MongoDB to cache data.
+	collectionName string              // The name of the collection used in MongoDB to cache data.
+	defaultTTL     time.Duration       // The defaultTTL of the Set operations.
+	codec          cacheadapters.Codec // The codec of the values in cache.
 }
 
 // NesSession create a new MongoDB Cache adapter from an existing
 // MongoDB client and the name of the database and the collection,
-// with a given default TTL.
-func New(client MongoClient, databaseName string, collectionName string, defaultTTL time.Duration) (cacheadapters.CacheAdapter, error) {
+// with a given default TTL, configured with the given options.
+func New(client MongoClient, databaseName string, collectionName string, defaultTTL time.Duration, opts ...Option) (cacheadapters.CacheAdapter, error) {
 	if client == nil {
 		return nil, ErrNilClient
 	}
@@ -50,53 +52,436 @@ func New(client MongoClient, databaseName string, collectionName string, default
 		return nil, cacheadapters.ErrInvalidTTL
 	}
 
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &MongoDBAdapter{
 		client:         client,
 		databaseName:   databaseName,
 		collectionName: collectionName,
 		defaultTTL:     defaultTTL,
+		codec:          config.codec,
 	}, nil
 }
 
+// EnsureIndexes creates the indexes used by the adapter on the cache
+// collection, if they do not exist yet. Call it once when the
+// application starts.
+//
+// Since New returns a cacheadapters.CacheAdapter, use a type
+// assertion to call it:
+//
+//	err := adapter.(*mongodbcacheadapters.MongoDBAdapter).EnsureIndexes(ctx)
+func (ma *MongoDBAdapter) EnsureIndexes(ctx context.Context) error {
+	return EnsureIndexes(ctx, ma.client.Database(ma.databaseName).Collection(ma.collectionName))
+}
+
+// OpenSession opens a new Cache Session.
 func (ma *MongoDBAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
+	return ma.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext opens a new Cache Session, honoring the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	collection := ma.client.Database(ma.databaseName).Collection(ma.collectionName)
 
-	return NewSession(collection, ma.defaultTTL)
+	return NewSession(collection, ma.defaultTTL, WithCodec(ma.codec))
 }
 
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (ma *MongoDBAdapter) Get(key string, objectRef interface{}) error {
-	msa, _ := ma.OpenSession()
+	return ma.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
 
 	defer msa.Close()
 
-	return msa.Get(key, objectRef)
+	return msa.GetContext(ctx, key, objectRef)
 }
 
 // Set sets a value represented by the object parameter into the cache, with the specified key.
 func (ma *MongoDBAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
-	rsa, _ := ma.OpenSession()
-	defer rsa.Close()
+	return ma.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
 
-	return rsa.Set(key, object, TTL)
+	return msa.SetContext(ctx, key, object, TTL)
 }
 
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (ma *MongoDBAdapter) SetTTL(key string, newTTL time.Duration) error {
-	rsa, _ := ma.OpenSession()
+	return ma.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
 
-	defer rsa.Close()
+	defer msa.Close()
 
-	return rsa.SetTTL(key, newTTL)
+	return msa.SetTTLContext(ctx, key, newTTL)
 }
 
 // Delete deletes a key from the cache.
 func (ma *MongoDBAdapter) Delete(key string) error {
-	rsa, _ := ma.OpenSession()
+	return ma.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) DeleteContext(ctx context.Context, key string) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
+
+	return msa.DeleteContext(ctx, key)
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (ma *MongoDBAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return ma.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer msa.Close()
+
+	return msa.GetManyContext(ctx, objectRefs)
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (ma *MongoDBAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return ma.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer msa.Close()
+
+	return msa.SetManyContext(ctx, objects, TTL)
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (ma *MongoDBAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return ma.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer msa.Close()
+
+	return msa.DeleteManyContext(ctx, keys)
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (ma *MongoDBAdapter) Exists(key string) (bool, error) {
+	return ma.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer msa.Close()
+
+	return msa.ExistsContext(ctx, key)
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (ma *MongoDBAdapter) TTL(key string) (time.Duration, error) {
+	return ma.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer msa.Close()
+
+	return msa.TTLContext(ctx, key)
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// If the key is not present or expired, the counter starts from
+// zero and the given TTL (or the default one if nil) is applied,
+// otherwise the TTL of the counter is left untouched.
+//
+// Only the counters created by Increment and Decrement can be
+// incremented: a value written by the Set operations, even if it is
+// an integer, is left untouched and cacheadapters.ErrInvalidCounter
+// is returned.
+func (ma *MongoDBAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ma.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer msa.Close()
+
+	return msa.IncrementContext(ctx, key, delta, TTL)
+}
 
-	defer rsa.Close()
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (ma *MongoDBAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ma.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer msa.Close()
+
+	return msa.DecrementContext(ctx, key, delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (ma *MongoDBAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ma.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer msa.Close()
+
+	return msa.SetIfAbsentContext(ctx, key, object, TTL)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (ma *MongoDBAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ma.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer msa.Close()
+
+	return msa.SetIfPresentContext(ctx, key, object, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (ma *MongoDBAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return ma.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer msa.Close()
+
+	return msa.GetWithVersionContext(ctx, key, objectRef)
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (ma *MongoDBAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return ma.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
+
+	return msa.CompareAndSwapContext(ctx, key, version, newObject, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+func (ma *MongoDBAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return ma.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
+
+	return msa.SetWithTagsContext(ctx, key, object, TTL, tags...)
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+func (ma *MongoDBAdapter) InvalidateTag(tag string) error {
+	return ma.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
+
+	return msa.InvalidateTagContext(ctx, tag)
+}
+
+// Scan returns an iterator over the keys of the cache starting
+// with the given prefix, or over all the keys if it is empty.
+func (ma *MongoDBAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return ma.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer msa.Close()
+
+	return msa.ScanContext(ctx, prefix)
+}
+
+// Clear deletes all the documents of the cache collection.
+func (ma *MongoDBAdapter) Clear() error {
+	return ma.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (ma *MongoDBAdapter) ClearContext(ctx context.Context) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
+
+	return msa.ClearContext(ctx)
+}
+
+// ClearPrefix deletes all the documents whose key starts with the given
+// prefix, or all the documents of the cache collection if it is empty.
+func (ma *MongoDBAdapter) ClearPrefix(prefix string) error {
+	return ma.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (ma *MongoDBAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	msa, err := ma.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer msa.Close()
 
-	return rsa.Delete(key)
+	return msa.ClearPrefixContext(ctx, prefix)
 }
@@ -17,13 +17,17 @@ package mongodbcacheadapters_test
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
 	mongodbcacheadapters "github.com/tryvium-travels/golang-cache-adapters/mongodb"
 	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -277,3 +281,168 @@ func (suite *MongoDBAdapterTestSuite) TestOpenSession_OK() {
 	suite.Require().NoError(err, "Should not error on creating a valid session adapter")
 	suite.Require().NotNil(sessionAdapter, "Should be successfully created")
 }
+
+func (suite *MongoDBAdapterTestSuite) TestNew_NilCodec() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL, mongodbcacheadapters.WithCodec(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestNew_WithCodec() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL, mongodbcacheadapters.WithCodec(cacheadapters.JSONCodec{}))
+	suite.Require().NoError(err, "Should not give error on valid codec")
+
+	// plain strings cannot be encoded as BSON documents,
+	// so this only works if the codec is used.
+	expected := "a plain string"
+	err = adapter.Set(testutil.TestKeyForSet, expected, nil)
+	suite.Require().NoError(err, "Should encode the value with the codec")
+
+	var actual string
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should decode the value with the codec")
+	suite.Require().Equal(expected, actual, "Should be equal to the value set")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestSet_InvalidRawDocument() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not give error on valid adapter")
+
+	err = adapter.Set(testutil.TestKeyForSet, cacheadapters.RawMessage(testutil.TestValueJSON), nil)
+	suite.Require().Error(err, "Should error on raw content which is not a BSON document")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestRawStorage_Interoperability() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL, mongodbcacheadapters.WithCodec(cacheadapters.JSONCodec{}))
+	suite.Require().NoError(err, "Should not give error on valid codec")
+
+	otherAdapter, err := inmemorycacheadapters.New(testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not give error on valid in-memory adapter")
+
+	err = otherAdapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var raw cacheadapters.RawMessage
+	err = otherAdapter.Get(testutil.TestKeyForSet, &raw)
+	suite.Require().NoError(err, "Should read the raw content")
+
+	err = adapter.Set(testutil.TestKeyForSet, raw, nil)
+	suite.Require().NoError(err, "Should write the raw content")
+
+	var actualRaw cacheadapters.RawMessage
+	err = adapter.Get(testutil.TestKeyForSet, &actualRaw)
+	suite.Require().NoError(err, "Should read the raw content")
+	suite.Require().Equal(testutil.TestValueRaw, actualRaw, "Should store the exact bytes encoded by the other adapter")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should decode the content written by the other adapter")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestIncrement_LeavesValuesUntouched() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not give error on valid adapter")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	version, err := adapter.GetWithVersion(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	_, err = adapter.Increment(testutil.TestKeyForSet, 1, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidCounter, "Should not increment a value written by Set")
+
+	err = adapter.CompareAndSwap(testutil.TestKeyForSet, version, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not change the version of the value on a failed Increment")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestEnsureIndexes() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	adapter, err := mongodbcacheadapters.New(client, testDatabase, testCollection, testutil.DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid adapter")
+
+	for i := 0; i < 2; i++ {
+		err = adapter.(*mongodbcacheadapters.MongoDBAdapter).EnsureIndexes(context.Background())
+		suite.Require().NoError(err, "Should not error when creating the indexes, even if they exist")
+	}
+
+	cursor, err := client.Database(testDatabase).Collection(testCollection).Indexes().List(context.Background())
+	suite.Require().NoError(err, "Should list the indexes")
+
+	var indexes []bson.M
+	err = cursor.All(context.Background(), &indexes)
+	suite.Require().NoError(err, "Should decode the indexes")
+
+	indexesByName := make(map[string]bson.M, len(indexes))
+	for _, index := range indexes {
+		indexesByName[index["name"].(string)] = index
+	}
+
+	suite.Require().Contains(indexesByName, "key_1", "Should index the keys")
+	suite.Require().Equal(true, indexesByName["key_1"]["unique"], "Should not allow the same key twice")
+	suite.Require().Contains(indexesByName, "tags_1", "Should index the tags")
+	suite.Require().Contains(indexesByName, "expires_at_1", "Should index the expiration time")
+	suite.Require().EqualValues(0, indexesByName["expires_at_1"]["expireAfterSeconds"], "Should remove the documents once expired")
+}
+
+func (suite *MongoDBAdapterTestSuite) TestSetIfAbsent_Concurrent() {
+	adapter, err := newTestAdapterFunc(testutil.DummyTTL)()
+	suite.Require().NoError(err, "Should not error on valid adapter")
+
+	err = adapter.(*mongodbcacheadapters.MongoDBAdapter).EnsureIndexes(context.Background())
+	suite.Require().NoError(err, "Should not error when creating the indexes")
+
+	const writers = 16
+
+	var wg sync.WaitGroup
+	var written int32
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ok, err := adapter.SetIfAbsent(testutil.TestKeyForSet, testutil.TestStruct{Value: fmt.Sprint(i)}, nil)
+			if ok {
+				atomic.AddInt32(&written, 1)
+			}
+
+			errs <- err
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		suite.Require().NoError(err, "Should not error on concurrent SetIfAbsent")
+	}
+
+	suite.Require().EqualValues(1, written, "Only the first writer should write the value")
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(localMongoDBServer.URI()))
+	suite.Require().NoError(err, "Should not error on creating a valid mongo client")
+
+	count, err := client.Database(testDatabase).Collection(testCollection).CountDocuments(context.Background(), bson.M{"key": testutil.TestKeyForSet})
+	suite.Require().NoError(err, "Should count the documents of the key")
+	suite.Require().EqualValues(1, count, "Should store a single document for the key")
+}
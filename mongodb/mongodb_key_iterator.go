@@ -0,0 +1,59 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcacheadapters
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoDBKeyIterator is the KeyIterator implementation for
+// MongoDB, which reads the keys from a cursor.
+type mongoDBKeyIterator struct {
+	ctx    context.Context // The context of the Scan operation.
+	cursor *mongo.Cursor   // The cursor over the documents of the keys.
+	key    string          // The current key.
+}
+
+// Next advances the iterator to the next key, returns false
+// when there are no more keys or an error occurred.
+func (mki *mongoDBKeyIterator) Next() bool {
+	for mki.cursor.Next(mki.ctx) {
+		key, ok := mki.cursor.Current.Lookup("key").StringValueOK()
+		if ok {
+			mki.key = key
+			return true
+		}
+	}
+
+	mki.key = ""
+	return false
+}
+
+// Key returns the current key of the iterator.
+func (mki *mongoDBKeyIterator) Key() string {
+	return mki.key
+}
+
+// Err returns the error occurred during the iteration, if any.
+func (mki *mongoDBKeyIterator) Err() error {
+	return mki.cursor.Err()
+}
+
+// Close closes the underlying cursor.
+func (mki *mongoDBKeyIterator) Close() error {
+	return mki.cursor.Close(mki.ctx)
+}
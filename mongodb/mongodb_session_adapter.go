@@ -16,26 +16,56 @@ package mongodbcacheadapters
 
 import (
 	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
 	"time"
 
 	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoDBSessionAdapter struct {
-	collection MongoCollection // The used MongoDB collection.
-	defaultTTL time.Duration   // The defaultTTL of the Set operations.
+	collection MongoCollection     // The used MongoDB collection.
+	defaultTTL time.Duration       // The defaultTTL of the Set operations.
+	codec      cacheadapters.Codec // The codec of the values in cache.
 }
 
 type cacheItem struct {
-	Key       string    `bson:"key"`        // The string key that identifies the item in cache
-	Item      bson.Raw  `bson:"item"`       // The actual item in cache.
-	ExpiresAt time.Time `bson:"expires_at"` // The expiration time of the item in cache.
+	Key       string             `bson:"key"`        // The string key that identifies the item in cache
+	Item      bson.RawValue      `bson:"item"`       // The actual item in cache, encoded by the codec.
+	ExpiresAt time.Time          `bson:"expires_at"` // The expiration time of the item in cache.
+	Counter   *int64             `bson:"counter"`    // The value of the item in cache, if it is a counter.
+	Version   primitive.ObjectID `bson:"version"`    // The version of the item in cache, changed on every write.
 }
 
-// NesSession create a new MongoDB Session adapter
-func NewSession(collection MongoCollection, defaultTTL time.Duration) (cacheadapters.CacheSessionAdapter, error) {
+// hasItem checks if the cache item contains a
+// value set by the Set operations.
+func (ci cacheItem) hasItem() bool {
+	return ci.Item.Type != 0
+}
+
+// unmarshal unmarshals the value of the cache item into
+// the object reference passed as parameter, using the codec.
+func (ci cacheItem) unmarshal(codec cacheadapters.Codec, objectRef interface{}) error {
+	if !ci.hasItem() && ci.Counter != nil {
+		return json.Unmarshal([]byte(strconv.FormatInt(*ci.Counter, 10)), objectRef)
+	}
+
+	if _, content, ok := ci.Item.BinaryOK(); ok {
+		return cacheadapters.Unmarshal(codec, content, objectRef)
+	}
+
+	return cacheadapters.Unmarshal(codec, ci.Item.Value, objectRef)
+}
+
+// NesSession create a new MongoDB Session adapter,
+// configured with the given options.
+func NewSession(collection MongoCollection, defaultTTL time.Duration, opts ...Option) (cacheadapters.CacheSessionAdapter, error) {
 	if collection == nil {
 		return nil, ErrNilCollection
 	}
@@ -44,9 +74,15 @@ func NewSession(collection MongoCollection, defaultTTL time.Duration) (cacheadap
 		return nil, cacheadapters.ErrInvalidTTL
 	}
 
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &MongoDBSessionAdapter{
 		collection: collection,
 		defaultTTL: defaultTTL,
+		codec:      config.codec,
 	}, nil
 }
 
@@ -55,40 +91,94 @@ func (msa *MongoDBSessionAdapter) Close() error {
 	return nil
 }
 
+// Get obtains a value from the cache using a key, then tries to unmarshal
+// it into the object reference passed as parameter.
 func (msa *MongoDBSessionAdapter) Get(key string, objectRef interface{}) error {
+	return msa.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	_, err := msa.GetWithVersionContext(ctx, key, objectRef)
+	return err
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (msa *MongoDBSessionAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return msa.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	if objectRef == nil {
-		return cacheadapters.ErrGetRequiresObjectReference
+		return "", cacheadapters.ErrGetRequiresObjectReference
 	}
 
-	result := msa.collection.FindOne(context.Background(), bson.M{"key": key})
+	result := msa.collection.FindOne(ctx, bson.M{"key": key})
 	if result == nil || result.Err() != nil {
-		return cacheadapters.ErrNotFound
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		return "", cacheadapters.ErrNotFound
 	}
 
 	var valueFromDB cacheItem
 
 	err := result.Decode(&valueFromDB)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	now := time.Now()
 	if valueFromDB.ExpiresAt.UnixNano() < now.UnixNano() {
-		msa.Delete(key)
-		return cacheadapters.ErrNotFound
+		msa.DeleteContext(ctx, key)
+		return "", cacheadapters.ErrNotFound
 	}
 
-	err = bson.Unmarshal(valueFromDB.Item, objectRef)
+	err = valueFromDB.unmarshal(msa.codec, objectRef)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return formatVersion(valueFromDB.Version), nil
 }
 
 // Set sets a value represented by the object parameter into the cache,
 // with the specified key.
 func (msa *MongoDBSessionAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
+	return msa.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	return msa.SetWithTagsContext(ctx, key, object, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+//
+// The tags are stored in the tags array field of the document,
+// indexed by EnsureIndexes to speed up InvalidateTag operations.
+func (msa *MongoDBSessionAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return msa.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if TTL == nil {
 		TTL = &msa.defaultTTL
 	}
@@ -97,7 +187,7 @@ func (msa *MongoDBSessionAdapter) Set(key string, object interface{}, TTL *time.
 		return cacheadapters.ErrInvalidTTL
 	}
 
-	marshalledObj, err := bson.Marshal(&object)
+	item, err := msa.marshal(object)
 	if err != nil {
 		return err
 	}
@@ -107,15 +197,13 @@ func (msa *MongoDBSessionAdapter) Set(key string, object interface{}, TTL *time.
 
 	optionsUpdate := options.Update().SetUpsert(true)
 	filter := bson.M{"key": key}
-	update := bson.M{
-		"$set": bson.M{
-			"key":        key,
-			"item":       bson.Raw(marshalledObj),
-			"expires_at": expiresAt,
-		},
+	update := newSetUpdate(key, item, expiresAt)
+	if len(tags) > 0 {
+		update["$set"].(bson.M)["tags"] = tags
+		delete(update["$unset"].(bson.M), "tags")
 	}
 
-	_, err = msa.collection.UpdateOne(context.Background(), filter, update, optionsUpdate)
+	_, err = msa.collection.UpdateOne(ctx, filter, update, optionsUpdate)
 	if err != nil {
 		return err
 	}
@@ -126,13 +214,27 @@ func (msa *MongoDBSessionAdapter) Set(key string, object interface{}, TTL *time.
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (msa *MongoDBSessionAdapter) SetTTL(key string, newTTL time.Duration) error {
+	return msa.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if newTTL <= cacheadapters.TTLExpired {
-		msa.Delete(key)
+		msa.DeleteContext(ctx, key)
 		return nil
 	}
 
-	mongoResult := msa.collection.FindOne(context.Background(), bson.M{"key": key})
+	mongoResult := msa.collection.FindOne(ctx, bson.M{"key": key})
 	if mongoResult == nil || mongoResult.Err() != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		return cacheadapters.ErrNotFound
 	}
 
@@ -144,7 +246,7 @@ func (msa *MongoDBSessionAdapter) SetTTL(key string, newTTL time.Duration) error
 
 	now := time.Now()
 	if result.ExpiresAt.UnixNano() < now.UnixNano() {
-		msa.Delete(key)
+		msa.DeleteContext(ctx, key)
 		return nil
 	}
 
@@ -155,7 +257,7 @@ func (msa *MongoDBSessionAdapter) SetTTL(key string, newTTL time.Duration) error
 			"expires_at": result.ExpiresAt,
 		},
 	}
-	_, err = msa.collection.UpdateOne(context.Background(), filter, update)
+	_, err = msa.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
@@ -165,10 +267,635 @@ func (msa *MongoDBSessionAdapter) SetTTL(key string, newTTL time.Duration) error
 
 // Delete deletes a key from the cache.
 func (msa *MongoDBSessionAdapter) Delete(key string) error {
-	_, err := msa.collection.DeleteOne(context.Background(), bson.M{"key": key})
+	return msa.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) DeleteContext(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := msa.collection.DeleteOne(ctx, bson.M{"key": key})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (msa *MongoDBSessionAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return msa.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the values are obtained with a single $in query.
+func (msa *MongoDBSessionAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(cacheadapters.BatchResult, len(objectRefs))
+	if len(objectRefs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, 0, len(objectRefs))
+	for key := range objectRefs {
+		keys = append(keys, key)
+		result[key] = cacheadapters.ErrNotFound
+	}
+
+	cursor, err := msa.collection.Find(ctx, bson.M{"key": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	now := time.Now()
+	for cursor.Next(ctx) {
+		key, ok := cursor.Current.Lookup("key").StringValueOK()
+		if !ok {
+			continue
+		}
+
+		var valueFromDB cacheItem
+
+		err := cursor.Decode(&valueFromDB)
+		if err != nil {
+			result[key] = err
+			continue
+		}
+
+		if valueFromDB.ExpiresAt.UnixNano() < now.UnixNano() {
+			continue
+		}
+
+		objectRef := objectRefs[key]
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		result[key] = valueFromDB.unmarshal(msa.codec, objectRef)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (msa *MongoDBSessionAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return msa.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the values are upserted with a single unordered BulkWrite.
+func (msa *MongoDBSessionAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if TTL == nil {
+		TTL = &msa.defaultTTL
+	}
+
+	if *TTL <= 0 {
+		return nil, cacheadapters.ErrInvalidTTL
+	}
+
+	result := make(cacheadapters.BatchResult, len(objects))
+	expiresAt := time.Now().Add(*TTL)
+
+	keys := make([]string, 0, len(objects))
+	models := make([]mongo.WriteModel, 0, len(objects))
+	for key, object := range objects {
+		item, err := msa.marshal(object)
+		result[key] = err
+		if err != nil {
+			continue
+		}
+
+		model := mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"key": key}).
+			SetUpdate(newSetUpdate(key, item, expiresAt)).
+			SetUpsert(true)
+
+		keys = append(keys, key)
+		models = append(models, model)
+	}
+
+	if len(models) == 0 {
+		return result, nil
+	}
+
+	_, err := msa.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if bulkErr, ok := err.(mongo.BulkWriteException); ok && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			result[keys[writeErr.Index]] = writeErr
+		}
+
+		return result, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (msa *MongoDBSessionAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return msa.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the keys are deleted with a single $in query.
+func (msa *MongoDBSessionAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(cacheadapters.BatchResult, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	for _, key := range keys {
+		result[key] = nil
+	}
+
+	_, err := msa.collection.DeleteMany(ctx, bson.M{"key": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (msa *MongoDBSessionAdapter) Exists(key string) (bool, error) {
+	return msa.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	_, err := msa.TTLContext(ctx, key)
+	if err == cacheadapters.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (msa *MongoDBSessionAdapter) TTL(key string) (time.Duration, error) {
+	return msa.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	findOptions := options.FindOne().SetProjection(bson.M{"expires_at": 1})
+
+	result := msa.collection.FindOne(ctx, bson.M{"key": key}, findOptions)
+	if result == nil {
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, cacheadapters.ErrNotFound
+		}
+
+		return 0, err
+	}
+
+	var valueFromDB cacheItem
+
+	err := result.Decode(&valueFromDB)
+	if err != nil {
+		return 0, err
+	}
+
+	remainingTTL := time.Until(valueFromDB.ExpiresAt)
+	if remainingTTL <= 0 {
+		msa.DeleteContext(ctx, key)
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	return remainingTTL, nil
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// If the key is not present or expired, the counter starts from
+// zero and the given TTL (or the default one if nil) is applied,
+// otherwise the TTL of the counter is left untouched.
+//
+// Only the counters created by Increment and Decrement can be
+// incremented: a value written by the Set operations, even if it is
+// an integer, is left untouched and cacheadapters.ErrInvalidCounter
+// is returned.
+func (msa *MongoDBSessionAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return msa.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if TTL == nil {
+		TTL = &msa.defaultTTL
+	} else if *TTL <= 0 {
+		return 0, cacheadapters.ErrInvalidTTL
+	}
+
+	now := time.Now()
+
+	// expired counters are removed first, so that the
+	// upsert below starts a new counter from zero.
+	_, err := msa.collection.DeleteOne(ctx, bson.M{"key": key, "expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return 0, err
+	}
+
+	// the update is a pipeline, so that a document containing a value
+	// set by the Set operations is left untouched, instead of gaining
+	// a counter and a new version: the check and the write are atomic.
+	isCounter := bson.M{"$eq": bson.A{bson.M{"$type": "$item"}, "missing"}}
+
+	filter := bson.M{"key": key}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"counter": bson.M{"$cond": bson.A{
+				isCounter,
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$counter", int64(0)}}, delta}},
+				"$counter",
+			}},
+			"version": bson.M{"$cond": bson.A{
+				isCounter,
+				primitive.NewObjectID(),
+				"$version",
+			}},
+			"expires_at": bson.M{"$ifNull": bson.A{"$expires_at", now.Add(*TTL)}},
+		}}},
+	}
+	optionsUpdate := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	mongoResult := msa.collection.FindOneAndUpdate(ctx, filter, update, optionsUpdate)
+	if mongoResult == nil {
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	if err := mongoResult.Err(); err != nil {
+		return 0, err
+	}
+
+	var valueFromDB cacheItem
+
+	err = mongoResult.Decode(&valueFromDB)
+	if err != nil {
+		return 0, err
+	}
+
+	if valueFromDB.hasItem() || valueFromDB.Counter == nil {
+		return 0, cacheadapters.ErrInvalidCounter
+	}
+
+	return *valueFromDB.Counter, nil
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (msa *MongoDBSessionAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return msa.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return msa.IncrementContext(ctx, key, -delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (msa *MongoDBSessionAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return msa.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+//
+// Only one of many concurrent calls for the same key writes the
+// value if the unique index created by EnsureIndexes exists.
+func (msa *MongoDBSessionAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if TTL == nil {
+		TTL = &msa.defaultTTL
+	} else if *TTL <= 0 {
+		return false, cacheadapters.ErrInvalidTTL
+	}
+
+	item, err := msa.marshal(object)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+
+	// expired items are removed first, so that
+	// the upsert below treats them as absent.
+	_, err = msa.collection.DeleteOne(ctx, bson.M{"key": key, "expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return false, err
+	}
+
+	optionsUpdate := options.Update().SetUpsert(true)
+	filter := bson.M{"key": key}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"item":       item,
+			"expires_at": now.Add(*TTL),
+			"version":    primitive.NewObjectID(),
+		},
+	}
+
+	mongoResult, err := msa.collection.UpdateOne(ctx, filter, update, optionsUpdate)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent upsert inserted the key first.
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return mongoResult.UpsertedCount > 0, nil
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (msa *MongoDBSessionAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return msa.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if TTL == nil {
+		TTL = &msa.defaultTTL
+	} else if *TTL <= 0 {
+		return false, cacheadapters.ErrInvalidTTL
+	}
+
+	item, err := msa.marshal(object)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	filter := bson.M{"key": key, "expires_at": bson.M{"$gt": now}}
+	update := newSetUpdate(key, item, now.Add(*TTL))
+
+	mongoResult, err := msa.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return mongoResult.MatchedCount > 0, nil
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (msa *MongoDBSessionAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return msa.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if TTL == nil {
+		TTL = &msa.defaultTTL
+	} else if *TTL <= 0 {
+		return cacheadapters.ErrInvalidTTL
+	}
+
+	item, err := msa.marshal(newObject)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	filter := bson.M{"key": key, "expires_at": bson.M{"$gt": now}}
+
+	// items written before versions were introduced
+	// have no version field and an empty version token.
+	if version == "" {
+		filter["version"] = bson.M{"$exists": false}
+	} else {
+		objectID, err := primitive.ObjectIDFromHex(string(version))
+		if err != nil {
+			return cacheadapters.ErrVersionConflict
+		}
+
+		filter["version"] = objectID
+	}
+
+	update := newSetUpdate(key, item, now.Add(*TTL))
+
+	mongoResult, err := msa.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
+	if mongoResult.MatchedCount == 0 {
+		return cacheadapters.ErrVersionConflict
+	}
+
 	return nil
 }
+
+// InvalidateTag deletes all the entries carrying the given tag.
+func (msa *MongoDBSessionAdapter) InvalidateTag(tag string) error {
+	return msa.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := msa.collection.DeleteMany(ctx, bson.M{"tags": tag})
+	return err
+}
+
+// Scan returns an iterator over the keys of the cache starting
+// with the given prefix, or over all the keys if it is empty.
+//
+// The prefix is matched with an anchored regular expression on
+// the key field, which can use the index on the keys.
+func (msa *MongoDBSessionAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return msa.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := prefixFilter(prefix)
+	filter["expires_at"] = bson.M{"$gt": time.Now()}
+
+	findOptions := options.Find().SetProjection(bson.M{"_id": 0, "key": 1})
+
+	cursor, err := msa.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoDBKeyIterator{
+		ctx:    ctx,
+		cursor: cursor,
+	}, nil
+}
+
+// Clear deletes all the documents of the cache collection.
+func (msa *MongoDBSessionAdapter) Clear() error {
+	return msa.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) ClearContext(ctx context.Context) error {
+	return msa.ClearPrefixContext(ctx, "")
+}
+
+// ClearPrefix deletes all the documents whose key starts with the given
+// prefix, or all the documents of the cache collection if it is empty.
+func (msa *MongoDBSessionAdapter) ClearPrefix(prefix string) error {
+	return msa.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (msa *MongoDBSessionAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := msa.collection.DeleteMany(ctx, prefixFilter(prefix))
+	return err
+}
+
+// marshal encodes the object using the codec, returning the
+// item to store: an embedded document for the BSON codec,
+// binary data, holding the exact encoded bytes, for the other codecs.
+func (msa *MongoDBSessionAdapter) marshal(object interface{}) (interface{}, error) {
+	content, err := cacheadapters.Marshal(msa.codec, object)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isBSON := msa.codec.(BSONCodec); isBSON {
+		// a RawMessage is not encoded by the codec,
+		// so it must be checked to be a valid document.
+		err = bson.Raw(content).Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		return bson.Raw(content), nil
+	}
+
+	return primitive.Binary{Subtype: bsontype.BinaryGeneric, Data: content}, nil
+}
+
+// newSetUpdate creates the update document used to upsert
+// an item in the cache collection.
+func newSetUpdate(key string, item interface{}, expiresAt time.Time) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"key":        key,
+			"item":       item,
+			"expires_at": expiresAt,
+			"version":    primitive.NewObjectID(),
+		},
+		"$unset": bson.M{
+			"counter": "",
+			"tags":    "",
+		},
+	}
+}
+
+// prefixFilter creates the filter matching the items
+// whose key starts with the prefix.
+func prefixFilter(prefix string) bson.M {
+	if prefix == "" {
+		return bson.M{}
+	}
+
+	return bson.M{"key": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}}
+}
+
+// formatVersion converts the version of an item
+// in cache into its opaque representation.
+func formatVersion(version primitive.ObjectID) cacheadapters.Version {
+	if version.IsZero() {
+		return ""
+	}
+
+	return cacheadapters.Version(version.Hex())
+}
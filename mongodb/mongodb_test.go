@@ -35,6 +35,7 @@ const (
 var (
 	testCacheIndexKeyName string = "test_cache_index_key"
 	testCacheIndexTTLName string = "test_cache_index_ttl"
+	testCacheIndexTagName string = "test_cache_index_tags"
 	localMongoDBServer    *memongo.Server
 	testKeyIndexModel     = mongo.IndexModel{
 		Keys: map[string]string{
@@ -53,6 +54,14 @@ var (
 			ExpireAfterSeconds: new(int32), // default value 0, because expires after 0 seconds after the expiration time.
 		},
 	}
+	testTagsIndexModel = mongo.IndexModel{
+		Keys: map[string]byte{
+			"tags": 1,
+		},
+		Options: &options.IndexOptions{
+			Name: &testCacheIndexTagName,
+		},
+	}
 	testMongoOptions *memongo.Options = &memongo.Options{
 		MongoVersion:   mongoDBVersion,
 		StartupTimeout: 10 * time.Second,
@@ -92,6 +101,11 @@ func createTestCollectionAndIndex() {
 	if err != nil {
 		panic(err)
 	}
+
+	_, err = indexes.CreateOne(ctx.Background(), testTagsIndexModel)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func stopLocalMongoDBServer() {
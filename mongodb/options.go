@@ -0,0 +1,58 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcacheadapters
+
+import (
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// Option configures a MongoDBAdapter or a MongoDBSessionAdapter when passed
+// to New or NewSession.
+type Option func(*settings) error
+
+// settings contains the configuration of the MongoDB adapters.
+type settings struct {
+	codec cacheadapters.Codec // The codec of the values in cache.
+}
+
+// newSettings creates the configuration of the MongoDB adapters,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{
+		codec: BSONCodec{},
+	}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithCodec sets the codec used to encode the values in cache,
+// BSONCodec is used if not set.
+func WithCodec(codec cacheadapters.Codec) Option {
+	return func(s *settings) error {
+		if codec == nil {
+			return cacheadapters.ErrNilCodec
+		}
+
+		s.codec = codec
+		return nil
+	}
+}
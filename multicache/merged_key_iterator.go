@@ -0,0 +1,99 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicacheadapters
+
+import (
+	"github.com/hashicorp/go-multierror"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// mergedKeyIterator is the KeyIterator which returns the keys of
+// the sub-adapters one after the other, without duplicates.
+type mergedKeyIterator struct {
+	iterators  []cacheadapters.KeyIterator // The iterators of the sub-adapters.
+	current    int                         // The index of the iterator in use.
+	seen       map[string]struct{}         // The keys already returned.
+	key        string                      // The current key.
+	errs       []error                     // The errors of the sub-adapters.
+	errorOrNil func([]error) error         // Parses the errors of the sub-adapters.
+}
+
+// newMergedKeyIterator creates a new iterator over the keys of
+// the sub-adapters, keeping the errors occurred while opening them.
+func newMergedKeyIterator(iterators []cacheadapters.KeyIterator, errs []error, errorOrNil func([]error) error) *mergedKeyIterator {
+	return &mergedKeyIterator{
+		iterators:  iterators,
+		seen:       make(map[string]struct{}),
+		errs:       errs,
+		errorOrNil: errorOrNil,
+	}
+}
+
+// Next advances the iterator to the next key not returned
+// yet, moving to the next sub-adapter when one is exhausted.
+func (mki *mergedKeyIterator) Next() bool {
+	for mki.current < len(mki.iterators) {
+		iterator := mki.iterators[mki.current]
+		for iterator.Next() {
+			key := iterator.Key()
+			if _, seen := mki.seen[key]; seen {
+				continue
+			}
+
+			mki.seen[key] = struct{}{}
+			mki.key = key
+			return true
+		}
+
+		if err := iterator.Err(); err != nil {
+			mki.errs = append(mki.errs, err)
+		}
+
+		mki.current++
+	}
+
+	mki.key = ""
+	return false
+}
+
+// Key returns the current key of the iterator.
+func (mki *mergedKeyIterator) Key() string {
+	return mki.key
+}
+
+// Err returns the errors of the sub-adapters, as an error if all
+// of them failed or as a warning if the warnings are enabled.
+func (mki *mergedKeyIterator) Err() error {
+	if len(mki.errs) == 0 {
+		return nil
+	}
+
+	return mki.errorOrNil(mki.errs)
+}
+
+// Close closes the iterators of all the sub-adapters.
+func (mki *mergedKeyIterator) Close() error {
+	var err error
+	for _, iterator := range mki.iterators {
+		if closeErr := iterator.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+	}
+
+	mki.current = len(mki.iterators)
+	mki.seen = nil
+
+	return err
+}
@@ -15,6 +15,7 @@
 package multicacheadapters_test
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -60,6 +61,200 @@ func (mca *mockMultiCacheAdapter) OpenSession() (cacheadapters.CacheSessionAdapt
 	return args.Get(0).(*mockMultiCacheSessionAdapter), args.Error(1)
 }
 
+// The context-aware variants of the mock delegate to the plain methods,
+// so expectations can be set once with the plain method name.
+
+func (mca *mockMultiCacheAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	return mca.Get(key, objectRef)
+}
+
+func (mca *mockMultiCacheAdapter) SetContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) error {
+	return mca.Set(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	return mca.SetTTL(key, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) DeleteContext(ctx context.Context, key string) error {
+	return mca.Delete(key)
+}
+
+func (mca *mockMultiCacheAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	args := mca.Called(objectRefs)
+
+	for _, objectRef := range objectRefs {
+		json.Unmarshal([]byte(testutil.TestValueJSON), objectRef)
+	}
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) SetMany(objects map[string]interface{}, newTTL *time.Duration) (cacheadapters.BatchResult, error) {
+	args := mca.Called(objects, newTTL)
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	args := mca.Called(keys)
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return mca.GetMany(objectRefs)
+}
+
+func (mca *mockMultiCacheAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, newTTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return mca.SetMany(objects, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	return mca.DeleteMany(keys)
+}
+
+func (mca *mockMultiCacheAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	return mca.OpenSession()
+}
+
+func (mca *mockMultiCacheAdapter) Exists(key string) (bool, error) {
+	args := mca.Called(key)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) TTL(key string) (time.Duration, error) {
+	args := mca.Called(key)
+
+	remainingTTL, _ := args.Get(0).(time.Duration)
+	return remainingTTL, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	return mca.Exists(key)
+}
+
+func (mca *mockMultiCacheAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	return mca.TTL(key)
+}
+
+func (mca *mockMultiCacheAdapter) Increment(key string, delta int64, newTTL *time.Duration) (int64, error) {
+	args := mca.Called(key, delta, newTTL)
+
+	counter, _ := args.Get(0).(int64)
+	return counter, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) Decrement(key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Increment(key, -delta, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) IncrementContext(ctx context.Context, key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Increment(key, delta, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) DecrementContext(ctx context.Context, key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Decrement(key, delta, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) SetIfAbsent(key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	args := mca.Called(key, object, newTTL)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) SetIfPresent(key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	args := mca.Called(key, object, newTTL)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	return mca.SetIfAbsent(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	return mca.SetIfPresent(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	args := mca.Called(key, objectRef)
+
+	json.Unmarshal([]byte(testutil.TestValueJSON), &objectRef)
+
+	version, _ := args.Get(0).(cacheadapters.Version)
+	return version, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, newTTL *time.Duration) error {
+	args := mca.Called(key, version, newObject, newTTL)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return mca.GetWithVersion(key, objectRef)
+}
+
+func (mca *mockMultiCacheAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, newTTL *time.Duration) error {
+	return mca.CompareAndSwap(key, version, newObject, newTTL)
+}
+
+func (mca *mockMultiCacheAdapter) SetWithTags(key string, object interface{}, newTTL *time.Duration, tags ...string) error {
+	args := mca.Called(key, object, newTTL, tags)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheAdapter) InvalidateTag(tag string) error {
+	args := mca.Called(tag)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration, tags ...string) error {
+	return mca.SetWithTags(key, object, newTTL, tags...)
+}
+
+func (mca *mockMultiCacheAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	return mca.InvalidateTag(tag)
+}
+
+func (mca *mockMultiCacheAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	args := mca.Called(prefix)
+
+	iterator, _ := args.Get(0).(cacheadapters.KeyIterator)
+	return iterator, args.Error(1)
+}
+
+func (mca *mockMultiCacheAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	return mca.Scan(prefix)
+}
+
+func (mca *mockMultiCacheAdapter) Clear() error {
+	args := mca.Called()
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheAdapter) ClearPrefix(prefix string) error {
+	args := mca.Called(prefix)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheAdapter) ClearContext(ctx context.Context) error {
+	return mca.Clear()
+}
+
+func (mca *mockMultiCacheAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	return mca.ClearPrefix(prefix)
+}
+
 func newmockMultiCacheAdapter() *mockMultiCacheAdapter {
 	return &mockMultiCacheAdapter{initialized: true}
 }
@@ -101,6 +296,242 @@ func (mca *mockMultiCacheSessionAdapter) Close() error {
 	return args.Error(0)
 }
 
+func (mca *mockMultiCacheSessionAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	return mca.Get(key, objectRef)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) error {
+	return mca.Set(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	return mca.SetTTL(key, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) DeleteContext(ctx context.Context, key string) error {
+	return mca.Delete(key)
+}
+
+func (mca *mockMultiCacheSessionAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	args := mca.Called(objectRefs)
+
+	for _, objectRef := range objectRefs {
+		json.Unmarshal([]byte(testutil.TestValueJSON), objectRef)
+	}
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetMany(objects map[string]interface{}, newTTL *time.Duration) (cacheadapters.BatchResult, error) {
+	args := mca.Called(objects, newTTL)
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	args := mca.Called(keys)
+
+	result, _ := args.Get(0).(cacheadapters.BatchResult)
+	return result, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return mca.GetMany(objectRefs)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, newTTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return mca.SetMany(objects, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	return mca.DeleteMany(keys)
+}
+
+func (mca *mockMultiCacheSessionAdapter) Exists(key string) (bool, error) {
+	args := mca.Called(key)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) TTL(key string) (time.Duration, error) {
+	args := mca.Called(key)
+
+	remainingTTL, _ := args.Get(0).(time.Duration)
+	return remainingTTL, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	return mca.Exists(key)
+}
+
+func (mca *mockMultiCacheSessionAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	return mca.TTL(key)
+}
+
+func (mca *mockMultiCacheSessionAdapter) Increment(key string, delta int64, newTTL *time.Duration) (int64, error) {
+	args := mca.Called(key, delta, newTTL)
+
+	counter, _ := args.Get(0).(int64)
+	return counter, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) Decrement(key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Increment(key, -delta, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) IncrementContext(ctx context.Context, key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Increment(key, delta, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) DecrementContext(ctx context.Context, key string, delta int64, newTTL *time.Duration) (int64, error) {
+	return mca.Decrement(key, delta, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetIfAbsent(key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	args := mca.Called(key, object, newTTL)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetIfPresent(key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	args := mca.Called(key, object, newTTL)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	return mca.SetIfAbsent(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration) (bool, error) {
+	return mca.SetIfPresent(key, object, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	args := mca.Called(key, objectRef)
+
+	json.Unmarshal([]byte(testutil.TestValueJSON), &objectRef)
+
+	version, _ := args.Get(0).(cacheadapters.Version)
+	return version, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, newTTL *time.Duration) error {
+	args := mca.Called(key, version, newObject, newTTL)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheSessionAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return mca.GetWithVersion(key, objectRef)
+}
+
+func (mca *mockMultiCacheSessionAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, newTTL *time.Duration) error {
+	return mca.CompareAndSwap(key, version, newObject, newTTL)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetWithTags(key string, object interface{}, newTTL *time.Duration, tags ...string) error {
+	args := mca.Called(key, object, newTTL, tags)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheSessionAdapter) InvalidateTag(tag string) error {
+	args := mca.Called(tag)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheSessionAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, newTTL *time.Duration, tags ...string) error {
+	return mca.SetWithTags(key, object, newTTL, tags...)
+}
+
+func (mca *mockMultiCacheSessionAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	return mca.InvalidateTag(tag)
+}
+
+func (mca *mockMultiCacheSessionAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	args := mca.Called(prefix)
+
+	iterator, _ := args.Get(0).(cacheadapters.KeyIterator)
+	return iterator, args.Error(1)
+}
+
+func (mca *mockMultiCacheSessionAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	return mca.Scan(prefix)
+}
+
+func (mca *mockMultiCacheSessionAdapter) Clear() error {
+	args := mca.Called()
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheSessionAdapter) ClearPrefix(prefix string) error {
+	args := mca.Called(prefix)
+
+	return args.Error(0)
+}
+
+func (mca *mockMultiCacheSessionAdapter) ClearContext(ctx context.Context) error {
+	return mca.Clear()
+}
+
+func (mca *mockMultiCacheSessionAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	return mca.ClearPrefix(prefix)
+}
+
 func newmockMultiCacheSessionAdapter() *mockMultiCacheSessionAdapter {
 	return &mockMultiCacheSessionAdapter{initialized: true}
 }
+
+// mockKeyIterator is a KeyIterator returning the
+// given keys, then the given error.
+type mockKeyIterator struct {
+	keys   []string
+	err    error
+	index  int
+	closed bool
+}
+
+func (mki *mockKeyIterator) Next() bool {
+	if mki.index >= len(mki.keys) {
+		return false
+	}
+
+	mki.index++
+	return true
+}
+
+func (mki *mockKeyIterator) Key() string {
+	return mki.keys[mki.index-1]
+}
+
+func (mki *mockKeyIterator) Err() error {
+	if mki.index < len(mki.keys) {
+		return nil
+	}
+
+	return mki.err
+}
+
+func (mki *mockKeyIterator) Close() error {
+	mki.closed = true
+	return nil
+}
+
+func newMockKeyIterator(err error, keys ...string) *mockKeyIterator {
+	return &mockKeyIterator{keys: keys, err: err}
+}
+
+// collectKeys returns all the keys of the iterator.
+func collectKeys(iterator cacheadapters.KeyIterator) []string {
+	keys := make([]string, 0)
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys
+}
@@ -15,7 +15,7 @@
 package multicacheadapters
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"reflect"
 	"sync"
@@ -31,6 +31,7 @@ import (
 type MultiCacheAdapter struct {
 	subAdapters  []cacheadapters.CacheAdapter // The array of sub-adapters
 	showWarnings bool
+	codec        cacheadapters.Codec // The codec used to decode and propagate the values.
 	wg           sync.WaitGroup
 }
 
@@ -52,7 +53,7 @@ func New(adapters ...cacheadapters.CacheAdapter) (*MultiCacheAdapter, error) {
 		return nil, ErrInvalidSubAdapters
 	}
 
-	return &MultiCacheAdapter{finalAdapters, false, sync.WaitGroup{}}, nil
+	return &MultiCacheAdapter{finalAdapters, false, cacheadapters.JSONCodec{}, sync.WaitGroup{}}, nil
 }
 
 // EnableWarning enable the return of warning errors.
@@ -101,20 +102,41 @@ func (mca *MultiCacheAdapter) DisableWarnings() {
 	mca.showWarnings = false
 }
 
+// UseCodec sets the codec used to decode the values read from the
+// sub-adapters and to encode the values propagated between them.
+//
+// Values are moved between the sub-adapters as raw encoded bytes,
+// so every sub-adapter must be configured with the same codec
+// (cacheadapters.JSONCodec by default).
+func (mca *MultiCacheAdapter) UseCodec(codec cacheadapters.Codec) error {
+	if codec == nil {
+		return cacheadapters.ErrNilCodec
+	}
+
+	mca.codec = codec
+	return nil
+}
+
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (mca *MultiCacheAdapter) Get(key string, objectRef interface{}) error {
+	return mca.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
 	errs := make([]error, 0, len(mca.subAdapters))
 	for _, adapter := range mca.subAdapters {
-		var temp json.RawMessage
+		var temp cacheadapters.RawMessage
 
-		err := adapter.Get(key, &temp)
+		err := adapter.GetContext(ctx, key, &temp)
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
 
-		err = json.Unmarshal(temp, &objectRef)
+		err = cacheadapters.Unmarshal(mca.codec, temp, objectRef)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -128,9 +150,15 @@ func (mca *MultiCacheAdapter) Get(key string, objectRef interface{}) error {
 
 // Set sets a value represented by the object parameter into the cache, with the specified key.
 func (mca *MultiCacheAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
+	return mca.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
 	errs := make([]error, 0, len(mca.subAdapters))
 	for _, adapter := range mca.subAdapters {
-		err := adapter.Set(key, object, TTL)
+		err := adapter.SetContext(ctx, key, object, TTL)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -142,9 +170,15 @@ func (mca *MultiCacheAdapter) Set(key string, object interface{}, TTL *time.Dura
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (mca *MultiCacheAdapter) SetTTL(key string, newTTL time.Duration) error {
+	return mca.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
 	errs := make([]error, 0, len(mca.subAdapters))
 	for _, adapter := range mca.subAdapters {
-		err := adapter.SetTTL(key, newTTL)
+		err := adapter.SetTTLContext(ctx, key, newTTL)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -155,9 +189,15 @@ func (mca *MultiCacheAdapter) SetTTL(key string, newTTL time.Duration) error {
 
 // Delete deletes a key from the cache.
 func (mca *MultiCacheAdapter) Delete(key string) error {
+	return mca.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) DeleteContext(ctx context.Context, key string) error {
 	errs := make([]error, 0, len(mca.subAdapters))
 	for _, adapter := range mca.subAdapters {
-		err := adapter.Delete(key)
+		err := adapter.DeleteContext(ctx, key)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -166,11 +206,479 @@ func (mca *MultiCacheAdapter) Delete(key string) error {
 	return mca.errorOrNil(errs)
 }
 
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (mca *MultiCacheAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return mca.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// The keys are requested to the sub-adapters following their priority:
+// each sub-adapter is asked only for the keys that have not been found
+// in the previous ones.
+func (mca *MultiCacheAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(objectRefs))
+	remaining := make(map[string]interface{}, len(objectRefs))
+	for key, objectRef := range objectRefs {
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		remaining[key] = objectRef
+	}
+
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		if len(remaining) == 0 {
+			break
+		}
+
+		temps := make(map[string]interface{}, len(remaining))
+		for key := range remaining {
+			temps[key] = new(cacheadapters.RawMessage)
+		}
+
+		subResult, err := adapter.GetManyContext(ctx, temps)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for key, objectRef := range remaining {
+			err, exists := subResult[key]
+			if !exists {
+				err = cacheadapters.ErrNotFound
+			}
+
+			if err == nil {
+				err = cacheadapters.Unmarshal(mca.codec, *temps[key].(*cacheadapters.RawMessage), objectRef)
+			}
+
+			result[key] = err
+			if err == nil {
+				delete(remaining, key)
+			}
+		}
+	}
+
+	if len(errs) == len(mca.subAdapters) {
+		return nil, mca.errorOrNil(errs)
+	}
+
+	return result, mca.errorOrNil(errs)
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (mca *MultiCacheAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return mca.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// A key is reported as successfully set if at least one of the
+// sub-adapters managed to set it.
+func (mca *MultiCacheAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(objects))
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		subResult, err := adapter.SetManyContext(ctx, objects, TTL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mergeBatchResult(result, subResult)
+	}
+
+	if len(errs) == len(mca.subAdapters) {
+		return nil, mca.errorOrNil(errs)
+	}
+
+	return result, mca.errorOrNil(errs)
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (mca *MultiCacheAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return mca.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// A key is reported as successfully deleted if at least one of the
+// sub-adapters managed to delete it.
+func (mca *MultiCacheAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(keys))
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		subResult, err := adapter.DeleteManyContext(ctx, keys)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mergeBatchResult(result, subResult)
+	}
+
+	if len(errs) == len(mca.subAdapters) {
+		return nil, mca.errorOrNil(errs)
+	}
+
+	return result, mca.errorOrNil(errs)
+}
+
+// Exists checks if a key is present in at least one of the sub-adapters,
+// following their priority.
+func (mca *MultiCacheAdapter) Exists(key string) (bool, error) {
+	return mca.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		exists, err := adapter.ExistsContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if exists {
+			return true, mca.errorOrNil(errs)
+		}
+	}
+
+	return false, mca.errorOrNil(errs)
+}
+
+// TTL obtains the remaining time to live of a key from the first
+// sub-adapter which contains it, following their priority.
+func (mca *MultiCacheAdapter) TTL(key string) (time.Duration, error) {
+	return mca.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		remainingTTL, err := adapter.TTLContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		return remainingTTL, mca.errorOrNil(errs)
+	}
+
+	return 0, mca.errorOrNil(errs)
+}
+
+// ExistsPerTier asks every sub-adapter whether a key is present,
+// returning the answers in the same order of the sub-adapters.
+func (mca *MultiCacheAdapter) ExistsPerTier(key string) []TierExists {
+	return mca.ExistsPerTierContext(context.Background(), key)
+}
+
+// ExistsPerTierContext is the same as ExistsPerTier, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) ExistsPerTierContext(ctx context.Context, key string) []TierExists {
+	answers := make([]TierExists, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		exists, err := adapter.ExistsContext(ctx, key)
+		answers = append(answers, TierExists{Exists: exists, Err: err})
+	}
+
+	return answers
+}
+
+// TTLPerTier asks every sub-adapter the remaining time to live of
+// a key, returning the answers in the same order of the sub-adapters.
+func (mca *MultiCacheAdapter) TTLPerTier(key string) []TierTTL {
+	return mca.TTLPerTierContext(context.Background(), key)
+}
+
+// TTLPerTierContext is the same as TTLPerTier, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) TTLPerTierContext(ctx context.Context, key string) []TierTTL {
+	answers := make([]TierTTL, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		remainingTTL, err := adapter.TTLContext(ctx, key)
+		answers = append(answers, TierTTL{TTL: remainingTTL, Err: err})
+	}
+
+	return answers
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// The last sub-adapter, which usually is the one shared among the
+// instances of the application, is authoritative for counters: the
+// operation is performed only there, and the key is deleted from the
+// other sub-adapters, so that reads fall through to the authoritative one.
+// Failures of the authoritative sub-adapter are returned as errors, while
+// failures while deleting the key from the others are warnings.
+func (mca *MultiCacheAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mca.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	authoritative := mca.subAdapters[len(mca.subAdapters)-1]
+
+	counter, err := authoritative.IncrementContext(ctx, key, delta, TTL)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters[:len(mca.subAdapters)-1] {
+		err := adapter.DeleteContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return counter, mca.warningOrNil(errs)
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (mca *MultiCacheAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mca.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mca.IncrementContext(ctx, key, -delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+//
+// As for counters, the last sub-adapter is authoritative: the condition
+// is checked only there and, if the value has been written, it is then
+// Set into the other sub-adapters. Failures of the authoritative
+// sub-adapter are returned as errors, while failures while setting the
+// value into the others are warnings.
+func (mca *MultiCacheAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return mca.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	authoritative := mca.subAdapters[len(mca.subAdapters)-1]
+
+	written, err := authoritative.SetIfAbsentContext(ctx, key, object, TTL)
+	if err != nil || !written {
+		return written, err
+	}
+
+	return true, mca.propagateSet(ctx, key, object, TTL)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+//
+// It follows the same rules of SetIfAbsent.
+func (mca *MultiCacheAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return mca.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	authoritative := mca.subAdapters[len(mca.subAdapters)-1]
+
+	written, err := authoritative.SetIfPresentContext(ctx, key, object, TTL)
+	if err != nil || !written {
+		return written, err
+	}
+
+	return true, mca.propagateSet(ctx, key, object, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+//
+// Since versions are specific to each sub-adapter, the value is
+// obtained from the last sub-adapter, which is authoritative for
+// CompareAndSwap operations.
+func (mca *MultiCacheAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return mca.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	authoritative := mca.subAdapters[len(mca.subAdapters)-1]
+
+	return authoritative.GetWithVersionContext(ctx, key, objectRef)
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+//
+// It follows the same rules of SetIfAbsent: the version is checked on
+// the last sub-adapter and, if the value has been swapped, it is then
+// Set into the other sub-adapters.
+func (mca *MultiCacheAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return mca.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	authoritative := mca.subAdapters[len(mca.subAdapters)-1]
+
+	err := authoritative.CompareAndSwapContext(ctx, key, version, newObject, TTL)
+	if err != nil {
+		return err
+	}
+
+	return mca.propagateSet(ctx, key, newObject, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+func (mca *MultiCacheAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return mca.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		err := adapter.SetWithTagsContext(ctx, key, object, TTL, tags...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mca.errorOrNil(errs)
+}
+
+// InvalidateTag deletes all the entries carrying the given tag
+// from every sub-adapter.
+func (mca *MultiCacheAdapter) InvalidateTag(tag string) error {
+	return mca.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		err := adapter.InvalidateTagContext(ctx, tag)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mca.errorOrNil(errs)
+}
+
+// Scan returns an iterator over the keys of every sub-adapter
+// starting with the given prefix, or over all the keys if it is
+// empty, without returning the same key twice.
+//
+// The keys are returned in sub-adapter order, and the keys
+// already returned are kept in memory to skip the duplicates.
+func (mca *MultiCacheAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return mca.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	iterators := make([]cacheadapters.KeyIterator, 0, len(mca.subAdapters))
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		iterator, err := adapter.ScanContext(ctx, prefix)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		iterators = append(iterators, iterator)
+	}
+
+	if len(iterators) == 0 {
+		return nil, mca.errorOrNil(errs)
+	}
+
+	iterator := newMergedKeyIterator(iterators, errs, mca.errorOrNil)
+	return iterator, iterator.Err()
+}
+
+// Clear deletes all the entries of every sub-adapter.
+func (mca *MultiCacheAdapter) Clear() error {
+	return mca.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (mca *MultiCacheAdapter) ClearContext(ctx context.Context) error {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		err := adapter.ClearContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mca.errorOrNil(errs)
+}
+
+// ClearPrefix deletes all the entries whose key starts with the
+// given prefix from every sub-adapter.
+func (mca *MultiCacheAdapter) ClearPrefix(prefix string) error {
+	return mca.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters {
+		err := adapter.ClearPrefixContext(ctx, prefix)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mca.errorOrNil(errs)
+}
+
+// OpenSession opens a new Cache Session on every sub-adapter.
 func (mca *MultiCacheAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
+	return mca.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext opens a new Cache Session on every sub-adapter,
+// honoring the cancellation and the deadline of the given context.
+func (mca *MultiCacheAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
 	adapters := make([]cacheadapters.CacheSessionAdapter, 0, len(mca.subAdapters))
 	errs := make([]error, 0, len(mca.subAdapters))
 	for _, adapter := range mca.subAdapters {
-		sessionAdapter, err := adapter.OpenSession()
+		sessionAdapter, err := adapter.OpenSessionContext(ctx)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -184,6 +692,8 @@ func (mca *MultiCacheAdapter) OpenSession() (cacheadapters.CacheSessionAdapter,
 		return nil, err
 	}
 
+	sessionAdapter.codec = mca.codec
+
 	err = mca.errorOrNil(errs)
 	if !errors.Is(err, ErrMultiCacheWarning) {
 		return sessionAdapter, err
@@ -206,3 +716,46 @@ func (mca *MultiCacheAdapter) errorOrNil(errs []error) error {
 
 	return nil
 }
+
+// mergeBatchResult merges the result of a batch operation performed
+// on a sub-adapter into the final result, keeping the successes
+// obtained from the previous sub-adapters.
+func mergeBatchResult(result cacheadapters.BatchResult, subResult cacheadapters.BatchResult) {
+	for key, err := range subResult {
+		if previousErr, exists := result[key]; !exists || previousErr != nil {
+			result[key] = err
+		}
+	}
+}
+
+// propagateSet sets the value written into the authoritative sub-adapter
+// into the other sub-adapters, returning their failures as warnings.
+//
+// The value is encoded only once and written as raw bytes.
+func (mca *MultiCacheAdapter) propagateSet(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	content, err := cacheadapters.Marshal(mca.codec, object)
+	if err != nil {
+		return mca.warningOrNil([]error{err})
+	}
+
+	errs := make([]error, 0, len(mca.subAdapters))
+	for _, adapter := range mca.subAdapters[:len(mca.subAdapters)-1] {
+		err := adapter.SetContext(ctx, key, cacheadapters.RawMessage(content), TTL)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mca.warningOrNil(errs)
+}
+
+// warningOrNil parses the errors accumulated while operating on
+// non-authoritative sub-adapters into one final warning, or returns
+// nil if there are none or the warnings are disabled.
+func (mca *MultiCacheAdapter) warningOrNil(errs []error) error {
+	if mca.showWarnings && len(errs) > 0 {
+		return multierror.Append(ErrMultiCacheWarning, errs...)
+	}
+
+	return nil
+}
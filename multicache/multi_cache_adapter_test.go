@@ -15,10 +15,10 @@
 package multicacheadapters_test
 
 import (
-	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
 	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
@@ -83,7 +83,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_OK() {
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
@@ -99,7 +99,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFail1() {
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
@@ -115,7 +115,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFail2() {
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
@@ -131,7 +131,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFail3() {
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
@@ -147,7 +147,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFailMulti
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
@@ -164,7 +164,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_TotalFailAndDisabledWarnings()
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
@@ -179,7 +179,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFailAndWa
 
 	var actual testutil.TestStruct
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(testutil.ErrTestingFailureCheck)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
@@ -193,7 +193,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_UsingPriorityOnPartialFailAndWa
 func (suite *MultiCacheAdapterTestSuite) TestGet_NilReference() {
 	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(cacheadapters.ErrGetRequiresObjectReference)
 	suite.secondDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(cacheadapters.ErrGetRequiresObjectReference)
 	suite.thirdDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(cacheadapters.ErrGetRequiresObjectReference)
@@ -207,7 +207,7 @@ func (suite *MultiCacheAdapterTestSuite) TestGet_NonUnmarshalableReference() {
 
 	actual := complex128(1)
 
-	var dummyRawMessage json.RawMessage
+	var dummyRawMessage cacheadapters.RawMessage
 
 	// forcing to return nil simulates a wrong unmarshal handling, corrected by the multi adapter.
 	suite.firstDummyAdapter.On("Get", testutil.TestKeyForGet, &dummyRawMessage).Once().Return(nil)
@@ -423,3 +423,474 @@ func (suite *MultiCacheAdapterTestSuite) TestOpenSession_PartialErrorNilSubadapt
 	_, err := adapter.OpenSession()
 	suite.ErrorIs(err, multicacheadapters.ErrInvalidSubAdapters, "Should error unitialized subSessionAdapters")
 }
+
+func (suite *MultiCacheAdapterTestSuite) TestGetMany_UsingPriority() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	firstKey := testutil.TestKeyForMany + ":1"
+	secondKey := testutil.TestKeyForMany + ":2"
+
+	hasKeys := func(keys ...string) interface{} {
+		return mock.MatchedBy(func(objectRefs map[string]interface{}) bool {
+			if len(objectRefs) != len(keys) {
+				return false
+			}
+
+			for _, key := range keys {
+				if _, exists := objectRefs[key]; !exists {
+					return false
+				}
+			}
+
+			return true
+		})
+	}
+
+	suite.firstDummyAdapter.On("GetMany", hasKeys(firstKey, secondKey)).Once().Return(cacheadapters.BatchResult{firstKey: nil, secondKey: cacheadapters.ErrNotFound}, nil)
+	suite.secondDummyAdapter.On("GetMany", hasKeys(secondKey)).Once().Return(cacheadapters.BatchResult{secondKey: nil}, nil)
+
+	var firstActual, secondActual testutil.TestStruct
+	result, err := adapter.GetMany(map[string]interface{}{
+		firstKey:  &firstActual,
+		secondKey: &secondActual,
+	})
+	suite.NoError(err, "Should not error on valid GetMany")
+	suite.NoError(result[firstKey], "Should find the first key in the first adapter")
+	suite.NoError(result[secondKey], "Should find the second key in the second adapter")
+	suite.Equal(testutil.TestValue.Value, firstActual.Value, "Should be equal to the provided test value")
+	suite.Equal(testutil.TestValue.Value, secondActual.Value, "Should be equal to the provided test value")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+	suite.thirdDummyAdapter.AssertNotCalled(suite.T(), "GetMany", mock.Anything)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestGetMany_MissingEverywhere() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	notFound := cacheadapters.BatchResult{testutil.TestKeyForMany: cacheadapters.ErrNotFound}
+	suite.firstDummyAdapter.On("GetMany", mock.Anything).Once().Return(notFound, nil)
+	suite.secondDummyAdapter.On("GetMany", mock.Anything).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("GetMany", mock.Anything).Once().Return(notFound, nil)
+
+	var actual testutil.TestStruct
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForMany: &actual})
+	suite.NoError(err, "Should not error on partially failing GetMany with disabled warnings")
+	suite.ErrorIs(result[testutil.TestKeyForMany], cacheadapters.ErrNotFound, "Should not find the key in any adapter")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestGetMany_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("GetMany", mock.Anything).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("GetMany", mock.Anything).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("GetMany", mock.Anything).Once().Return(nil, testutil.ErrTestingFailureCheck)
+
+	var actual testutil.TestStruct
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForMany: &actual})
+	suite.Error(err, "Should error on total failing GetMany")
+	suite.Nil(result, "Should not return a result on total failing GetMany")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestGetMany_NilReference() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForMany: nil})
+	suite.NoError(err, "Should not error on the whole GetMany")
+	suite.ErrorIs(result[testutil.TestKeyForMany], cacheadapters.ErrGetRequiresObjectReference, "Should error on nil object reference")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetMany_PartialErrorAndWarnings() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	objects := map[string]interface{}{testutil.TestKeyForMany: testutil.TestValue}
+
+	var nilDuration *time.Duration
+	suite.firstDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(cacheadapters.BatchResult{testutil.TestKeyForMany: testutil.ErrTestingFailureCheck}, nil)
+	suite.thirdDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(cacheadapters.BatchResult{testutil.TestKeyForMany: nil}, nil)
+
+	result, err := adapter.SetMany(objects, nil)
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should error with warning on partially failing SetMany")
+	suite.NoError(result[testutil.TestKeyForMany], "Should be set since one adapter succeeded")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetMany_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	objects := map[string]interface{}{testutil.TestKeyForMany: testutil.TestValue}
+
+	var nilDuration *time.Duration
+	suite.firstDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("SetMany", objects, nilDuration).Once().Return(nil, testutil.ErrTestingFailureCheck)
+
+	_, err := adapter.SetMany(objects, nil)
+	suite.Error(err, "Should error on total failing SetMany")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestDeleteMany_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	keys := []string{testutil.TestKeyForMany}
+	deleted := cacheadapters.BatchResult{testutil.TestKeyForMany: nil}
+
+	suite.firstDummyAdapter.On("DeleteMany", keys).Once().Return(deleted, nil)
+	suite.secondDummyAdapter.On("DeleteMany", keys).Once().Return(deleted, nil)
+	suite.thirdDummyAdapter.On("DeleteMany", keys).Once().Return(deleted, nil)
+
+	result, err := adapter.DeleteMany(keys)
+	suite.NoError(err, "Should not error on valid DeleteMany")
+	suite.NoError(result[testutil.TestKeyForMany], "Should be deleted")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestExists_UsingPriority() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, nil)
+	suite.secondDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(true, nil)
+
+	exists, err := adapter.Exists(testutil.TestKeyForExists)
+	suite.NoError(err, "Should not error on valid Exists")
+	suite.True(exists, "Should exist in the second sub-adapter")
+
+	suite.thirdDummyAdapter.AssertNotCalled(suite.T(), "Exists", testutil.TestKeyForExists)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestExists_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, testutil.ErrTestingFailureCheck)
+
+	_, err := adapter.Exists(testutil.TestKeyForExists)
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if every sub-adapter fails")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestTTL_UsingPriority() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), cacheadapters.ErrNotFound)
+	suite.secondDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(testutil.DummyTTL, nil)
+
+	remainingTTL, err := adapter.TTL(testutil.TestKeyForExists)
+	suite.NoError(err, "Should not error on valid TTL")
+	suite.Equal(testutil.DummyTTL, remainingTTL, "Should be the TTL of the second sub-adapter")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestTTL_MissingEverywhere() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), cacheadapters.ErrNotFound)
+	suite.secondDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), cacheadapters.ErrNotFound)
+	suite.thirdDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), cacheadapters.ErrNotFound)
+
+	_, err := adapter.TTL(testutil.TestKeyForExists)
+	suite.ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found in any sub-adapter")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestPerTier_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, nil)
+	suite.secondDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(true, nil)
+	suite.thirdDummyAdapter.On("Exists", testutil.TestKeyForExists).Once().Return(false, testutil.ErrTestingFailureCheck)
+
+	existsAnswers := adapter.ExistsPerTier(testutil.TestKeyForExists)
+	suite.Equal([]multicacheadapters.TierExists{
+		{Exists: false, Err: nil},
+		{Exists: true, Err: nil},
+		{Exists: false, Err: testutil.ErrTestingFailureCheck},
+	}, existsAnswers, "Should report the answer of every sub-adapter")
+
+	suite.firstDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), cacheadapters.ErrNotFound)
+	suite.secondDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(testutil.DummyTTL, nil)
+	suite.thirdDummyAdapter.On("TTL", testutil.TestKeyForExists).Once().Return(time.Duration(0), testutil.ErrTestingFailureCheck)
+
+	ttlAnswers := adapter.TTLPerTier(testutil.TestKeyForExists)
+	suite.Equal([]multicacheadapters.TierTTL{
+		{TTL: 0, Err: cacheadapters.ErrNotFound},
+		{TTL: testutil.DummyTTL, Err: nil},
+		{TTL: 0, Err: testutil.ErrTestingFailureCheck},
+	}, ttlAnswers, "Should report the answer of every sub-adapter")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestIncrement_UsingLastAsAuthoritative() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	suite.thirdDummyAdapter.On("Increment", testutil.TestKeyForCounter, int64(2), &testutil.DummyTTL).Once().Return(int64(3), nil)
+	suite.firstDummyAdapter.On("Delete", testutil.TestKeyForCounter).Once().Return(nil)
+	suite.secondDummyAdapter.On("Delete", testutil.TestKeyForCounter).Once().Return(testutil.ErrTestingFailureCheck)
+
+	counter, err := adapter.Increment(testutil.TestKeyForCounter, 2, &testutil.DummyTTL)
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn when the key cannot be deleted from a sub-adapter")
+	suite.Equal(int64(3), counter, "Should be the counter of the authoritative sub-adapter")
+
+	suite.firstDummyAdapter.AssertNotCalled(suite.T(), "Increment", testutil.TestKeyForCounter, int64(2), &testutil.DummyTTL)
+	suite.secondDummyAdapter.AssertNotCalled(suite.T(), "Increment", testutil.TestKeyForCounter, int64(2), &testutil.DummyTTL)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestDecrement_AuthoritativeFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.thirdDummyAdapter.On("Increment", testutil.TestKeyForCounter, int64(-2), &testutil.DummyTTL).Once().Return(int64(0), testutil.ErrTestingFailureCheck)
+
+	_, err := adapter.Decrement(testutil.TestKeyForCounter, 2, &testutil.DummyTTL)
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if the authoritative sub-adapter fails")
+
+	suite.firstDummyAdapter.AssertNotCalled(suite.T(), "Delete", testutil.TestKeyForCounter)
+	suite.secondDummyAdapter.AssertNotCalled(suite.T(), "Delete", testutil.TestKeyForCounter)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetIfAbsent_PropagatesWhenWritten() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.thirdDummyAdapter.On("SetIfAbsent", testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL).Once().Return(true, nil)
+	suite.firstDummyAdapter.On("Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(nil)
+	suite.secondDummyAdapter.On("Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(nil)
+
+	written, err := adapter.SetIfAbsent(testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL)
+	suite.NoError(err, "Should not error on valid SetIfAbsent")
+	suite.True(written, "Should be written by the authoritative sub-adapter")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetIfPresent_NotWritten() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.thirdDummyAdapter.On("SetIfPresent", testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL).Once().Return(false, nil)
+
+	written, err := adapter.SetIfPresent(testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL)
+	suite.NoError(err, "Should not error on valid SetIfPresent")
+	suite.False(written, "Should not be written by the authoritative sub-adapter")
+
+	suite.firstDummyAdapter.AssertNotCalled(suite.T(), "Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL)
+	suite.secondDummyAdapter.AssertNotCalled(suite.T(), "Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetIfAbsent_PropagationWarnings() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	suite.thirdDummyAdapter.On("SetIfAbsent", testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL).Once().Return(true, nil)
+	suite.firstDummyAdapter.On("Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Set", testutil.TestKeyForSetIf, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(nil)
+
+	written, err := adapter.SetIfAbsent(testutil.TestKeyForSetIf, testutil.TestValue, &testutil.DummyTTL)
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn when the value cannot be propagated")
+	suite.True(written, "Should be written by the authoritative sub-adapter")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestGetWithVersion_UsingLastAsAuthoritative() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	var actual testutil.TestStruct
+
+	suite.thirdDummyAdapter.On("GetWithVersion", testutil.TestKeyForVersion, &actual).Once().Return(cacheadapters.Version("1"), nil)
+
+	version, err := adapter.GetWithVersion(testutil.TestKeyForVersion, &actual)
+	suite.NoError(err, "Should not error on valid GetWithVersion")
+	suite.Equal(cacheadapters.Version("1"), version, "Should be the version of the authoritative sub-adapter")
+	suite.Equal(testutil.TestValue, actual, "Should be equal to the provided test value")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestCompareAndSwap_PropagatesWhenSwapped() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.thirdDummyAdapter.On("CompareAndSwap", testutil.TestKeyForVersion, cacheadapters.Version("1"), testutil.TestValue, &testutil.DummyTTL).Once().Return(nil)
+	suite.firstDummyAdapter.On("Set", testutil.TestKeyForVersion, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(nil)
+	suite.secondDummyAdapter.On("Set", testutil.TestKeyForVersion, testutil.TestValueRaw, &testutil.DummyTTL).Once().Return(nil)
+
+	err := adapter.CompareAndSwap(testutil.TestKeyForVersion, "1", testutil.TestValue, &testutil.DummyTTL)
+	suite.NoError(err, "Should not error on valid CompareAndSwap")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestCompareAndSwap_Conflict() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.thirdDummyAdapter.On("CompareAndSwap", testutil.TestKeyForVersion, cacheadapters.Version("1"), testutil.TestValue, &testutil.DummyTTL).Once().Return(cacheadapters.ErrVersionConflict)
+
+	err := adapter.CompareAndSwap(testutil.TestKeyForVersion, "1", testutil.TestValue, &testutil.DummyTTL)
+	suite.ErrorIs(err, cacheadapters.ErrVersionConflict, "Should return the conflict of the authoritative sub-adapter")
+
+	suite.firstDummyAdapter.AssertNotCalled(suite.T(), "Set", testutil.TestKeyForVersion, testutil.TestValueRaw, &testutil.DummyTTL)
+	suite.secondDummyAdapter.AssertNotCalled(suite.T(), "Set", testutil.TestKeyForVersion, testutil.TestValueRaw, &testutil.DummyTTL)
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestSetWithTags_PartialErrorAndWarnings() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	tags := []string{testutil.TestTag}
+
+	suite.firstDummyAdapter.On("SetWithTags", testutil.TestKeyForTags, testutil.TestValue, &testutil.DummyTTL, tags).Once().Return(nil)
+	suite.secondDummyAdapter.On("SetWithTags", testutil.TestKeyForTags, testutil.TestValue, &testutil.DummyTTL, tags).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("SetWithTags", testutil.TestKeyForTags, testutil.TestValue, &testutil.DummyTTL, tags).Once().Return(nil)
+
+	err := adapter.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, &testutil.DummyTTL, testutil.TestTag)
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn on partial failure")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestInvalidateTag_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(nil)
+	suite.secondDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(nil)
+	suite.thirdDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(nil)
+
+	err := adapter.InvalidateTag(testutil.TestTag)
+	suite.NoError(err, "Should not error on valid InvalidateTag")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+	suite.thirdDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestInvalidateTag_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("InvalidateTag", testutil.TestTag).Once().Return(testutil.ErrTestingFailureCheck)
+
+	err := adapter.InvalidateTag(testutil.TestTag)
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if every sub-adapter fails")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestScan_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	firstIterator := newMockKeyIterator(nil, "a", "b")
+	secondIterator := newMockKeyIterator(nil, "b", "c")
+	thirdIterator := newMockKeyIterator(nil, "a", "d")
+
+	suite.firstDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(firstIterator, nil)
+	suite.secondDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(secondIterator, nil)
+	suite.thirdDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(thirdIterator, nil)
+
+	iterator, err := adapter.Scan(testutil.TestKeyForScan)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+
+	suite.Equal([]string{"a", "b", "c", "d"}, collectKeys(iterator), "Should merge the keys of every sub-adapter without duplicates")
+	suite.NoError(iterator.Err(), "Should not error after a valid iteration")
+
+	suite.NoError(iterator.Close(), "Should not error on Close")
+	suite.True(firstIterator.closed, "Should close the iterator of the first sub-adapter")
+	suite.True(secondIterator.closed, "Should close the iterator of the second sub-adapter")
+	suite.True(thirdIterator.closed, "Should close the iterator of the third sub-adapter")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+	suite.thirdDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestScan_PartialFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	suite.firstDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(newMockKeyIterator(testutil.ErrTestingFailureCheck, "a"), nil)
+	suite.thirdDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(newMockKeyIterator(nil, "b"), nil)
+
+	iterator, err := adapter.Scan(testutil.TestKeyForScan)
+	suite.Require().NotNil(iterator, "Should return the iterator if some sub-adapters succeed")
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn about the failed sub-adapter")
+
+	suite.Equal([]string{"a", "b"}, collectKeys(iterator), "Should return the keys of the working sub-adapters")
+	suite.ErrorIs(iterator.Err(), multicacheadapters.ErrMultiCacheWarning, "Should warn about the failed sub-adapters")
+	suite.ErrorIs(iterator.Err(), testutil.ErrTestingFailureCheck, "Should contain the errors of the failed sub-adapters")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestScan_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(nil, testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("Scan", testutil.TestKeyForScan).Once().Return(nil, testutil.ErrTestingFailureCheck)
+
+	iterator, err := adapter.Scan(testutil.TestKeyForScan)
+	suite.Nil(iterator, "Should be nil if every sub-adapter fails")
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if every sub-adapter fails")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestClear_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Clear").Once().Return(nil)
+	suite.secondDummyAdapter.On("Clear").Once().Return(nil)
+	suite.thirdDummyAdapter.On("Clear").Once().Return(nil)
+
+	err := adapter.Clear()
+	suite.NoError(err, "Should not error on valid Clear")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+	suite.thirdDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestClear_PartialFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+	adapter.EnableWarnings()
+
+	suite.firstDummyAdapter.On("Clear").Once().Return(testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Clear").Once().Return(nil)
+	suite.thirdDummyAdapter.On("Clear").Once().Return(nil)
+
+	err := adapter.Clear()
+	suite.ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn if a sub-adapter fails")
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should contain the error of the failed sub-adapter")
+
+	suite.thirdDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestClear_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("Clear").Once().Return(testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("Clear").Once().Return(testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("Clear").Once().Return(testutil.ErrTestingFailureCheck)
+
+	err := adapter.Clear()
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if every sub-adapter fails")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestClearPrefix_OK() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(nil)
+	suite.secondDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(nil)
+	suite.thirdDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(nil)
+
+	err := adapter.ClearPrefix(testutil.TestKeyForClear)
+	suite.NoError(err, "Should not error on valid ClearPrefix")
+
+	suite.firstDummyAdapter.AssertExpectations(suite.T())
+	suite.secondDummyAdapter.AssertExpectations(suite.T())
+	suite.thirdDummyAdapter.AssertExpectations(suite.T())
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestClearPrefix_TotalFail() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	suite.firstDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.secondDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(testutil.ErrTestingFailureCheck)
+	suite.thirdDummyAdapter.On("ClearPrefix", testutil.TestKeyForClear).Once().Return(testutil.ErrTestingFailureCheck)
+
+	err := adapter.ClearPrefix(testutil.TestKeyForClear)
+	suite.ErrorIs(err, testutil.ErrTestingFailureCheck, "Should error if every sub-adapter fails")
+}
+
+func (suite *MultiCacheAdapterTestSuite) TestUseCodec_Nil() {
+	adapter, _ := multicacheadapters.New(suite.firstDummyAdapter, suite.secondDummyAdapter, suite.thirdDummyAdapter)
+
+	err := adapter.UseCodec(nil)
+	suite.ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
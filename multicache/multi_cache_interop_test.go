@@ -0,0 +1,165 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicacheadapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	rediscacheadapters "github.com/tryvium-travels/golang-cache-adapters/redis"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestMultiCacheInteropSuite(t *testing.T) {
+	suite.Run(t, new(MultiCacheInteropTestSuite))
+}
+
+// MultiCacheInteropTestSuite checks that values written by any of
+// the real adapters can be read by all the others, both directly
+// and through a MultiCacheAdapter.
+type MultiCacheInteropTestSuite struct {
+	suite.Suite
+	redisServer *miniredis.Miniredis
+	tiers       map[string]cacheadapters.CacheAdapter
+}
+
+func (suite *MultiCacheInteropTestSuite) SetupTest() {
+	var err error
+
+	suite.redisServer, err = miniredis.Run()
+	suite.Require().NoError(err, "Should start the local redis server")
+
+	redisPool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", suite.redisServer.Addr())
+		},
+	}
+
+	inMemoryAdapter, err := inmemorycacheadapters.New(time.Minute)
+	suite.Require().NoError(err, "Should create the in-memory adapter")
+
+	redisAdapter, err := rediscacheadapters.New(redisPool, time.Minute)
+	suite.Require().NoError(err, "Should create the redis adapter")
+
+	suite.tiers = map[string]cacheadapters.CacheAdapter{
+		"in-memory": inMemoryAdapter,
+		"redis":     redisAdapter,
+	}
+}
+
+func (suite *MultiCacheInteropTestSuite) TearDownTest() {
+	suite.redisServer.Close()
+}
+
+func (suite *MultiCacheInteropTestSuite) TestRoundTrip_BetweenTiers() {
+	for writerName, writer := range suite.tiers {
+		err := writer.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+		suite.Require().NoError(err, "Should not error on Set in the %s tier", writerName)
+
+		for readerName, reader := range suite.tiers {
+			var raw cacheadapters.RawMessage
+			err = reader.Get(testutil.TestKeyForSet, &raw)
+			if readerName != writerName {
+				suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not find the value in the %s tier", readerName)
+
+				err = reader.Set(testutil.TestKeyForSet, suite.readRaw(writer, testutil.TestKeyForSet), nil)
+				suite.Require().NoError(err, "Should copy the raw value into the %s tier", readerName)
+			}
+
+			var actual testutil.TestStruct
+			err = reader.Get(testutil.TestKeyForSet, &actual)
+			suite.Require().NoError(err, "Should read in the %s tier the value written by the %s tier", readerName, writerName)
+			suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+			suite.Require().Equal(testutil.TestValueRaw, suite.readRaw(reader, testutil.TestKeyForSet), "Should store the same bytes in every tier")
+		}
+
+		for _, tier := range suite.tiers {
+			err = tier.Delete(testutil.TestKeyForSet)
+			suite.Require().NoError(err, "Should not error on Delete")
+		}
+	}
+}
+
+func (suite *MultiCacheInteropTestSuite) TestGet_FromEveryTier() {
+	adapter, err := multicacheadapters.New(suite.tiers["in-memory"], suite.tiers["redis"])
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+
+	for name, tier := range suite.tiers {
+		err = tier.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+		suite.Require().NoError(err, "Should not error on Set in the %s tier", name)
+
+		var actual testutil.TestStruct
+		err = adapter.Get(testutil.TestKeyForGet, &actual)
+		suite.Require().NoError(err, "Should read the value written in the %s tier", name)
+		suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+
+		results, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForGet: &actual})
+		suite.Require().NoError(err, "Should read the value written in the %s tier", name)
+		suite.Require().NoError(results[testutil.TestKeyForGet], "Should find the value written in the %s tier", name)
+
+		err = tier.Delete(testutil.TestKeyForGet)
+		suite.Require().NoError(err, "Should not error on Delete")
+	}
+}
+
+func (suite *MultiCacheInteropTestSuite) TestSetIfAbsent_PropagatesRawValue() {
+	adapter, err := multicacheadapters.New(suite.tiers["in-memory"], suite.tiers["redis"])
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+
+	written, err := adapter.SetIfAbsent(testutil.TestKeyForSetIf, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on SetIfAbsent")
+	suite.Require().True(written, "Should write the absent value")
+
+	for name, tier := range suite.tiers {
+		suite.Require().Equal(testutil.TestValueRaw, suite.readRaw(tier, testutil.TestKeyForSetIf), "Should store the same bytes in the %s tier", name)
+	}
+}
+
+func (suite *MultiCacheInteropTestSuite) TestUseCodec_Gob() {
+	codec := cacheadapters.GobCodec{}
+
+	inMemoryAdapter, err := inmemorycacheadapters.New(time.Minute, inmemorycacheadapters.WithCodec(codec))
+	suite.Require().NoError(err, "Should create the in-memory adapter")
+
+	adapter, err := multicacheadapters.New(inMemoryAdapter)
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+
+	err = adapter.UseCodec(codec)
+	suite.Require().NoError(err, "Should not error on valid codec")
+
+	err = inMemoryAdapter.Set(testutil.TestKeyForGet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().NoError(err, "Should decode the value with the codec")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+}
+
+// readRaw reads the value stored in the key of
+// the tier, without decoding it.
+func (suite *MultiCacheInteropTestSuite) readRaw(tier cacheadapters.CacheAdapter, key string) cacheadapters.RawMessage {
+	var raw cacheadapters.RawMessage
+	err := tier.Get(key, &raw)
+	suite.Require().NoError(err, "Should read the raw value")
+
+	return raw
+}
@@ -15,7 +15,7 @@
 package multicacheadapters
 
 import (
-	"encoding/json"
+	"context"
 	"reflect"
 	"sync"
 	"time"
@@ -30,6 +30,7 @@ import (
 type MultiCacheSessionAdapter struct {
 	subAdapters  []cacheadapters.CacheSessionAdapter // The array of sub-adapters
 	showWarnings bool
+	codec        cacheadapters.Codec // The codec used to decode and propagate the values.
 	wg           sync.WaitGroup
 }
 
@@ -51,7 +52,7 @@ func NewSession(adapters ...cacheadapters.CacheSessionAdapter) (*MultiCacheSessi
 		return nil, ErrInvalidSubAdapters
 	}
 
-	return &MultiCacheSessionAdapter{finalAdapters, false, sync.WaitGroup{}}, nil
+	return &MultiCacheSessionAdapter{finalAdapters, false, cacheadapters.JSONCodec{}, sync.WaitGroup{}}, nil
 }
 
 // EnableWarning enable the return of warning errors.
@@ -100,20 +101,41 @@ func (mcsa *MultiCacheSessionAdapter) DisableWarnings() {
 	mcsa.showWarnings = false
 }
 
+// UseCodec sets the codec used to decode the values read from the
+// sub-adapters and to encode the values propagated between them.
+//
+// Values are moved between the sub-adapters as raw encoded bytes,
+// so every sub-adapter must be configured with the same codec
+// (cacheadapters.JSONCodec by default).
+func (mcsa *MultiCacheSessionAdapter) UseCodec(codec cacheadapters.Codec) error {
+	if codec == nil {
+		return cacheadapters.ErrNilCodec
+	}
+
+	mcsa.codec = codec
+	return nil
+}
+
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (mcsa *MultiCacheSessionAdapter) Get(key string, objectRef interface{}) error {
+	return mcsa.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
 	errs := make([]error, 0, len(mcsa.subAdapters))
 	for _, adapter := range mcsa.subAdapters {
-		var temp json.RawMessage
+		var temp cacheadapters.RawMessage
 
-		err := adapter.Get(key, &temp)
+		err := adapter.GetContext(ctx, key, &temp)
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
 
-		err = json.Unmarshal(temp, &objectRef)
+		err = cacheadapters.Unmarshal(mcsa.codec, temp, objectRef)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -127,9 +149,15 @@ func (mcsa *MultiCacheSessionAdapter) Get(key string, objectRef interface{}) err
 
 // Set sets a value represented by the object parameter into the cache, with the specified key.
 func (mcsa *MultiCacheSessionAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
+	return mcsa.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
 	errs := make([]error, 0, len(mcsa.subAdapters))
 	for _, adapter := range mcsa.subAdapters {
-		err := adapter.Set(key, object, TTL)
+		err := adapter.SetContext(ctx, key, object, TTL)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -141,9 +169,15 @@ func (mcsa *MultiCacheSessionAdapter) Set(key string, object interface{}, TTL *t
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (mcsa *MultiCacheSessionAdapter) SetTTL(key string, newTTL time.Duration) error {
+	return mcsa.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
 	errs := make([]error, 0, len(mcsa.subAdapters))
 	for _, adapter := range mcsa.subAdapters {
-		err := adapter.SetTTL(key, newTTL)
+		err := adapter.SetTTLContext(ctx, key, newTTL)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -154,9 +188,476 @@ func (mcsa *MultiCacheSessionAdapter) SetTTL(key string, newTTL time.Duration) e
 
 // Delete deletes a key from the cache.
 func (mcsa *MultiCacheSessionAdapter) Delete(key string) error {
+	return mcsa.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) DeleteContext(ctx context.Context, key string) error {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		err := adapter.DeleteContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mcsa.errorOrNil(errs)
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (mcsa *MultiCacheSessionAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return mcsa.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// The keys are requested to the sub-adapters following their priority:
+// each sub-adapter is asked only for the keys that have not been found
+// in the previous ones.
+func (mcsa *MultiCacheSessionAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(objectRefs))
+	remaining := make(map[string]interface{}, len(objectRefs))
+	for key, objectRef := range objectRefs {
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		remaining[key] = objectRef
+	}
+
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		if len(remaining) == 0 {
+			break
+		}
+
+		temps := make(map[string]interface{}, len(remaining))
+		for key := range remaining {
+			temps[key] = new(cacheadapters.RawMessage)
+		}
+
+		subResult, err := adapter.GetManyContext(ctx, temps)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for key, objectRef := range remaining {
+			err, exists := subResult[key]
+			if !exists {
+				err = cacheadapters.ErrNotFound
+			}
+
+			if err == nil {
+				err = cacheadapters.Unmarshal(mcsa.codec, *temps[key].(*cacheadapters.RawMessage), objectRef)
+			}
+
+			result[key] = err
+			if err == nil {
+				delete(remaining, key)
+			}
+		}
+	}
+
+	if len(errs) == len(mcsa.subAdapters) {
+		return nil, mcsa.errorOrNil(errs)
+	}
+
+	return result, mcsa.errorOrNil(errs)
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (mcsa *MultiCacheSessionAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return mcsa.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// A key is reported as successfully set if at least one of the
+// sub-adapters managed to set it.
+func (mcsa *MultiCacheSessionAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(objects))
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		subResult, err := adapter.SetManyContext(ctx, objects, TTL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mergeBatchResult(result, subResult)
+	}
+
+	if len(errs) == len(mcsa.subAdapters) {
+		return nil, mcsa.errorOrNil(errs)
+	}
+
+	return result, mcsa.errorOrNil(errs)
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (mcsa *MultiCacheSessionAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return mcsa.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// A key is reported as successfully deleted if at least one of the
+// sub-adapters managed to delete it.
+func (mcsa *MultiCacheSessionAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(keys))
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		subResult, err := adapter.DeleteManyContext(ctx, keys)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mergeBatchResult(result, subResult)
+	}
+
+	if len(errs) == len(mcsa.subAdapters) {
+		return nil, mcsa.errorOrNil(errs)
+	}
+
+	return result, mcsa.errorOrNil(errs)
+}
+
+// Exists checks if a key is present in at least one of the sub-adapters,
+// following their priority.
+func (mcsa *MultiCacheSessionAdapter) Exists(key string) (bool, error) {
+	return mcsa.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		exists, err := adapter.ExistsContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if exists {
+			return true, mcsa.errorOrNil(errs)
+		}
+	}
+
+	return false, mcsa.errorOrNil(errs)
+}
+
+// TTL obtains the remaining time to live of a key from the first
+// sub-adapter which contains it, following their priority.
+func (mcsa *MultiCacheSessionAdapter) TTL(key string) (time.Duration, error) {
+	return mcsa.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		remainingTTL, err := adapter.TTLContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		return remainingTTL, mcsa.errorOrNil(errs)
+	}
+
+	return 0, mcsa.errorOrNil(errs)
+}
+
+// ExistsPerTier asks every sub-adapter whether a key is present,
+// returning the answers in the same order of the sub-adapters.
+func (mcsa *MultiCacheSessionAdapter) ExistsPerTier(key string) []TierExists {
+	return mcsa.ExistsPerTierContext(context.Background(), key)
+}
+
+// ExistsPerTierContext is the same as ExistsPerTier, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) ExistsPerTierContext(ctx context.Context, key string) []TierExists {
+	answers := make([]TierExists, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		exists, err := adapter.ExistsContext(ctx, key)
+		answers = append(answers, TierExists{Exists: exists, Err: err})
+	}
+
+	return answers
+}
+
+// TTLPerTier asks every sub-adapter the remaining time to live of
+// a key, returning the answers in the same order of the sub-adapters.
+func (mcsa *MultiCacheSessionAdapter) TTLPerTier(key string) []TierTTL {
+	return mcsa.TTLPerTierContext(context.Background(), key)
+}
+
+// TTLPerTierContext is the same as TTLPerTier, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) TTLPerTierContext(ctx context.Context, key string) []TierTTL {
+	answers := make([]TierTTL, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		remainingTTL, err := adapter.TTLContext(ctx, key)
+		answers = append(answers, TierTTL{TTL: remainingTTL, Err: err})
+	}
+
+	return answers
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// The last sub-adapter, which usually is the one shared among the
+// instances of the application, is authoritative for counters: the
+// operation is performed only there, and the key is deleted from the
+// other sub-adapters, so that reads fall through to the authoritative one.
+// Failures of the authoritative sub-adapter are returned as errors, while
+// failures while deleting the key from the others are warnings.
+func (mcsa *MultiCacheSessionAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mcsa.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	authoritative := mcsa.subAdapters[len(mcsa.subAdapters)-1]
+
+	counter, err := authoritative.IncrementContext(ctx, key, delta, TTL)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters[:len(mcsa.subAdapters)-1] {
+		err := adapter.DeleteContext(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return counter, mcsa.warningOrNil(errs)
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (mcsa *MultiCacheSessionAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mcsa.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return mcsa.IncrementContext(ctx, key, -delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+//
+// As for counters, the last sub-adapter is authoritative: the condition
+// is checked only there and, if the value has been written, it is then
+// Set into the other sub-adapters. Failures of the authoritative
+// sub-adapter are returned as errors, while failures while setting the
+// value into the others are warnings.
+func (mcsa *MultiCacheSessionAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return mcsa.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	authoritative := mcsa.subAdapters[len(mcsa.subAdapters)-1]
+
+	written, err := authoritative.SetIfAbsentContext(ctx, key, object, TTL)
+	if err != nil || !written {
+		return written, err
+	}
+
+	return true, mcsa.propagateSet(ctx, key, object, TTL)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+//
+// It follows the same rules of SetIfAbsent.
+func (mcsa *MultiCacheSessionAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return mcsa.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	authoritative := mcsa.subAdapters[len(mcsa.subAdapters)-1]
+
+	written, err := authoritative.SetIfPresentContext(ctx, key, object, TTL)
+	if err != nil || !written {
+		return written, err
+	}
+
+	return true, mcsa.propagateSet(ctx, key, object, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+//
+// Since versions are specific to each sub-adapter, the value is
+// obtained from the last sub-adapter, which is authoritative for
+// CompareAndSwap operations.
+func (mcsa *MultiCacheSessionAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return mcsa.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	authoritative := mcsa.subAdapters[len(mcsa.subAdapters)-1]
+
+	return authoritative.GetWithVersionContext(ctx, key, objectRef)
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+//
+// It follows the same rules of SetIfAbsent: the version is checked on
+// the last sub-adapter and, if the value has been swapped, it is then
+// Set into the other sub-adapters.
+func (mcsa *MultiCacheSessionAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return mcsa.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	authoritative := mcsa.subAdapters[len(mcsa.subAdapters)-1]
+
+	err := authoritative.CompareAndSwapContext(ctx, key, version, newObject, TTL)
+	if err != nil {
+		return err
+	}
+
+	return mcsa.propagateSet(ctx, key, newObject, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+func (mcsa *MultiCacheSessionAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return mcsa.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		err := adapter.SetWithTagsContext(ctx, key, object, TTL, tags...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mcsa.errorOrNil(errs)
+}
+
+// InvalidateTag deletes all the entries carrying the given tag
+// from every sub-adapter.
+func (mcsa *MultiCacheSessionAdapter) InvalidateTag(tag string) error {
+	return mcsa.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		err := adapter.InvalidateTagContext(ctx, tag)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mcsa.errorOrNil(errs)
+}
+
+// Scan returns an iterator over the keys of every sub-adapter
+// starting with the given prefix, or over all the keys if it is
+// empty, without returning the same key twice.
+//
+// The keys are returned in sub-adapter order, and the keys
+// already returned are kept in memory to skip the duplicates.
+func (mcsa *MultiCacheSessionAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return mcsa.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	iterators := make([]cacheadapters.KeyIterator, 0, len(mcsa.subAdapters))
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		iterator, err := adapter.ScanContext(ctx, prefix)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		iterators = append(iterators, iterator)
+	}
+
+	if len(iterators) == 0 {
+		return nil, mcsa.errorOrNil(errs)
+	}
+
+	iterator := newMergedKeyIterator(iterators, errs, mcsa.errorOrNil)
+	return iterator, iterator.Err()
+}
+
+// Clear deletes all the entries of every sub-adapter.
+func (mcsa *MultiCacheSessionAdapter) Clear() error {
+	return mcsa.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) ClearContext(ctx context.Context) error {
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters {
+		err := adapter.ClearContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mcsa.errorOrNil(errs)
+}
+
+// ClearPrefix deletes all the entries whose key starts with the
+// given prefix from every sub-adapter.
+func (mcsa *MultiCacheSessionAdapter) ClearPrefix(prefix string) error {
+	return mcsa.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (mcsa *MultiCacheSessionAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
 	errs := make([]error, 0, len(mcsa.subAdapters))
 	for _, adapter := range mcsa.subAdapters {
-		err := adapter.Delete(key)
+		err := adapter.ClearPrefixContext(ctx, prefix)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -192,3 +693,35 @@ func (mcsa *MultiCacheSessionAdapter) errorOrNil(errs []error) error {
 
 	return nil
 }
+
+// propagateSet sets the value written into the authoritative sub-adapter
+// into the other sub-adapters, returning their failures as warnings.
+//
+// The value is encoded only once and written as raw bytes.
+func (mcsa *MultiCacheSessionAdapter) propagateSet(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	content, err := cacheadapters.Marshal(mcsa.codec, object)
+	if err != nil {
+		return mcsa.warningOrNil([]error{err})
+	}
+
+	errs := make([]error, 0, len(mcsa.subAdapters))
+	for _, adapter := range mcsa.subAdapters[:len(mcsa.subAdapters)-1] {
+		err := adapter.SetContext(ctx, key, cacheadapters.RawMessage(content), TTL)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mcsa.warningOrNil(errs)
+}
+
+// warningOrNil parses the errors accumulated while operating on
+// non-authoritative sub-adapters into one final warning, or returns
+// nil if there are none or the warnings are disabled.
+func (mcsa *MultiCacheSessionAdapter) warningOrNil(errs []error) error {
+	if mcsa.showWarnings && len(errs) > 0 {
+		return multierror.Append(ErrMultiCacheWarning, errs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicacheadapters
+
+import "time"
+
+// TierExists is the answer of a single sub-adapter to
+// an Exists operation, as reported by ExistsPerTier.
+type TierExists struct {
+	Exists bool  // Whether the key is present in the sub-adapter.
+	Err    error // The error returned by the sub-adapter, if any.
+}
+
+// TierTTL is the answer of a single sub-adapter to
+// a TTL operation, as reported by TTLPerTier.
+type TierTTL struct {
+	TTL time.Duration // The remaining time to live of the key in the sub-adapter.
+	Err error         // The error returned by the sub-adapter, if any.
+}
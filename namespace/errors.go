@@ -0,0 +1,26 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespacecacheadapters
+
+import "fmt"
+
+var (
+	// ErrNilAdapter will come out if you try to pass a nil adapter
+	// or session when creating a new NamespaceAdapter or a session.
+	ErrNilAdapter = fmt.Errorf("you must pass a valid adapter to namespace, nil found")
+	// ErrInvalidNamespace will come out if you try to pass an empty
+	// or whitespace-only namespace.
+	ErrInvalidNamespace = fmt.Errorf("you must pass a non-empty namespace")
+)
@@ -0,0 +1,73 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespacecacheadapters
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// Separator is put between the namespace and the keys.
+const Separator = ":"
+
+// NamespaceAdapter is a cache adapter which prefixes every key and
+// tag with a namespace before passing it to the wrapped adapter.
+type NamespaceAdapter struct {
+	namespacedOperator
+	adapter cacheadapters.CacheAdapter // The wrapped adapter.
+}
+
+// New creates a new NamespaceAdapter which isolates the keys of the
+// given adapter inside the namespace.
+//
+// Wrapping a NamespaceAdapter nests the namespaces, e.g. the key "k"
+// of New(New(adapter, "a"), "b") is stored as "a:b:k".
+func New(adapter cacheadapters.CacheAdapter, namespace string) (*NamespaceAdapter, error) {
+	if value := reflect.ValueOf(adapter); adapter == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	if strings.TrimSpace(namespace) == "" {
+		return nil, ErrInvalidNamespace
+	}
+
+	return &NamespaceAdapter{
+		namespacedOperator: namespacedOperator{
+			inner:     cacheadapters.AdapterAsSession(adapter),
+			namespace: namespace,
+		},
+		adapter: adapter,
+	}, nil
+}
+
+// OpenSession opens a new Cache Session on the wrapped adapter,
+// using the same namespace.
+func (na *NamespaceAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
+	return na.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext is the same as OpenSession, but honors the
+// cancellation and the deadline of the given context.
+func (na *NamespaceAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	session, err := na.adapter.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSession(session, na.namespace)
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespacecacheadapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	namespacecacheadapters "github.com/tryvium-travels/golang-cache-adapters/namespace"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+const testNamespace = "test-namespace"
+
+// NamespaceAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type NamespaceAdapterTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL time.Duration
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return namespacecacheadapters.New(inMemoryAdapter, testNamespace)
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		adapter, err := newTestAdapterFunc(defaultTTL)()
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter.OpenSession()
+	}
+}
+
+// newNamespaceTestSuite creates a new test suite with tests for
+// Namespace adapters and sessions, backed by an In-Memory adapter.
+func newNamespaceTestSuite(defaultTTL time.Duration) *NamespaceAdapterTestSuite {
+	var suite suite.Suite
+
+	return &NamespaceAdapterTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestNamespaceAdapterSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newNamespaceTestSuite(defaultTTL))
+}
+
+func (suite *NamespaceAdapterTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNew_NilAdapter() {
+	var nilAdapter *inmemorycacheadapters.InMemoryAdapter
+
+	adapter, err := namespacecacheadapters.New(nil, testNamespace)
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, namespacecacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+
+	adapter, err = namespacecacheadapters.New(nilAdapter, testNamespace)
+	suite.Require().Nil(adapter, "Should be nil on typed nil adapter")
+	suite.Require().ErrorIs(err, namespacecacheadapters.ErrNilAdapter, "Should give error on typed nil adapter")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNew_InvalidNamespace() {
+	for _, namespace := range []string{"", " "} {
+		adapter, err := namespacecacheadapters.New(suite.newInMemoryAdapter(), namespace)
+		suite.Require().Nil(adapter, "Should be nil on invalid namespace %q", namespace)
+		suite.Require().ErrorIs(err, namespacecacheadapters.ErrInvalidNamespace, "Should give error on invalid namespace %q", namespace)
+	}
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNewSession_NilSession() {
+	session, err := namespacecacheadapters.NewSession(nil, testNamespace)
+	suite.Require().Nil(session, "Should be nil on nil session")
+	suite.Require().ErrorIs(err, namespacecacheadapters.ErrNilAdapter, "Should give error on nil session")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNewSession_InvalidNamespace() {
+	session, err := suite.newInMemoryAdapter().OpenSession()
+	suite.Require().NoError(err, "Should not fail to open the session")
+
+	namespaceSession, err := namespacecacheadapters.NewSession(session, "")
+	suite.Require().Nil(namespaceSession, "Should be nil on invalid namespace")
+	suite.Require().ErrorIs(err, namespacecacheadapters.ErrInvalidNamespace, "Should give error on invalid namespace")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_PrefixesKeys() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+
+	adapter, err := namespacecacheadapters.New(inMemoryAdapter, testNamespace)
+	suite.Require().NoError(err, "Should not fail to create the adapter")
+	suite.Require().Equal(testNamespace, adapter.Namespace(), "Should expose its namespace")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key")
+
+	var actual testutil.TestStruct
+	err = inMemoryAdapter.Get(testNamespace+namespacecacheadapters.Separator+testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should store the key with the namespace prefix")
+	suite.Require().Equal(testutil.TestValue, actual, "Should store the same value")
+
+	err = inMemoryAdapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not store the key without the namespace prefix")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_Isolation() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+
+	first, err := namespacecacheadapters.New(inMemoryAdapter, "first")
+	suite.Require().NoError(err, "Should not fail to create the first adapter")
+
+	second, err := namespacecacheadapters.New(inMemoryAdapter, "second")
+	suite.Require().NoError(err, "Should not fail to create the second adapter")
+
+	err = first.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, testutil.TestTag)
+	suite.Require().NoError(err, "Should not fail to set the key in the first namespace")
+
+	err = second.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, testutil.TestTag)
+	suite.Require().NoError(err, "Should not fail to set the key in the second namespace")
+
+	err = first.InvalidateTag(testutil.TestTag)
+	suite.Require().NoError(err, "Should not fail to invalidate the tag in the first namespace")
+
+	exists, err := first.Exists(testutil.TestKeyForTags)
+	suite.Require().NoError(err, "Should not fail to check the key in the first namespace")
+	suite.Require().False(exists, "Should invalidate the key in the first namespace")
+
+	exists, err = second.Exists(testutil.TestKeyForTags)
+	suite.Require().NoError(err, "Should not fail to check the key in the second namespace")
+	suite.Require().True(exists, "Should not invalidate the key in the second namespace")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_Nested() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+
+	outer, err := namespacecacheadapters.New(inMemoryAdapter, "outer")
+	suite.Require().NoError(err, "Should not fail to create the outer adapter")
+
+	inner, err := namespacecacheadapters.New(outer, "inner")
+	suite.Require().NoError(err, "Should not fail to create the inner adapter")
+
+	err = inner.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key")
+
+	var actual testutil.TestStruct
+	err = outer.Get("inner"+namespacecacheadapters.Separator+testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should find the key in the outer namespace")
+
+	err = inMemoryAdapter.Get("outer:inner:"+testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should compose the namespaces")
+	suite.Require().Equal(testutil.TestValue, actual, "Should store the same value")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_BatchResultKeys() {
+	adapter, err := namespacecacheadapters.New(suite.newInMemoryAdapter(), testNamespace)
+	suite.Require().NoError(err, "Should not fail to create the adapter")
+
+	var actual testutil.TestStruct
+	result, err := adapter.GetMany(map[string]interface{}{testutil.TestKeyForMany: &actual})
+	suite.Require().NoError(err, "Should not fail on a batch miss")
+	suite.Require().Contains(result, testutil.TestKeyForMany, "Should report the key without the namespace prefix")
+	suite.Require().ErrorIs(result[testutil.TestKeyForMany], cacheadapters.ErrNotFound, "Should report the miss")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_ScanIsolation() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+
+	first, err := namespacecacheadapters.New(inMemoryAdapter, "first")
+	suite.Require().NoError(err, "Should not fail to create the first adapter")
+
+	second, err := namespacecacheadapters.New(inMemoryAdapter, "second")
+	suite.Require().NoError(err, "Should not fail to create the second adapter")
+
+	err = first.Set(testutil.TestKeyForScan+"first", testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key in the first namespace")
+
+	err = second.Set(testutil.TestKeyForScan+"second", testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key in the second namespace")
+
+	iterator, err := first.Scan("")
+	suite.Require().NoError(err, "Should not fail to scan the first namespace")
+	defer iterator.Close()
+
+	keys := make([]string, 0)
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	suite.Require().NoError(iterator.Err(), "Should not fail to iterate over the first namespace")
+	suite.Require().Equal([]string{testutil.TestKeyForScan + "first"}, keys, "Should return only the keys of the namespace, without prefix")
+}
+
+func (suite *NamespaceAdapterTestSuite) TestNamespace_ClearIsolation() {
+	inMemoryAdapter := suite.newInMemoryAdapter()
+
+	first, err := namespacecacheadapters.New(inMemoryAdapter, "first")
+	suite.Require().NoError(err, "Should not fail to create the first adapter")
+
+	second, err := namespacecacheadapters.New(inMemoryAdapter, "second")
+	suite.Require().NoError(err, "Should not fail to create the second adapter")
+
+	err = first.Set(testutil.TestKeyForClear, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key in the first namespace")
+
+	err = second.Set(testutil.TestKeyForClear, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not fail to set the key in the second namespace")
+
+	err = first.Clear()
+	suite.Require().NoError(err, "Should not fail to clear the first namespace")
+
+	exists, err := first.Exists(testutil.TestKeyForClear)
+	suite.Require().NoError(err, "Should not fail to check the key in the first namespace")
+	suite.Require().False(exists, "Should clear the key in the first namespace")
+
+	exists, err = second.Exists(testutil.TestKeyForClear)
+	suite.Require().NoError(err, "Should not fail to check the key in the second namespace")
+	suite.Require().True(exists, "Should not clear the key in the second namespace")
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespacecacheadapters
+
+import (
+	"reflect"
+	"strings"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// NamespaceSessionAdapter is a cache session adapter which prefixes every
+// key and tag with a namespace before passing it to the wrapped session.
+type NamespaceSessionAdapter struct {
+	namespacedOperator
+}
+
+// NewSession creates a new NamespaceSessionAdapter which isolates
+// the keys of the given session inside the namespace.
+func NewSession(session cacheadapters.CacheSessionAdapter, namespace string) (*NamespaceSessionAdapter, error) {
+	if value := reflect.ValueOf(session); session == nil || !value.IsValid() || value.IsNil() {
+		return nil, ErrNilAdapter
+	}
+
+	if strings.TrimSpace(namespace) == "" {
+		return nil, ErrInvalidNamespace
+	}
+
+	return &NamespaceSessionAdapter{
+		namespacedOperator: namespacedOperator{
+			inner:     session,
+			namespace: namespace,
+		},
+	}, nil
+}
+
+// Close closes the wrapped Cache Session.
+func (nsa *NamespaceSessionAdapter) Close() error {
+	return nsa.inner.Close()
+}
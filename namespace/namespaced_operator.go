@@ -0,0 +1,354 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespacecacheadapters
+
+import (
+	"context"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// namespacedOperator implements the operations shared between
+// NamespaceAdapter and NamespaceSessionAdapter.
+type namespacedOperator struct {
+	inner     cacheadapters.CacheSessionAdapter // The wrapped adapter or session.
+	namespace string                            // The namespace of the keys.
+}
+
+// Namespace returns the namespace of the keys.
+func (no namespacedOperator) Namespace() string {
+	return no.namespace
+}
+
+// Get obtains a value from the cache using a key, then tries to unmarshal
+// it into the object reference passed as parameter.
+func (no namespacedOperator) Get(key string, objectRef interface{}) error {
+	return no.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	return no.inner.GetContext(ctx, no.prefixed(key), objectRef)
+}
+
+// Set sets a value represented by the object parameter into the cache, with the specified key.
+func (no namespacedOperator) Set(key string, object interface{}, TTL *time.Duration) error {
+	return no.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	return no.inner.SetContext(ctx, no.prefixed(key), object, TTL)
+}
+
+// SetTTL marks the specified key new expiration, deletes it via using
+// cacheadapters.TTLExpired or negative duration.
+func (no namespacedOperator) SetTTL(key string, newTTL time.Duration) error {
+	return no.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	return no.inner.SetTTLContext(ctx, no.prefixed(key), newTTL)
+}
+
+// Delete deletes a key from the cache.
+func (no namespacedOperator) Delete(key string) error {
+	return no.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) DeleteContext(ctx context.Context, key string) error {
+	return no.inner.DeleteContext(ctx, no.prefixed(key))
+}
+
+// GetMany obtains multiple values from the cache at once, unmarshalling
+// each of them into the object reference mapped to its key.
+func (no namespacedOperator) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return no.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	prefixedRefs := make(map[string]interface{}, len(objectRefs))
+	for key, objectRef := range objectRefs {
+		prefixedRefs[no.prefixed(key)] = objectRef
+	}
+
+	result, err := no.inner.GetManyContext(ctx, prefixedRefs)
+	return no.unprefixedResult(result), err
+}
+
+// SetMany sets multiple values into the cache at once, with the same TTL.
+func (no namespacedOperator) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return no.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	prefixedObjects := make(map[string]interface{}, len(objects))
+	for key, object := range objects {
+		prefixedObjects[no.prefixed(key)] = object
+	}
+
+	result, err := no.inner.SetManyContext(ctx, prefixedObjects, TTL)
+	return no.unprefixedResult(result), err
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (no namespacedOperator) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return no.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	prefixedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixedKeys = append(prefixedKeys, no.prefixed(key))
+	}
+
+	result, err := no.inner.DeleteManyContext(ctx, prefixedKeys)
+	return no.unprefixedResult(result), err
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (no namespacedOperator) Exists(key string) (bool, error) {
+	return no.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) ExistsContext(ctx context.Context, key string) (bool, error) {
+	return no.inner.ExistsContext(ctx, no.prefixed(key))
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+func (no namespacedOperator) TTL(key string) (time.Duration, error) {
+	return no.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	return no.inner.TTLContext(ctx, no.prefixed(key))
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+func (no namespacedOperator) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return no.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return no.inner.IncrementContext(ctx, no.prefixed(key), delta, TTL)
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+func (no namespacedOperator) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return no.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return no.inner.DecrementContext(ctx, no.prefixed(key), delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (no namespacedOperator) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return no.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return no.inner.SetIfAbsentContext(ctx, no.prefixed(key), object, TTL)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (no namespacedOperator) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return no.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return no.inner.SetIfPresentContext(ctx, no.prefixed(key), object, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (no namespacedOperator) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return no.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (no namespacedOperator) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return no.inner.GetWithVersionContext(ctx, no.prefixed(key), objectRef)
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (no namespacedOperator) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return no.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (no namespacedOperator) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return no.inner.CompareAndSwapContext(ctx, no.prefixed(key), version, newObject, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+//
+// Tags are namespaced as well, so invalidating a tag never
+// affects the entries of other namespaces.
+func (no namespacedOperator) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return no.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (no namespacedOperator) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	prefixedTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		prefixedTags = append(prefixedTags, no.prefixed(tag))
+	}
+
+	return no.inner.SetWithTagsContext(ctx, no.prefixed(key), object, TTL, prefixedTags...)
+}
+
+// InvalidateTag deletes all the entries of the namespace
+// carrying the given tag.
+func (no namespacedOperator) InvalidateTag(tag string) error {
+	return no.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (no namespacedOperator) InvalidateTagContext(ctx context.Context, tag string) error {
+	return no.inner.InvalidateTagContext(ctx, no.prefixed(tag))
+}
+
+// Scan returns an iterator over the keys of the namespace starting
+// with the given prefix, or over all of them if it is empty.
+//
+// The keys are returned without the namespace prefix.
+func (no namespacedOperator) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return no.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	iterator, err := no.inner.ScanContext(ctx, no.prefixed(prefix))
+	if iterator == nil {
+		return nil, err
+	}
+
+	return &namespacedKeyIterator{
+		KeyIterator: iterator,
+		operator:    no,
+	}, err
+}
+
+// Clear deletes all the entries of the namespace, leaving
+// the ones of the other namespaces untouched.
+func (no namespacedOperator) Clear() error {
+	return no.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (no namespacedOperator) ClearContext(ctx context.Context) error {
+	return no.inner.ClearPrefixContext(ctx, no.prefixed(""))
+}
+
+// ClearPrefix deletes all the entries of the namespace whose key starts
+// with the given prefix, or all of them if it is empty.
+func (no namespacedOperator) ClearPrefix(prefix string) error {
+	return no.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (no namespacedOperator) ClearPrefixContext(ctx context.Context, prefix string) error {
+	return no.inner.ClearPrefixContext(ctx, no.prefixed(prefix))
+}
+
+// prefixed returns the key (or tag) inside the namespace.
+func (no namespacedOperator) prefixed(key string) string {
+	return no.namespace + Separator + key
+}
+
+// unprefixed returns the key (or tag) outside the namespace.
+func (no namespacedOperator) unprefixed(key string) string {
+	return key[len(no.namespace)+len(Separator):]
+}
+
+// unprefixedResult converts the keys of a batch
+// result of the wrapped adapter back.
+func (no namespacedOperator) unprefixedResult(result cacheadapters.BatchResult) cacheadapters.BatchResult {
+	if result == nil {
+		return nil
+	}
+
+	unprefixedResult := make(cacheadapters.BatchResult, len(result))
+	for key, err := range result {
+		unprefixedResult[no.unprefixed(key)] = err
+	}
+
+	return unprefixedResult
+}
+
+// namespacedKeyIterator removes the namespace prefix
+// from the keys returned by the wrapped iterator.
+type namespacedKeyIterator struct {
+	cacheadapters.KeyIterator
+	operator namespacedOperator // The operator which created the iterator.
+}
+
+// Key returns the current key of the iterator,
+// without the namespace prefix.
+func (nki *namespacedKeyIterator) Key() string {
+	key := nki.KeyIterator.Key()
+	if key == "" {
+		return ""
+	}
+
+	return nki.operator.unprefixed(key)
+}
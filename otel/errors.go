@@ -0,0 +1,26 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelcacheadapters
+
+import "fmt"
+
+var (
+	// ErrNilTracerProvider will come out if you try to pass a nil
+	// tracer provider to WithTracerProvider.
+	ErrNilTracerProvider = fmt.Errorf("you must pass a valid tracer provider, not a nil one")
+	// ErrInvalidAdapterName will come out if you try to pass an
+	// empty name to WithAdapterName.
+	ErrInvalidAdapterName = fmt.Errorf("you must pass a non-empty adapter name")
+)
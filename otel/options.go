@@ -0,0 +1,93 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelcacheadapters
+
+import (
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a Tracer when passed to NewTracer, New or NewSession.
+type Option func(*settings) error
+
+// settings contains the configuration of a Tracer.
+type settings struct {
+	tracerProvider trace.TracerProvider // The provider of the tracer creating the spans.
+	adapterName    string               // The name of the adapter, set as the cache.adapter attribute.
+	codec          cacheadapters.Codec  // The codec measuring the size of the values, if any.
+}
+
+// newSettings creates the configuration of a Tracer,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{
+		tracerProvider: otel.GetTracerProvider(),
+	}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithTracerProvider sets the provider of the tracer creating the
+// spans, the global one (otel.GetTracerProvider) is used if not set.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(s *settings) error {
+		if tracerProvider == nil {
+			return ErrNilTracerProvider
+		}
+
+		s.tracerProvider = tracerProvider
+		return nil
+	}
+}
+
+// WithAdapterName sets the name of the adapter, set as the
+// cache.adapter attribute of the spans (e.g. "redis"). The type of
+// the adapter is used if not set.
+func WithAdapterName(name string) Option {
+	return func(s *settings) error {
+		if name == "" {
+			return ErrInvalidAdapterName
+		}
+
+		s.adapterName = name
+		return nil
+	}
+}
+
+// WithCodec sets the codec used to measure the size of the values
+// set as the cache.value_size attribute of the spans.
+//
+// If not set, the size is measured only for cacheadapters.RawMessage
+// values (e.g. the ones moved by a MultiCacheAdapter between its
+// sub-adapters), since measuring the others requires encoding them
+// once more.
+func WithCodec(codec cacheadapters.Codec) Option {
+	return func(s *settings) error {
+		if codec == nil {
+			return cacheadapters.ErrNilCodec
+		}
+
+		s.codec = codec
+		return nil
+	}
+}
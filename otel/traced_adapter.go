@@ -0,0 +1,50 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelcacheadapters
+
+import (
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+)
+
+// New wraps the adapter, and the sessions it opens, creating a
+// span for every operation.
+//
+// To know which sub-adapter of a MultiCacheAdapter served a read,
+// wrap every sub-adapter as well: their spans will be children of
+// the ones of the MultiCacheAdapter.
+//
+//	    redisAdapter, _ := otelcacheadapters.New(redisAdapter, otelcacheadapters.WithAdapterName("redis"))
+//	    mongoAdapter, _ := otelcacheadapters.New(mongoAdapter, otelcacheadapters.WithAdapterName("mongodb"))
+//	    multiCacheAdapter, _ := multicacheadapters.New(redisAdapter, mongoAdapter)
+//	    adapter, _ := otelcacheadapters.New(multiCacheAdapter, otelcacheadapters.WithAdapterName("multicache"))
+func New(adapter cacheadapters.CacheAdapter, opts ...Option) (*interceptorcacheadapters.InterceptedAdapter, error) {
+	tracer, err := newTracer(adapterType(adapter), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return interceptorcacheadapters.New(adapter, tracer.Intercept)
+}
+
+// NewSession wraps the session, creating a span for every operation.
+func NewSession(session cacheadapters.CacheSessionAdapter, opts ...Option) (*interceptorcacheadapters.InterceptedSessionAdapter, error) {
+	tracer, err := newTracer(adapterType(session), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return interceptorcacheadapters.NewSession(session, tracer.Intercept)
+}
@@ -0,0 +1,311 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelcacheadapters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	otelcacheadapters "github.com/tryvium-travels/golang-cache-adapters/otel"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TracedAdapterTestSuite contains all methods to run tests in a
+// isolated suite.
+type TracedAdapterTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL     time.Duration
+	spans          *tracetest.SpanRecorder
+	tracerProvider *sdktrace.TracerProvider
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+// failing is an interceptor failing every operation.
+func failing(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	return testutil.ErrTestingFailureCheck
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return otelcacheadapters.New(inMemoryAdapter)
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := inMemoryAdapter.OpenSession()
+		if err != nil {
+			return nil, err
+		}
+
+		return otelcacheadapters.NewSession(session)
+	}
+}
+
+// newTracedTestSuite creates a new test suite with tests for traced
+// adapters and sessions, backed by an In-Memory adapter.
+func newTracedTestSuite(defaultTTL time.Duration) *TracedAdapterTestSuite {
+	var suite suite.Suite
+
+	return &TracedAdapterTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestTracedAdapterSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newTracedTestSuite(defaultTTL))
+}
+
+func (suite *TracedAdapterTestSuite) SetupTest() {
+	suite.spans = tracetest.NewSpanRecorder()
+	suite.tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.spans))
+}
+
+func (suite *TracedAdapterTestSuite) newTracedAdapter(adapter cacheadapters.CacheAdapter, name string) cacheadapters.CacheAdapter {
+	tracedAdapter, err := otelcacheadapters.New(adapter,
+		otelcacheadapters.WithTracerProvider(suite.tracerProvider),
+		otelcacheadapters.WithAdapterName(name),
+	)
+	suite.Require().NoError(err, "Should not fail to create the traced adapter")
+
+	return tracedAdapter
+}
+
+func (suite *TracedAdapterTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+// attributes returns the attributes of the span, by key.
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	result := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		result[kv.Key] = kv.Value
+	}
+
+	return result
+}
+
+func (suite *TracedAdapterTestSuite) TestNew_NilAdapter() {
+	adapter, err := otelcacheadapters.New(nil)
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+
+	session, err := otelcacheadapters.NewSession(nil)
+	suite.Require().Nil(session, "Should be nil on nil session")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil session")
+}
+
+func (suite *TracedAdapterTestSuite) TestNew_InvalidOptions() {
+	adapter, err := otelcacheadapters.New(suite.newInMemoryAdapter(), otelcacheadapters.WithTracerProvider(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil tracer provider")
+	suite.Require().ErrorIs(err, otelcacheadapters.ErrNilTracerProvider, "Should give error on nil tracer provider")
+
+	adapter, err = otelcacheadapters.New(suite.newInMemoryAdapter(), otelcacheadapters.WithAdapterName(""))
+	suite.Require().Nil(adapter, "Should be nil on empty adapter name")
+	suite.Require().ErrorIs(err, otelcacheadapters.ErrInvalidAdapterName, "Should give error on empty adapter name")
+
+	adapter, err = otelcacheadapters.New(suite.newInMemoryAdapter(), otelcacheadapters.WithCodec(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_Attributes() {
+	adapter, err := otelcacheadapters.New(suite.newInMemoryAdapter(),
+		otelcacheadapters.WithTracerProvider(suite.tracerProvider),
+		otelcacheadapters.WithCodec(cacheadapters.JSONCodec{}),
+	)
+	suite.Require().NoError(err, "Should not error on valid options")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should miss the key never set")
+
+	spans := suite.spans.Ended()
+	suite.Require().Len(spans, 3, "Should create a span for every operation")
+
+	set := attributes(spans[0])
+	suite.Require().Equal("cache.Set", spans[0].Name(), "Should name the span after the operation")
+	suite.Require().Equal("inmemorycacheadapters.InMemoryAdapter", set[otelcacheadapters.AdapterKey].AsString(), "Should use the type of the adapter by default")
+	suite.Require().Equal("Set", set[otelcacheadapters.OperationKey].AsString(), "Should set the operation")
+	suite.Require().Len(set[otelcacheadapters.KeyHashKey].AsString(), 16, "Should set the hash of the key")
+	suite.Require().NotContains(set[otelcacheadapters.KeyHashKey].AsString(), testutil.TestKeyForSet, "Should not expose the key")
+	suite.Require().Equal(int64(len(testutil.TestValueJSON)), set[otelcacheadapters.ValueSizeKey].AsInt64(), "Should set the size of the value written")
+
+	hit := attributes(spans[1])
+	suite.Require().Equal(set[otelcacheadapters.KeyHashKey], hit[otelcacheadapters.KeyHashKey], "Should hash the same key in the same way")
+	suite.Require().True(hit[otelcacheadapters.HitKey].AsBool(), "Should report the hit")
+	suite.Require().Equal(int64(len(testutil.TestValueJSON)), hit[otelcacheadapters.ValueSizeKey].AsInt64(), "Should set the size of the value read")
+	suite.Require().Equal(codes.Unset, spans[1].Status().Code, "Should not report errors on hits")
+
+	miss := attributes(spans[2])
+	suite.Require().False(miss[otelcacheadapters.HitKey].AsBool(), "Should report the miss")
+	suite.Require().Equal(codes.Unset, spans[2].Status().Code, "Should not report misses as errors")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_Error() {
+	brokenAdapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), failing)
+	adapter := suite.newTracedAdapter(brokenAdapter, "broken")
+
+	err := adapter.Delete(testutil.TestKeyForDelete)
+	suite.Require().ErrorIs(err, testutil.ErrTestingFailureCheck, "Should return the error of the operation")
+
+	spans := suite.spans.Ended()
+	suite.Require().Len(spans, 1, "Should create a span for the failed operation")
+	suite.Require().Equal(codes.Error, spans[0].Status().Code, "Should report the error")
+	suite.Require().Equal(testutil.ErrTestingFailureCheck.Error(), spans[0].Status().Description, "Should describe the error")
+	suite.Require().Len(spans[0].Events(), 1, "Should record the error")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_Sessions() {
+	adapter := suite.newTracedAdapter(suite.newInMemoryAdapter(), "in_memory")
+
+	session, err := adapter.OpenSession()
+	suite.Require().NoError(err, "Should not error on valid OpenSession")
+
+	err = session.SetTTL(testutil.TestKeyForSetTTL, time.Second)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not find the key never set")
+
+	err = session.Close()
+	suite.Require().NoError(err, "Should not error on valid Close")
+
+	var names []string
+	for _, span := range suite.spans.Ended() {
+		names = append(names, span.Name())
+	}
+
+	suite.Require().Equal([]string{"cache.OpenSession", "cache.SetTTL", "cache.Close"}, names, "Should trace the session and its operations")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_MultiCacheTiers() {
+	brokenAdapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), failing)
+	workingAdapter := suite.newInMemoryAdapter()
+
+	err := workingAdapter.Set(testutil.TestKeyForGet, cacheadapters.RawMessage(testutil.TestValueJSON), nil)
+	suite.Require().NoError(err, "Should set the value in the working tier")
+
+	multiCacheAdapter, err := multicacheadapters.New(
+		suite.newTracedAdapter(brokenAdapter, "first"),
+		suite.newTracedAdapter(workingAdapter, "second"),
+	)
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+	multiCacheAdapter.EnableWarnings()
+
+	adapter := suite.newTracedAdapter(multiCacheAdapter, "multicache")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn about the failing tier")
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range suite.spans.Ended() {
+		spans[attributes(span)[otelcacheadapters.AdapterKey].AsString()] = span
+	}
+
+	root := spans["multicache"]
+	suite.Require().NotNil(root, "Should trace the multi cache adapter")
+	suite.Require().False(root.Parent().IsValid(), "Should create a root span with the context-free API")
+	suite.Require().True(attributes(root)[otelcacheadapters.WarningKey].AsBool(), "Should report the warning")
+	suite.Require().True(attributes(root)[otelcacheadapters.HitKey].AsBool(), "Should report the hit despite the warning")
+	suite.Require().Equal(codes.Unset, root.Status().Code, "Should not report the warning as an error")
+
+	for _, name := range []string{"first", "second"} {
+		suite.Require().NotNil(spans[name], "Should trace the tier %s", name)
+		suite.Require().Equal(root.SpanContext().SpanID(), spans[name].Parent().SpanID(), "Should create child spans for the tiers")
+		suite.Require().Equal(root.SpanContext().TraceID(), spans[name].SpanContext().TraceID(), "Should create the tier spans in the same trace")
+	}
+
+	suite.Require().Equal(codes.Error, spans["first"].Status().Code, "Should report the error of the failing tier")
+	suite.Require().True(attributes(spans["second"])[otelcacheadapters.HitKey].AsBool(), "Should show the tier serving the read")
+	suite.Require().Equal(int64(len(testutil.TestValueJSON)), attributes(spans["second"])[otelcacheadapters.ValueSizeKey].AsInt64(), "Should measure the raw values of the tiers")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_ParentFromContext() {
+	adapter := suite.newTracedAdapter(suite.newInMemoryAdapter(), "in_memory")
+
+	ctx, parent := suite.tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	err := adapter.SetContext(ctx, testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid SetContext")
+	parent.End()
+
+	spans := suite.spans.Ended()
+	suite.Require().Len(spans, 2, "Should create a span for the operation")
+	suite.Require().Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID(), "Should create a child of the span in the context")
+}
+
+func (suite *TracedAdapterTestSuite) TestSpans_GetMany() {
+	adapter := suite.newTracedAdapter(suite.newInMemoryAdapter(), "in_memory")
+
+	firstKey := testutil.TestKeyForMany + ":1"
+	secondKey := testutil.TestKeyForMany + ":2"
+
+	err := adapter.Set(firstKey, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var first, second testutil.TestStruct
+	_, err = adapter.GetMany(map[string]interface{}{firstKey: &first, secondKey: &second})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+
+	spans := suite.spans.Ended()
+	suite.Require().Len(spans, 2, "Should create a span for every operation")
+
+	getMany := attributes(spans[1])
+	suite.Require().Equal(int64(2), getMany[otelcacheadapters.KeysKey].AsInt64(), "Should set the number of keys")
+	suite.Require().Equal(int64(1), getMany[otelcacheadapters.HitsKey].AsInt64(), "Should set the number of hits")
+	suite.Require().Equal(int64(1), getMany[otelcacheadapters.MissesKey].AsInt64(), "Should set the number of misses")
+}
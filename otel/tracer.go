@@ -0,0 +1,218 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelcacheadapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer creating the spans.
+const instrumentationName = "github.com/tryvium-travels/golang-cache-adapters/otel"
+
+// The attributes of the spans.
+const (
+	AdapterKey   = attribute.Key("cache.adapter")    // The name, or the type, of the adapter.
+	OperationKey = attribute.Key("cache.operation")  // The name of the operation (e.g. "Get").
+	KeyHashKey   = attribute.Key("cache.key_hash")   // The hash of the key of the single-key operations.
+	KeysKey      = attribute.Key("cache.keys")       // The number of keys of the batch operations.
+	HitKey       = attribute.Key("cache.hit")        // Whether Get or GetWithVersion found the value.
+	HitsKey      = attribute.Key("cache.hits")       // The number of values found by GetMany.
+	MissesKey    = attribute.Key("cache.misses")     // The number of values not found by GetMany.
+	ValueSizeKey = attribute.Key("cache.value_size") // The size in bytes of the value read or written.
+	WarningKey   = attribute.Key("cache.warning")    // Whether a MultiCacheAdapter succeeded with a warning.
+)
+
+// Tracer creates a span for every operation
+// passing through its Intercept function.
+type Tracer struct {
+	tracer      trace.Tracer        // The tracer creating the spans.
+	adapterName string              // The name of the adapter, if any.
+	codec       cacheadapters.Codec // The codec measuring the size of the values, if any.
+}
+
+// NewTracer creates a new Tracer.
+//
+// Use New or NewSession instead to trace the operations of an adapter,
+// unless you want to add the Intercept function to your own chain of
+// interceptors.
+func NewTracer(opts ...Option) (*Tracer, error) {
+	return newTracer("", opts)
+}
+
+// newTracer creates a new Tracer, with the given
+// adapter name unless set by the options.
+func newTracer(adapterName string, opts []Option) (*Tracer, error) {
+	settings, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.adapterName != "" {
+		adapterName = settings.adapterName
+	}
+
+	return &Tracer{
+		tracer:      settings.tracerProvider.Tracer(instrumentationName),
+		adapterName: adapterName,
+		codec:       settings.codec,
+	}, nil
+}
+
+// Intercept is an interceptorcacheadapters.Interceptor creating a
+// span for the operation, child of the span in the context if any.
+//
+// The operations performed with the context-free API (e.g. Get
+// instead of GetContext) create a root span, the parent of the spans
+// created by the sub-adapters of a MultiCacheAdapter.
+func (t *Tracer) Intercept(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	ctx, span := t.tracer.Start(ctx, "cache."+string(op.Name),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(t.startAttributes(op)...),
+	)
+	defer span.End()
+
+	err := next(ctx, op)
+	t.record(span, op, err)
+
+	return err
+}
+
+// startAttributes returns the attributes of the span of the
+// operation known before performing it.
+func (t *Tracer) startAttributes(op *interceptorcacheadapters.Operation) []attribute.KeyValue {
+	result := []attribute.KeyValue{OperationKey.String(string(op.Name))}
+
+	if t.adapterName != "" {
+		result = append(result, AdapterKey.String(t.adapterName))
+	}
+
+	if op.Key != "" {
+		result = append(result, KeyHashKey.String(hashKey(op.Key)))
+	}
+
+	if op.Keys != nil {
+		result = append(result, KeysKey.Int(len(op.Keys)))
+	}
+
+	switch op.Name {
+	case interceptorcacheadapters.OperationSet, interceptorcacheadapters.OperationSetIfAbsent,
+		interceptorcacheadapters.OperationSetIfPresent, interceptorcacheadapters.OperationCompareAndSwap,
+		interceptorcacheadapters.OperationSetWithTags:
+		if size, ok := t.valueSize(op.Value); ok {
+			result = append(result, ValueSizeKey.Int(size))
+		}
+	}
+
+	return result
+}
+
+// record adds the outcome of the operation to its span.
+func (t *Tracer) record(span trace.Span, op *interceptorcacheadapters.Operation, err error) {
+	if errors.Is(err, multicacheadapters.ErrMultiCacheWarning) {
+		// the operation succeeded, the warning
+		// reports the failures of some sub-adapters.
+		span.SetAttributes(WarningKey.Bool(true))
+		span.RecordError(err)
+		err = nil
+	}
+
+	switch op.Name {
+	case interceptorcacheadapters.OperationGet, interceptorcacheadapters.OperationGetWithVersion:
+		if err == nil || errors.Is(err, cacheadapters.ErrNotFound) {
+			span.SetAttributes(HitKey.Bool(err == nil))
+		}
+
+		if err == nil {
+			if size, ok := t.valueSize(op.Value); ok {
+				span.SetAttributes(ValueSizeKey.Int(size))
+			}
+		}
+	case interceptorcacheadapters.OperationGetMany:
+		var hits, misses int
+		for _, keyErr := range op.BatchResult {
+			switch {
+			case keyErr == nil:
+				hits++
+			case errors.Is(keyErr, cacheadapters.ErrNotFound):
+				misses++
+			}
+		}
+
+		span.SetAttributes(HitsKey.Int(hits), MissesKey.Int(misses))
+	}
+
+	if isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// valueSize returns the size in bytes of the value, if it can be
+// measured without encoding it or the Tracer has a codec.
+func (t *Tracer) valueSize(value interface{}) (int, bool) {
+	switch raw := value.(type) {
+	case cacheadapters.RawMessage:
+		return len(raw), true
+	case *cacheadapters.RawMessage:
+		if raw != nil {
+			return len(*raw), true
+		}
+	}
+
+	if t.codec == nil || value == nil {
+		return 0, false
+	}
+
+	content, err := cacheadapters.Marshal(t.codec, value)
+	if err != nil {
+		return 0, false
+	}
+
+	return len(content), true
+}
+
+// hashKey returns the hash of the key, so that the keys (which may
+// contain personal data) do not end up in the traces, while the spans
+// of the same key can still be found.
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:8])
+}
+
+// adapterType returns the type of the adapter, used as
+// its name if not set with WithAdapterName.
+func adapterType(adapter interface{}) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", adapter), "*")
+}
+
+// isFailure checks if the error reports a failure of the operation,
+// rather than one of its expected outcomes, like a miss.
+func isFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, cacheadapters.ErrNotFound) &&
+		!errors.Is(err, cacheadapters.ErrVersionConflict)
+}
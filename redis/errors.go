@@ -5,4 +5,12 @@ import "fmt"
 var (
 	//ErrInvalidConnection will come out if you try to use an invalid connection in a session.
 	ErrInvalidConnection = fmt.Errorf("cannot use an invalid connection")
+
+	// ErrInvalidInternalKeyPrefix will come out if you try to set an
+	// empty prefix for the internal keys, or one containing braces.
+	ErrInvalidInternalKeyPrefix = fmt.Errorf("the prefix of the internal keys cannot be empty or contain braces")
+
+	// ErrClearNotScoped will come out if you try to Clear an adapter
+	// without declaring the keys it owns with WithOwnedKeyPrefix.
+	ErrClearNotScoped = fmt.Errorf("cannot clear the keys of the adapter without an owned key prefix")
 )
@@ -0,0 +1,102 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscacheadapters
+
+import (
+	"strings"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
+
+// Option configures a RedisAdapter or a RedisSessionAdapter when passed
+// to New or NewSession.
+type Option func(*settings) error
+
+// defaultInternalKeyPrefix is the prefix of the keys storing
+// the versions and the tags, if not set with WithInternalKeyPrefix.
+const defaultInternalKeyPrefix = "cacheadapters:"
+
+// settings contains the configuration of the Redis adapters.
+type settings struct {
+	codec             cacheadapters.Codec // The codec of the values in cache.
+	internalKeyPrefix string              // The prefix of the keys storing the versions and the tags.
+	ownedKeyPrefix    string              // The prefix of the keys deleted by Clear.
+	ownsKeys          bool                // Whether the keys deleted by Clear have been declared.
+}
+
+// newSettings creates the configuration of the Redis adapters,
+// applying the given options over the default ones.
+func newSettings(opts []Option) (*settings, error) {
+	result := &settings{
+		codec:             cacheadapters.JSONCodec{},
+		internalKeyPrefix: defaultInternalKeyPrefix,
+	}
+
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithCodec sets the codec used to encode the values in cache,
+// cacheadapters.JSONCodec is used if not set.
+func WithCodec(codec cacheadapters.Codec) Option {
+	return func(s *settings) error {
+		if codec == nil {
+			return cacheadapters.ErrNilCodec
+		}
+
+		s.codec = codec
+		return nil
+	}
+}
+
+// WithInternalKeyPrefix sets the prefix of the keys used by the adapter
+// to store the versions of the values and the tags, "cacheadapters:"
+// is used if not set.
+//
+// Use a different prefix for every application sharing the same Redis
+// database, so that their tags do not collide. The keys starting with
+// the prefix are never returned by Scan. The prefix cannot contain braces,
+// which would change the hash slot of the keys in Redis Cluster.
+func WithInternalKeyPrefix(prefix string) Option {
+	return func(s *settings) error {
+		if prefix == "" || strings.ContainsAny(prefix, "{}") {
+			return ErrInvalidInternalKeyPrefix
+		}
+
+		s.internalKeyPrefix = prefix
+		return nil
+	}
+}
+
+// WithOwnedKeyPrefix declares that the keys starting with the given
+// prefix belong to the adapter, so that Clear deletes them. Pass an
+// empty prefix only if the whole Redis database belongs to the adapter.
+//
+// Clear returns ErrClearNotScoped if this option is not set, since
+// the keys written by the adapter cannot be told apart from the ones
+// of other applications sharing the same Redis database.
+func WithOwnedKeyPrefix(prefix string) Option {
+	return func(s *settings) error {
+		s.ownedKeyPrefix = prefix
+		s.ownsKeys = true
+		return nil
+	}
+}
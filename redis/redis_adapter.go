@@ -15,6 +15,7 @@
 package rediscacheadapters
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -24,12 +25,17 @@ import (
 
 // RedisAdapter is the CacheAdapter implementation for Redis.
 type RedisAdapter struct {
-	pool       *redis.Pool   // The Redis pool used to create connections.
-	defaultTTL time.Duration // The defaultTTL of the Set operations.
+	pool              *redis.Pool         // The Redis pool used to create connections.
+	defaultTTL        time.Duration       // The defaultTTL of the Set operations.
+	codec             cacheadapters.Codec // The codec of the values in cache.
+	internalKeyPrefix string              // The prefix of the keys storing the versions and the tags.
+	ownedKeyPrefix    string              // The prefix of the keys deleted by Clear.
+	ownsKeys          bool                // Whether the keys deleted by Clear have been declared.
 }
 
-// New creates a new RedisAdapter from an initialized Redis pool.
-func New(pool *redis.Pool, defaultTTL time.Duration) (cacheadapters.CacheAdapter, error) {
+// New creates a new RedisAdapter from an initialized Redis pool,
+// configured with the given options.
+func New(pool *redis.Pool, defaultTTL time.Duration, opts ...Option) (cacheadapters.CacheAdapter, error) {
 	if pool == nil {
 		return nil, fmt.Errorf("the Redis Pool cannot be nil")
 	}
@@ -38,68 +44,448 @@ func New(pool *redis.Pool, defaultTTL time.Duration) (cacheadapters.CacheAdapter
 		return nil, cacheadapters.ErrInvalidTTL
 	}
 
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RedisAdapter{
-		pool:       pool,
-		defaultTTL: defaultTTL,
+		pool:              pool,
+		defaultTTL:        defaultTTL,
+		codec:             config.codec,
+		internalKeyPrefix: config.internalKeyPrefix,
+		ownedKeyPrefix:    config.ownedKeyPrefix,
+		ownsKeys:          config.ownsKeys,
 	}, nil
 }
 
 // OpenSession opens a new Cache Session.
 func (ra *RedisAdapter) OpenSession() (cacheadapters.CacheSessionAdapter, error) {
-	conn, err := ra.pool.Dial()
+	return ra.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext opens a new Cache Session, honoring the
+// cancellation and the deadline of the given context.
+//
+// The context is used to dial the connection when the pool
+// provides a DialContext function.
+func (ra *RedisAdapter) OpenSessionContext(ctx context.Context) (cacheadapters.CacheSessionAdapter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var conn redis.Conn
+	var err error
+	if ra.pool.DialContext != nil {
+		conn, err = ra.pool.DialContext(ctx)
+	} else {
+		conn, err = ra.pool.Dial()
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return NewSession(conn, ra.defaultTTL)
+	opts := []Option{WithCodec(ra.codec), WithInternalKeyPrefix(ra.internalKeyPrefix)}
+	if ra.ownsKeys {
+		opts = append(opts, WithOwnedKeyPrefix(ra.ownedKeyPrefix))
+	}
+
+	return NewSession(conn, ra.defaultTTL, opts...)
 }
 
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (ra *RedisAdapter) Get(key string, objectRef interface{}) error {
-	rsa, err := ra.OpenSession()
+	return ra.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	rsa, err := ra.OpenSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	defer rsa.Close()
 
-	return rsa.Get(key, objectRef)
+	return rsa.GetContext(ctx, key, objectRef)
 }
 
 // Set sets a value represented by the object parameter into the cache, with the specified key.
 func (ra *RedisAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
-	rsa, err := ra.OpenSession()
+	return ra.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
+	rsa, err := ra.OpenSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	defer rsa.Close()
 
-	return rsa.Set(key, object, TTL)
+	return rsa.SetContext(ctx, key, object, TTL)
 }
 
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (ra *RedisAdapter) SetTTL(key string, newTTL time.Duration) error {
-	rsa, err := ra.OpenSession()
+	return ra.SetTTLContext(context.Background(), key, newTTL)
+}
+
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
+	rsa, err := ra.OpenSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	defer rsa.Close()
 
-	return rsa.SetTTL(key, newTTL)
+	return rsa.SetTTLContext(ctx, key, newTTL)
 }
 
 // Delete deletes a key from the cache.
 func (ra *RedisAdapter) Delete(key string) error {
-	rsa, err := ra.OpenSession()
+	return ra.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) DeleteContext(ctx context.Context, key string) error {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rsa.Close()
+
+	return rsa.DeleteContext(ctx, key)
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+//
+// A single session is used for all the keys.
+func (ra *RedisAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return ra.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.GetManyContext(ctx, objectRefs)
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+//
+// A single session is used for all the keys.
+func (ra *RedisAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return ra.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.SetManyContext(ctx, objects, TTL)
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+//
+// A single session is used for all the keys.
+func (ra *RedisAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return ra.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.DeleteManyContext(ctx, keys)
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (ra *RedisAdapter) Exists(key string) (bool, error) {
+	return ra.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.ExistsContext(ctx, key)
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+//
+// Keys stored without an expiration report cacheadapters.TTLInfinite.
+func (ra *RedisAdapter) TTL(key string) (time.Duration, error) {
+	return ra.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.TTLContext(ctx, key)
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// If the key is not present or expired, the counter starts from
+// zero and the given TTL (or the default one if nil) is applied,
+// otherwise the TTL of the counter is left untouched.
+func (ra *RedisAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ra.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.IncrementContext(ctx, key, delta, TTL)
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (ra *RedisAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return ra.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.DecrementContext(ctx, key, delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (ra *RedisAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ra.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.SetIfAbsentContext(ctx, key, object, TTL)
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (ra *RedisAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return ra.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer rsa.Close()
+
+	return rsa.SetIfPresentContext(ctx, key, object, TTL)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+func (ra *RedisAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return ra.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (ra *RedisAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer rsa.Close()
+
+	return rsa.GetWithVersionContext(ctx, key, objectRef)
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+func (ra *RedisAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return ra.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (ra *RedisAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rsa.Close()
+
+	return rsa.CompareAndSwapContext(ctx, key, version, newObject, TTL)
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+func (ra *RedisAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return ra.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (ra *RedisAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rsa.Close()
+
+	return rsa.SetWithTagsContext(ctx, key, object, TTL, tags...)
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+func (ra *RedisAdapter) InvalidateTag(tag string) error {
+	return ra.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+func (ra *RedisAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rsa.Close()
+
+	return rsa.InvalidateTagContext(ctx, tag)
+}
+
+// Scan returns an iterator over the keys of the cache starting
+// with the given prefix, or over all the keys if it is empty.
+//
+// The iterator holds a connection until it is closed.
+func (ra *RedisAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return ra.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRedisKeyIterator(ctx, rsa.(*RedisSessionAdapter), prefix, true), nil
+}
+
+// Clear deletes the keys starting with the prefix set with
+// WithOwnedKeyPrefix, returns ErrClearNotScoped if it is not set.
+func (ra *RedisAdapter) Clear() error {
+	return ra.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (ra *RedisAdapter) ClearContext(ctx context.Context) error {
+	rsa, err := ra.OpenSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rsa.Close()
+
+	return rsa.ClearContext(ctx)
+}
+
+// ClearPrefix deletes all the keys starting with the given prefix,
+// or all the keys of the Redis database in use if it is empty,
+// removing them from the sets of their tags.
+func (ra *RedisAdapter) ClearPrefix(prefix string) error {
+	return ra.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (ra *RedisAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	rsa, err := ra.OpenSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	defer rsa.Close()
 
-	return rsa.Delete(key)
+	return rsa.ClearPrefixContext(ctx, prefix)
 }
@@ -15,6 +15,7 @@
 package rediscacheadapters_test
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -36,7 +37,7 @@ type RedisAdapterTestSuite struct {
 
 func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
 	return func() (cacheadapters.CacheAdapter, error) {
-		return rediscacheadapters.New(testRedisPool, defaultTTL)
+		return rediscacheadapters.New(testRedisPool, defaultTTL, rediscacheadapters.WithOwnedKeyPrefix(""))
 	}
 }
 
@@ -125,3 +126,261 @@ func (suite *RedisAdapterTestSuite) TestDelete_InvalidPool() {
 	err = adapter.Delete(testutil.TestKeyForDelete)
 	suite.Require().Error(err, "Should error since the pool is invalid")
 }
+
+func (suite *RedisAdapterTestSuite) TestTTL_NoExpiration() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := localRedisServer.Set(testutil.TestKeyForExists, "1")
+	suite.Require().NoError(err, "Must not error on setting test var")
+
+	remainingTTL, err := adapter.TTL(testutil.TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on a key without expiration")
+	suite.Require().Equal(cacheadapters.TTLInfinite, remainingTTL, "Should report an infinite TTL")
+}
+
+func (suite *RedisAdapterTestSuite) TestExists_InvalidPool() {
+	adapter, _ := rediscacheadapters.New(invalidRedisPool, time.Second)
+
+	_, err := adapter.Exists(testutil.TestKeyForExists)
+	suite.Require().Error(err, "Should error since the pool is invalid")
+}
+
+func (suite *RedisAdapterTestSuite) TestNew_NilCodec() {
+	adapter, err := rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithCodec(nil))
+	suite.Require().Nil(adapter, "Should be nil on nil codec")
+	suite.Require().ErrorIs(err, cacheadapters.ErrNilCodec, "Should give error on nil codec")
+}
+
+func (suite *RedisAdapterTestSuite) TestNew_WithCodec() {
+	codec := cacheadapters.GobCodec{}
+
+	adapter, err := rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithCodec(codec))
+	suite.Require().NoError(err, "Should not give error on valid codec")
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should encode the value with the codec")
+
+	expectedContent, err := codec.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should encode the value")
+
+	actualContent, err := localRedisServer.Get(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should find the value in Redis")
+	suite.Require().Equal(string(expectedContent), actualContent, "Should store the value encoded by the codec")
+
+	session, err := adapter.OpenSession()
+	suite.Require().NoError(err, "Should open a session")
+	defer session.Close()
+
+	var actual testutil.TestStruct
+	err = session.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should decode the value with the codec of the adapter")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+}
+
+func (suite *RedisAdapterTestSuite) TestCompareAndSwap_ValueWithoutVersion() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	content, err := json.Marshal(testutil.TestValue)
+	suite.Require().NoError(err, "Should encode the value")
+
+	err = localRedisServer.Set(testutil.TestKeyForVersion, string(content))
+	suite.Require().NoError(err, "Must not error on setting test var")
+
+	var actual testutil.TestStruct
+	version, err := adapter.GetWithVersion(testutil.TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on a value without version key")
+	suite.Require().Equal(testutil.TestValue, actual, "Should decode the value")
+
+	err = adapter.CompareAndSwap(testutil.TestKeyForVersion, version, testutil.TestStruct{Value: "2"}, nil)
+	suite.Require().NoError(err, "Should swap a value without version key")
+
+	newVersion, err := adapter.GetWithVersion(testutil.TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+	suite.Require().Equal(testutil.TestStruct{Value: "2"}, actual, "The value must be the swapped one")
+	suite.Require().NotEqual(version, newVersion, "The version must change after a swap")
+}
+
+// TestCompareAndSwap_SameValueWrittenAgain replaces the one of the common
+// suite: the values written by Set are versioned by their content, so only
+// the values written again by CompareAndSwap get a different version.
+func (suite *RedisAdapterTestSuite) TestCompareAndSwap_SameValueWrittenAgain() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := adapter.Set(testutil.TestKeyForVersion, testutil.TestStruct{Value: "2"}, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual testutil.TestStruct
+	version, err := adapter.GetWithVersion(testutil.TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	for _, value := range []string{"3", "2"} {
+		currentVersion, err := adapter.GetWithVersion(testutil.TestKeyForVersion, &actual)
+		suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+		err = adapter.CompareAndSwap(testutil.TestKeyForVersion, currentVersion, testutil.TestStruct{Value: value}, nil)
+		suite.Require().NoError(err, "Should not error on valid CompareAndSwap")
+	}
+
+	err = adapter.CompareAndSwap(testutil.TestKeyForVersion, version, testutil.TestValue, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a value written again with the same content")
+}
+
+func (suite *RedisAdapterTestSuite) TestSet_WithoutInternalKeys() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	key := testutil.TestKeyForSet + ":plain"
+	err := adapter.Set(key, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual testutil.TestStruct
+	_, err = adapter.GetWithVersion(key, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	for _, storedKey := range localRedisServer.Keys() {
+		if storedKey != key {
+			suite.Require().NotContains(storedKey, key, "Should store only the value")
+		}
+	}
+}
+
+func (suite *RedisAdapterTestSuite) TestSetWithTags_HashTaggedKeys() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	for key, metaKey := range map[string]string{
+		testutil.TestKeyForTags:              "cacheadapters:meta:{" + testutil.TestKeyForTags + "}",
+		"tags:{user:1}:profile":              "cacheadapters:meta:tags:{user:1}:profile",
+		"tags:{}:" + testutil.TestKeyForTags: "cacheadapters:meta:tags:{}:" + testutil.TestKeyForTags,
+	} {
+		err := adapter.SetWithTags(key, testutil.TestValue, nil, "slots")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+		suite.Require().True(localRedisServer.Exists(metaKey), "Should store the tags in the hash slot of the key")
+	}
+}
+
+func (suite *RedisAdapterTestSuite) TestScan_HidesInternalKeys() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := adapter.SetWithTags(testutil.TestKeyForScan, testutil.TestValue, nil, "scanned")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	suite.Require().True(localRedisServer.Exists("cacheadapters:meta:{"+testutil.TestKeyForScan+"}"), "Should store the tags of the value")
+
+	iterator, err := adapter.Scan("")
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	for iterator.Next() {
+		suite.Require().NotContains(iterator.Key(), "cacheadapters:", "Should not return the internal keys")
+	}
+
+	suite.Require().NoError(iterator.Err(), "Should not error during the iteration")
+}
+
+func (suite *RedisAdapterTestSuite) TestSetTTL_ExtendsTagSets() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := adapter.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, "ttl")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.SetTTL(testutil.TestKeyForTags, time.Hour)
+	suite.Require().NoError(err, "Should not error on valid SetTTL")
+	suite.Require().Equal(time.Hour, localRedisServer.TTL("cacheadapters:tag:ttl"), "The set of the tag should not expire before its keys")
+}
+
+func (suite *RedisAdapterTestSuite) TestDelete_RemovesKeysFromTagSets() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	firstKey, secondKey := testutil.TestKeyForTags+":first", testutil.TestKeyForTags+":second"
+	for _, key := range []string{firstKey, secondKey} {
+		err := adapter.SetWithTags(key, testutil.TestValue, nil, "deleted")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err := adapter.Delete(firstKey)
+	suite.Require().NoError(err, "Should not error on valid Delete")
+
+	members, err := localRedisServer.Members("cacheadapters:tag:deleted")
+	suite.Require().NoError(err, "Should find the set of the tag")
+	suite.Require().Equal([]string{secondKey}, members, "Should remove the deleted key from the set of the tag")
+
+	_, err = adapter.DeleteMany([]string{secondKey})
+	suite.Require().NoError(err, "Should not error on valid DeleteMany")
+	suite.Require().False(localRedisServer.Exists("cacheadapters:tag:deleted"), "Should drop the empty set of the tag")
+	suite.Require().False(localRedisServer.Exists("cacheadapters:meta:{"+secondKey+"}"), "Should drop the tags of the deleted key")
+}
+
+func (suite *RedisAdapterTestSuite) TestSetWithTags_SetWithoutTagsDetachesThem() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := adapter.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, "replaced")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.Set(testutil.TestKeyForTags, testutil.TestStruct{Value: "2"}, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = adapter.InvalidateTag("replaced")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+
+	exists, err := adapter.Exists(testutil.TestKeyForTags)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+	suite.Require().True(exists, "Should not delete a key written again without the tag")
+}
+
+func (suite *RedisAdapterTestSuite) TestNew_InternalKeyPrefix() {
+	adapter, err := rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithInternalKeyPrefix(""))
+	suite.Require().Nil(adapter, "Should be nil on empty internal key prefix")
+	suite.Require().ErrorIs(err, rediscacheadapters.ErrInvalidInternalKeyPrefix, "Should give error on empty internal key prefix")
+
+	adapter, err = rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithInternalKeyPrefix("{app}:"))
+	suite.Require().Nil(adapter, "Should be nil on internal key prefix with braces")
+	suite.Require().ErrorIs(err, rediscacheadapters.ErrInvalidInternalKeyPrefix, "Should give error on internal key prefix with braces")
+
+	adapter, err = rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithInternalKeyPrefix("app:cache:"))
+	suite.Require().NoError(err, "Should not error on valid internal key prefix")
+
+	err = adapter.SetWithTags(testutil.TestKeyForTags, testutil.TestValue, nil, "prefixed")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	suite.Require().True(localRedisServer.Exists("app:cache:tag:prefixed"), "Should store the set of the tag with the prefix")
+	suite.Require().True(localRedisServer.Exists("app:cache:meta:{"+testutil.TestKeyForTags+"}"), "Should store the tags of the value with the prefix")
+
+	err = adapter.InvalidateTag("prefixed")
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+
+	exists, err := adapter.Exists(testutil.TestKeyForTags)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+	suite.Require().False(exists, "Should delete the keys of the tag")
+}
+
+func (suite *RedisAdapterTestSuite) TestClear_NotScoped() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second)
+
+	err := localRedisServer.Set(testutil.TestKeyForClear, "1")
+	suite.Require().NoError(err, "Must not error on setting test var")
+
+	err = adapter.Clear()
+	suite.Require().ErrorIs(err, rediscacheadapters.ErrClearNotScoped, "Should not clear without an owned key prefix")
+	suite.Require().True(localRedisServer.Exists(testutil.TestKeyForClear), "Should not delete any key")
+}
+
+func (suite *RedisAdapterTestSuite) TestClear_OwnedKeyPrefix() {
+	adapter, _ := rediscacheadapters.New(testRedisPool, time.Second, rediscacheadapters.WithOwnedKeyPrefix("owned:"))
+
+	ownedKey, otherKey := "owned:"+testutil.TestKeyForClear, "other:"+testutil.TestKeyForClear
+	for _, key := range []string{ownedKey, otherKey} {
+		err := adapter.SetWithTags(key, testutil.TestValue, nil, "cleared")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	_, err := localRedisServer.Lpush("owned:list", "1")
+	suite.Require().NoError(err, "Must not error on setting test var")
+
+	err = adapter.Clear()
+	suite.Require().NoError(err, "Should not error on valid Clear")
+	suite.Require().False(localRedisServer.Exists(ownedKey), "Should delete the owned keys")
+	suite.Require().False(localRedisServer.Exists("owned:list"), "Should delete the owned keys of any type")
+	suite.Require().False(localRedisServer.Exists("cacheadapters:meta:{"+ownedKey+"}"), "Should delete the tags of the owned keys")
+	suite.Require().True(localRedisServer.Exists(otherKey), "Should not delete the keys of other applications")
+
+	members, err := localRedisServer.Members("cacheadapters:tag:cleared")
+	suite.Require().NoError(err, "Should find the set of the tag")
+	suite.Require().Equal([]string{otherKey}, members, "Should remove the cleared keys from the sets of their tags")
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscacheadapters
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// scanCount is the number of keys suggested
+// to Redis for every SCAN command.
+const scanCount = 100
+
+// scanPatternEscaper escapes the characters having
+// a special meaning in the SCAN MATCH patterns.
+var scanPatternEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`?`, `\?`,
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// redisKeyIterator is the KeyIterator implementation for Redis,
+// which fetches the keys in pages using the SCAN command.
+type redisKeyIterator struct {
+	ctx          context.Context      // The context of the Scan operation.
+	session      *RedisSessionAdapter // The session used to send the SCAN commands.
+	closeSession bool                 // Whether the session must be closed with the iterator.
+	pattern      string               // The MATCH pattern of the SCAN commands.
+	cursor       int64                // The cursor of the next SCAN command.
+	done         bool                 // Whether the last page has been fetched.
+	keys         []string             // The keys of the current page not returned yet.
+	key          string               // The current key.
+	err          error                // The error occurred during the iteration.
+}
+
+// newRedisKeyIterator creates a new iterator over the
+// keys starting with the prefix, using the session.
+func newRedisKeyIterator(ctx context.Context, session *RedisSessionAdapter, prefix string, closeSession bool) *redisKeyIterator {
+	return &redisKeyIterator{
+		ctx:          ctx,
+		session:      session,
+		closeSession: closeSession,
+		pattern:      scanPattern(prefix),
+	}
+}
+
+// Next advances the iterator to the next key, returns false
+// when there are no more keys or an error occurred.
+func (rki *redisKeyIterator) Next() bool {
+	for len(rki.keys) == 0 {
+		if rki.err != nil || rki.done {
+			rki.key = ""
+			return false
+		}
+
+		rki.fetch()
+	}
+
+	rki.key, rki.keys = rki.keys[0], rki.keys[1:]
+	return true
+}
+
+// fetch sends the next SCAN command, skipping
+// the internal keys of the adapter.
+func (rki *redisKeyIterator) fetch() {
+	reply, err := redis.Values(rki.session.do(rki.ctx, "SCAN", rki.cursor, "MATCH", rki.pattern, "COUNT", scanCount))
+	if err != nil {
+		rki.err = err
+		return
+	}
+
+	var keys []string
+	_, err = redis.Scan(reply, &rki.cursor, &keys)
+	if err != nil {
+		rki.err = err
+		return
+	}
+
+	rki.done = rki.cursor == 0
+	for _, key := range keys {
+		if !strings.HasPrefix(key, rki.session.internalKeyPrefix) {
+			rki.keys = append(rki.keys, key)
+		}
+	}
+}
+
+// Key returns the current key of the iterator.
+func (rki *redisKeyIterator) Key() string {
+	return rki.key
+}
+
+// Err returns the error occurred during the iteration, if any.
+func (rki *redisKeyIterator) Err() error {
+	return rki.err
+}
+
+// Close stops the iteration, closing the session
+// if it has been opened by the Scan operation.
+func (rki *redisKeyIterator) Close() error {
+	rki.done = true
+	rki.keys = nil
+
+	if !rki.closeSession {
+		return nil
+	}
+
+	rki.closeSession = false
+	return rki.session.Close()
+}
+
+// scanPattern returns the SCAN MATCH pattern
+// of the keys starting with the prefix.
+func scanPattern(prefix string) string {
+	return scanPatternEscaper.Replace(prefix) + "*"
+}
@@ -15,7 +15,10 @@
 package rediscacheadapters
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,17 +28,182 @@ import (
 
 type RedisCommandFunc func(commandName string, args ...interface{})
 
+// metaFunctions are the Lua functions shared by the scripts handling the
+// metadata of a value, stored in a hash (KEYS[2]) in the same hash slot of
+// the value (KEYS[1]). The hash holds the SHA-1 hash of the value it refers
+// to, so a value written again with a plain SET, which leaves the hash
+// behind, is not described by it anymore.
+//
+// storedString returns the value only if it is a string, so that the
+// scripts replacing or deleting the keys work on keys of any type.
+const metaFunctions = `
+local function storedString()
+	local content = redis.pcall("GET", KEYS[1])
+	if type(content) == "table" then
+		return false
+	end
+	return content
+end
+
+local function validMeta(content)
+	if not content then
+		return false
+	end
+	return redis.call("HGET", KEYS[2], "content") == redis.sha1hex(content)
+end
+
+local function metaTags(valid)
+	local tags = {}
+	if valid then
+		for _, field in ipairs(redis.call("HKEYS", KEYS[2])) do
+			if string.sub(field, 1, 4) == "tag:" then
+				table.insert(tags, string.sub(field, 5))
+			end
+		end
+	end
+	return tags
+end
+
+local function currentVersion(content, valid)
+	if valid then
+		local version = redis.call("HGET", KEYS[2], "version")
+		if version then
+			return version
+		end
+	end
+	return redis.sha1hex(content)
+end
+`
+
+// getWithVersionScript returns the value and its version, which is
+// the one written by CompareAndSwap or the SHA-1 hash of the value.
+var getWithVersionScript = redis.NewScript(2, metaFunctions+`
+local content = redis.call("GET", KEYS[1])
+if not content then
+	return false
+end
+return {content, currentVersion(content, validMeta(content))}
+`)
+
+// setWithTagsScript sets a value together with its tags, returning the
+// tags of the previous value, whose sets must not contain the key anymore.
+var setWithTagsScript = redis.NewScript(2, metaFunctions+`
+local tags = metaTags(validMeta(storedString()))
+redis.call("PSETEX", KEYS[1], ARGV[1], ARGV[2])
+redis.call("DEL", KEYS[2])
+redis.call("HSET", KEYS[2], "content", redis.sha1hex(ARGV[2]))
+for i = 3, #ARGV do
+	redis.call("HSET", KEYS[2], "tag:" .. ARGV[i], "")
+end
+redis.call("PEXPIRE", KEYS[2], ARGV[1])
+return tags
+`)
+
+// compareAndSwapScript sets a value and its new version only if the
+// current version matches the expected one, returning the detached tags
+// of the previous value, or nil if the versions do not match.
+var compareAndSwapScript = redis.NewScript(2, metaFunctions+`
+local content = redis.call("GET", KEYS[1])
+if not content then
+	return false
+end
+local valid = validMeta(content)
+if currentVersion(content, valid) ~= ARGV[4] then
+	return false
+end
+local tags = metaTags(valid)
+redis.call("PSETEX", KEYS[1], ARGV[1], ARGV[2])
+redis.call("DEL", KEYS[2])
+redis.call("HSET", KEYS[2], "content", redis.sha1hex(ARGV[2]), "version", ARGV[3])
+redis.call("PEXPIRE", KEYS[2], ARGV[1])
+return tags
+`)
+
+// incrementScript atomically increments a counter, applying the TTL
+// only when the counter is created. The tags of the counter are kept,
+// while the version written by CompareAndSwap is dropped.
+var incrementScript = redis.NewScript(2, metaFunctions+`
+local content = redis.call("GET", KEYS[1])
+local valid = validMeta(content)
+local counter = redis.call("INCRBY", KEYS[1], ARGV[1])
+if not content then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if valid then
+	redis.call("HSET", KEYS[2], "content", redis.sha1hex(redis.call("GET", KEYS[1])))
+	redis.call("HDEL", KEYS[2], "version")
+end
+return counter
+`)
+
+// setTTLScript changes the TTL of a value and of its metadata,
+// returning the tags of the value, whose sets must be extended.
+var setTTLScript = redis.NewScript(2, metaFunctions+`
+if redis.call("PEXPIRE", KEYS[1], ARGV[1]) == 0 then
+	return {}
+end
+local valid = validMeta(storedString())
+if valid then
+	redis.call("PEXPIRE", KEYS[2], ARGV[1])
+end
+return metaTags(valid)
+`)
+
+// deleteScript deletes a value together with its metadata, returning
+// its tags, whose sets must not contain the key anymore.
+var deleteScript = redis.NewScript(2, metaFunctions+`
+local tags = metaTags(validMeta(storedString()))
+redis.call("DEL", KEYS[1], KEYS[2])
+return tags
+`)
+
+// invalidateScript deletes a value together with its metadata if it
+// still carries the tag, returning its tags, or nil if it does not.
+var invalidateScript = redis.NewScript(2, metaFunctions+`
+local valid = validMeta(storedString())
+if not valid or redis.call("HEXISTS", KEYS[2], "tag:" .. ARGV[1]) == 0 then
+	return false
+end
+local tags = metaTags(valid)
+redis.call("DEL", KEYS[1], KEYS[2])
+return tags
+`)
+
+// addTagScript adds a key to the set of a tag, extending
+// the TTL of the set so that it does not expire before the key.
+var addTagScript = redis.NewScript(1, `
+redis.call("SADD", KEYS[1], ARGV[1])
+if redis.call("PTTL", KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return redis.status_reply("OK")
+`)
+
+// extendTagScript extends the TTL of the set of a tag,
+// so that it does not expire before the given one.
+var extendTagScript = redis.NewScript(1, `
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl >= 0 and ttl < tonumber(ARGV[1]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return redis.status_reply("OK")
+`)
+
 // RedisSessionAdapter is the CacheSessionAdapter implementation
 // for Redis.
 type RedisSessionAdapter struct {
-	conn       redis.Conn    // The redis connection used to connect.
-	defaultTTL time.Duration // The defaultTTL of the Set operations.
-	mutex      *sync.Mutex   // mutex to handle transactions.
+	conn              redis.Conn          // The redis connection used to connect.
+	defaultTTL        time.Duration       // The defaultTTL of the Set operations.
+	codec             cacheadapters.Codec // The codec of the values in cache.
+	internalKeyPrefix string              // The prefix of the keys storing the versions and the tags.
+	ownedKeyPrefix    string              // The prefix of the keys deleted by Clear.
+	ownsKeys          bool                // Whether the keys deleted by Clear have been declared.
+	mutex             *sync.Mutex         // mutex to handle pipelines.
 }
 
 // NewSession creates a new Redis Cache Session adapter from
-// an existing Redis connection.
-func NewSession(conn redis.Conn, defaultTTL time.Duration) (cacheadapters.CacheSessionAdapter, error) {
+// an existing Redis connection, configured with the given options.
+func NewSession(conn redis.Conn, defaultTTL time.Duration, opts ...Option) (cacheadapters.CacheSessionAdapter, error) {
 	if conn == nil {
 		return nil, ErrInvalidConnection
 	}
@@ -44,17 +212,32 @@ func NewSession(conn redis.Conn, defaultTTL time.Duration) (cacheadapters.CacheS
 		return nil, cacheadapters.ErrInvalidTTL
 	}
 
+	config, err := newSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RedisSessionAdapter{
-		conn:       conn,
-		defaultTTL: defaultTTL,
-		mutex:      &sync.Mutex{},
+		conn:              conn,
+		defaultTTL:        defaultTTL,
+		codec:             config.codec,
+		internalKeyPrefix: config.internalKeyPrefix,
+		ownedKeyPrefix:    config.ownedKeyPrefix,
+		ownsKeys:          config.ownsKeys,
+		mutex:             &sync.Mutex{},
 	}, nil
 }
 
 // Get obtains a value from the cache using a key, then tries to unmarshal
 // it into the object reference passed as parameter.
 func (rsa *RedisSessionAdapter) Get(key string, objectRef interface{}) error {
-	resultContent, err := redis.Bytes(rsa.conn.Do("GET", key))
+	return rsa.GetContext(context.Background(), key, objectRef)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) GetContext(ctx context.Context, key string, objectRef interface{}) error {
+	resultContent, err := redis.Bytes(rsa.do(ctx, "GET", key))
 	if err == redis.ErrNil {
 		return cacheadapters.ErrNotFound
 	}
@@ -67,16 +250,54 @@ func (rsa *RedisSessionAdapter) Get(key string, objectRef interface{}) error {
 		return cacheadapters.ErrGetRequiresObjectReference
 	}
 
-	err = json.Unmarshal(resultContent, objectRef)
+	return cacheadapters.Unmarshal(rsa.codec, resultContent, objectRef)
+}
+
+// GetWithVersion is the same as Get, but also returns the
+// version of the value, to be used in CompareAndSwap operations.
+//
+// The version of a value written by CompareAndSwap is a random token,
+// stored in a hash next to the value, while the version of a value
+// written in any other way is the SHA-1 hash of its content: writing
+// again the same content with Set is not seen as a change.
+func (rsa *RedisSessionAdapter) GetWithVersion(key string, objectRef interface{}) (cacheadapters.Version, error) {
+	return rsa.GetWithVersionContext(context.Background(), key, objectRef)
+}
+
+// GetWithVersionContext is the same as GetWithVersion, but honors the
+// cancellation and the deadline of the given context.
+func (rsa *RedisSessionAdapter) GetWithVersionContext(ctx context.Context, key string, objectRef interface{}) (cacheadapters.Version, error) {
+	// The value and its version are read with a single
+	// script, so they are always consistent.
+	resultContents, err := redis.ByteSlices(rsa.eval(ctx, getWithVersionScript, key, rsa.metaKey(key)))
+	if err == redis.ErrNil {
+		return "", cacheadapters.ErrNotFound
+	}
+
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	if objectRef == nil {
+		return "", cacheadapters.ErrGetRequiresObjectReference
+	}
+
+	err = cacheadapters.Unmarshal(rsa.codec, resultContents[0], objectRef)
+	if err != nil {
+		return "", err
+	}
+
+	return cacheadapters.Version(resultContents[1]), nil
 }
 
 // Set sets a value represented by the object parameter into the cache, with the specified key.
 func (rsa *RedisSessionAdapter) Set(key string, object interface{}, TTL *time.Duration) error {
+	return rsa.SetContext(context.Background(), key, object, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) SetContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) error {
 	if TTL == nil {
 		TTL = new(time.Duration)
 		*TTL = rsa.defaultTTL
@@ -84,39 +305,779 @@ func (rsa *RedisSessionAdapter) Set(key string, object interface{}, TTL *time.Du
 		return cacheadapters.ErrInvalidTTL
 	}
 
-	objectContent, err := json.Marshal(object)
+	objectContent, err := cacheadapters.Marshal(rsa.codec, object)
 	if err != nil {
 		return err
 	}
 
-	_, err = rsa.conn.Do("PSETEX", key, (*TTL).Milliseconds(), objectContent)
+	_, err = rsa.do(ctx, "PSETEX", key, (*TTL).Milliseconds(), objectContent)
+	return err
+}
+
+// SetWithTags is the same as Set, but also attaches the given tags
+// to the entry, so that it can be removed using InvalidateTag.
+//
+// The tags of a key are stored in a hash next to the value, and
+// the keys carrying each tag in a Redis set, which expires no
+// earlier than the keys it contains. The tags belong to the value:
+// writing the key again replaces them (unless it is written again
+// with Set and the same content), while Increment, Decrement and
+// SetTTL keep them.
+//
+// The sets of the tags are updated after the value, with separate
+// commands, so that every command touches a single hash slot.
+func (rsa *RedisSessionAdapter) SetWithTags(key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	return rsa.SetWithTagsContext(context.Background(), key, object, TTL, tags...)
+}
+
+// SetWithTagsContext is the same as SetWithTags, but honors the
+// cancellation and the deadline of the given context.
+func (rsa *RedisSessionAdapter) SetWithTagsContext(ctx context.Context, key string, object interface{}, TTL *time.Duration, tags ...string) error {
+	if TTL == nil {
+		TTL = new(time.Duration)
+		*TTL = rsa.defaultTTL
+	} else if *TTL <= 0 {
+		return cacheadapters.ErrInvalidTTL
+	}
+
+	if len(tags) == 0 {
+		return rsa.SetContext(ctx, key, object, TTL)
+	}
+
+	objectContent, err := cacheadapters.Marshal(rsa.codec, object)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	keysAndArgs := redis.Args{key, rsa.metaKey(key), (*TTL).Milliseconds(), objectContent}.AddFlat(tags)
+	oldTags, err := redis.Strings(rsa.eval(ctx, setWithTagsScript, keysAndArgs...))
+	if err != nil {
+		return err
+	}
+
+	return rsa.updateTags(ctx, key, oldTags, tags, *TTL)
 }
 
 // SetTTL marks the specified key new expiration, deletes it via using
 // cacheadapters.TTLExpired or negative duration.
 func (rsa *RedisSessionAdapter) SetTTL(key string, newTTL time.Duration) error {
-	var err error
+	return rsa.SetTTLContext(context.Background(), key, newTTL)
+}
 
+// SetTTLContext is the same as SetTTL, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) SetTTLContext(ctx context.Context, key string, newTTL time.Duration) error {
 	if newTTL > cacheadapters.TTLExpired {
-		_, err = rsa.conn.Do("PEXPIRE", key, newTTL.Milliseconds())
-		return err
+		tags, err := redis.Strings(rsa.eval(ctx, setTTLScript, key, rsa.metaKey(key), newTTL.Milliseconds()))
+		if err != nil {
+			return err
+		}
+
+		return rsa.extendTags(ctx, tags, newTTL)
 	} else {
-		return rsa.Delete(key)
+		return rsa.DeleteContext(ctx, key)
 	}
 }
 
 // Delete deletes a key from the cache.
 func (rsa *RedisSessionAdapter) Delete(key string) error {
-	_, err := rsa.conn.Do("DEL", key)
-	return err
+	return rsa.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is the same as Delete, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) DeleteContext(ctx context.Context, key string) error {
+	tags, err := redis.Strings(rsa.eval(ctx, deleteScript, key, rsa.metaKey(key)))
+	if err != nil {
+		return err
+	}
+
+	return rsa.untag(ctx, map[string][]string{key: tags})
+}
+
+// GetMany obtains multiple values from the cache at once, then tries
+// to unmarshal each of them into the object reference mapped to its key.
+func (rsa *RedisSessionAdapter) GetMany(objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	return rsa.GetManyContext(context.Background(), objectRefs)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the values are obtained with a single MGET command.
+func (rsa *RedisSessionAdapter) GetManyContext(ctx context.Context, objectRefs map[string]interface{}) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(objectRefs))
+	if len(objectRefs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]interface{}, 0, len(objectRefs))
+	for key := range objectRefs {
+		keys = append(keys, key)
+	}
+
+	resultContents, err := redis.ByteSlices(rsa.do(ctx, "MGET", keys...))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, resultContent := range resultContents {
+		key := keys[i].(string)
+		objectRef := objectRefs[key]
+
+		if resultContent == nil {
+			result[key] = cacheadapters.ErrNotFound
+			continue
+		}
+
+		if objectRef == nil {
+			result[key] = cacheadapters.ErrGetRequiresObjectReference
+			continue
+		}
+
+		result[key] = cacheadapters.Unmarshal(rsa.codec, resultContent, objectRef)
+	}
+
+	return result, nil
+}
+
+// SetMany sets multiple values into the cache at once, each one with
+// the key it is mapped to and all of them with the same TTL.
+func (rsa *RedisSessionAdapter) SetMany(objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	return rsa.SetManyContext(context.Background(), objects, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the values are set with pipelined PSETEX commands.
+func (rsa *RedisSessionAdapter) SetManyContext(ctx context.Context, objects map[string]interface{}, TTL *time.Duration) (cacheadapters.BatchResult, error) {
+	if TTL == nil {
+		TTL = new(time.Duration)
+		*TTL = rsa.defaultTTL
+	} else if *TTL <= 0 {
+		return nil, cacheadapters.ErrInvalidTTL
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(cacheadapters.BatchResult, len(objects))
+	sentKeys := make([]string, 0, len(objects))
+
+	replies, err := rsa.pipeline(ctx, func(conn redis.Conn) error {
+		for key, object := range objects {
+			objectContent, err := cacheadapters.Marshal(rsa.codec, object)
+			result[key] = err
+			if err != nil {
+				continue
+			}
+
+			err = conn.Send("PSETEX", key, (*TTL).Milliseconds(), objectContent)
+			if err != nil {
+				return err
+			}
+
+			sentKeys = append(sentKeys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, reply := range replies {
+		if replyErr, ok := reply.(redis.Error); ok {
+			result[sentKeys[i]] = replyErr
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteMany deletes multiple keys from the cache at once.
+func (rsa *RedisSessionAdapter) DeleteMany(keys []string) (cacheadapters.BatchResult, error) {
+	return rsa.DeleteManyContext(context.Background(), keys)
+}
+
+// DeleteManyContext is the same as DeleteMany, but honors the cancellation
+// and the deadline of the given context.
+//
+// All the keys are deleted with pipelined scripts.
+func (rsa *RedisSessionAdapter) DeleteManyContext(ctx context.Context, keys []string) (cacheadapters.BatchResult, error) {
+	result := make(cacheadapters.BatchResult, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	keyTags, err := rsa.deleteAll(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		result[key] = nil
+	}
+
+	return result, rsa.untag(ctx, keyTags)
+}
+
+// Exists checks if a key is present in the cache and not expired,
+// without obtaining its value.
+func (rsa *RedisSessionAdapter) Exists(key string) (bool, error) {
+	return rsa.ExistsContext(context.Background(), key)
+}
+
+// ExistsContext is the same as Exists, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) ExistsContext(ctx context.Context, key string) (bool, error) {
+	count, err := redis.Int(rsa.do(ctx, "EXISTS", key))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// TTL obtains the remaining time to live of a key, returns
+// cacheadapters.ErrNotFound if the key is not present in the
+// cache or expired.
+//
+// Keys stored without an expiration report cacheadapters.TTLInfinite.
+func (rsa *RedisSessionAdapter) TTL(key string) (time.Duration, error) {
+	return rsa.TTLContext(context.Background(), key)
+}
+
+// TTLContext is the same as TTL, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	remainingMillis, err := redis.Int64(rsa.do(ctx, "PTTL", key))
+	if err != nil {
+		return 0, err
+	}
+
+	// PTTL returns -2 when the key does not exist and -1 when
+	// the key exists but has no expiration associated.
+	switch {
+	case remainingMillis == -1:
+		return cacheadapters.TTLInfinite, nil
+	case remainingMillis < 0:
+		return 0, cacheadapters.ErrNotFound
+	}
+
+	return time.Duration(remainingMillis) * time.Millisecond, nil
+}
+
+// Increment atomically adds delta to the integer counter stored
+// in the key, returning the new value.
+//
+// If the key is not present or expired, the counter starts from
+// zero and the given TTL (or the default one if nil) is applied,
+// otherwise the TTL of the counter is left untouched.
+func (rsa *RedisSessionAdapter) Increment(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return rsa.IncrementContext(context.Background(), key, delta, TTL)
+}
+
+// IncrementContext is the same as Increment, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) IncrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	if TTL == nil {
+		TTL = &rsa.defaultTTL
+	} else if *TTL <= 0 {
+		return 0, cacheadapters.ErrInvalidTTL
+	}
+
+	counter, err := redis.Int64(rsa.eval(ctx, incrementScript, key, rsa.metaKey(key), delta, (*TTL).Milliseconds()))
+	if redisErr, ok := err.(redis.Error); ok && strings.Contains(redisErr.Error(), "not an integer") {
+		return 0, cacheadapters.ErrInvalidCounter
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return counter, nil
+}
+
+// Decrement atomically subtracts delta from the integer counter
+// stored in the key, returning the new value.
+//
+// It follows the same rules of Increment.
+func (rsa *RedisSessionAdapter) Decrement(key string, delta int64, TTL *time.Duration) (int64, error) {
+	return rsa.DecrementContext(context.Background(), key, delta, TTL)
+}
+
+// DecrementContext is the same as Decrement, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) DecrementContext(ctx context.Context, key string, delta int64, TTL *time.Duration) (int64, error) {
+	return rsa.IncrementContext(ctx, key, -delta, TTL)
+}
+
+// SetIfAbsent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is not present
+// or expired. Returns whether the value has been written.
+func (rsa *RedisSessionAdapter) SetIfAbsent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return rsa.SetIfAbsentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfAbsentContext is the same as SetIfAbsent, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) SetIfAbsentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return rsa.setIf(ctx, key, object, TTL, "NX")
+}
+
+// SetIfPresent sets a value represented by the object parameter into
+// the cache, with the specified key, only if the key is present and
+// not expired. Returns whether the value has been written.
+func (rsa *RedisSessionAdapter) SetIfPresent(key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return rsa.SetIfPresentContext(context.Background(), key, object, TTL)
+}
+
+// SetIfPresentContext is the same as SetIfPresent, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) SetIfPresentContext(ctx context.Context, key string, object interface{}, TTL *time.Duration) (bool, error) {
+	return rsa.setIf(ctx, key, object, TTL, "XX")
+}
+
+// setIf sets the value only if the given
+// condition, either NX or XX, is met.
+func (rsa *RedisSessionAdapter) setIf(ctx context.Context, key string, object interface{}, TTL *time.Duration, condition string) (bool, error) {
+	if TTL == nil {
+		TTL = &rsa.defaultTTL
+	} else if *TTL <= 0 {
+		return false, cacheadapters.ErrInvalidTTL
+	}
+
+	objectContent, err := cacheadapters.Marshal(rsa.codec, object)
+	if err != nil {
+		return false, err
+	}
+
+	reply, err := rsa.do(ctx, "SET", key, objectContent, "PX", (*TTL).Milliseconds(), condition)
+	if err != nil {
+		return false, err
+	}
+
+	return reply != nil, nil
+}
+
+// CompareAndSwap sets a value represented by the newObject parameter
+// into the cache, with the specified key, only if the value stored
+// still has the given version, otherwise returns
+// cacheadapters.ErrVersionConflict.
+//
+// The check and the write are performed atomically by a Lua script.
+func (rsa *RedisSessionAdapter) CompareAndSwap(key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	return rsa.CompareAndSwapContext(context.Background(), key, version, newObject, TTL)
+}
+
+// CompareAndSwapContext is the same as CompareAndSwap, but honors the
+// cancellation and the deadline of the given context.
+func (rsa *RedisSessionAdapter) CompareAndSwapContext(ctx context.Context, key string, version cacheadapters.Version, newObject interface{}, TTL *time.Duration) error {
+	if TTL == nil {
+		TTL = &rsa.defaultTTL
+	} else if *TTL <= 0 {
+		return cacheadapters.ErrInvalidTTL
+	}
+
+	objectContent, err := cacheadapters.Marshal(rsa.codec, newObject)
+	if err != nil {
+		return err
+	}
+
+	nextVersion, err := newVersion()
+	if err != nil {
+		return err
+	}
+
+	oldTags, err := redis.Strings(rsa.eval(ctx, compareAndSwapScript, key, rsa.metaKey(key), (*TTL).Milliseconds(), objectContent, nextVersion, string(version)))
+	if err == redis.ErrNil {
+		return cacheadapters.ErrVersionConflict
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return rsa.untag(ctx, map[string][]string{key: oldTags})
+}
+
+// InvalidateTag deletes all the entries carrying the given tag.
+//
+// Keys which have been written again without the tag,
+// after being set with it, are not deleted.
+func (rsa *RedisSessionAdapter) InvalidateTag(tag string) error {
+	return rsa.InvalidateTagContext(context.Background(), tag)
+}
+
+// InvalidateTagContext is the same as InvalidateTag, but honors the
+// cancellation and the deadline of the given context.
+//
+// The keys are checked and deleted one at a time with pipelined
+// scripts, so that every script touches a single hash slot.
+func (rsa *RedisSessionAdapter) InvalidateTagContext(ctx context.Context, tag string) error {
+	keys, err := redis.Strings(rsa.do(ctx, "SMEMBERS", rsa.tagKey(tag)))
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	replies, err := rsa.pipeline(ctx, func(conn redis.Conn) error {
+		for _, key := range keys {
+			err := invalidateScript.Send(conn, key, rsa.metaKey(key), tag)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The keys not carrying the tag anymore are removed
+	// from its set too, together with the invalidated ones.
+	keyTags := make(map[string][]string, len(keys))
+	for i, reply := range replies {
+		if replyErr, ok := reply.(redis.Error); ok {
+			return replyErr
+		}
+
+		tags, _ := redis.Strings(reply, nil)
+		keyTags[keys[i]] = append(tags, tag)
+	}
+
+	return rsa.untag(ctx, keyTags)
+}
+
+// Scan returns an iterator over the keys of the cache starting
+// with the given prefix, or over all the keys if it is empty.
+//
+// The keys are fetched using the SCAN command, so Redis
+// may return the same key more than once.
+func (rsa *RedisSessionAdapter) Scan(prefix string) (cacheadapters.KeyIterator, error) {
+	return rsa.ScanContext(context.Background(), prefix)
+}
+
+// ScanContext is the same as Scan, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) ScanContext(ctx context.Context, prefix string) (cacheadapters.KeyIterator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newRedisKeyIterator(ctx, rsa, prefix, false), nil
+}
+
+// Clear deletes the keys starting with the prefix set with
+// WithOwnedKeyPrefix, as ClearPrefix does. Returns ErrClearNotScoped
+// if it is not set, since the keys of other applications sharing the
+// same Redis database would be deleted as well.
+func (rsa *RedisSessionAdapter) Clear() error {
+	return rsa.ClearContext(context.Background())
+}
+
+// ClearContext is the same as Clear, but honors the cancellation
+// and the deadline of the given context.
+func (rsa *RedisSessionAdapter) ClearContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !rsa.ownsKeys {
+		return ErrClearNotScoped
+	}
+
+	return rsa.ClearPrefixContext(ctx, rsa.ownedKeyPrefix)
+}
+
+// ClearPrefix deletes all the keys starting with the given prefix,
+// or all the keys of the Redis database in use if it is empty,
+// removing them from the sets of their tags.
+//
+// The keys are found with the SCAN command and deleted one page
+// at a time with pipelined scripts, so Redis is never blocked.
+func (rsa *RedisSessionAdapter) ClearPrefix(prefix string) error {
+	return rsa.ClearPrefixContext(context.Background(), prefix)
+}
+
+// ClearPrefixContext is the same as ClearPrefix, but honors the
+// cancellation and the deadline of the given context.
+func (rsa *RedisSessionAdapter) ClearPrefixContext(ctx context.Context, prefix string) error {
+	var cursor int64
+	for {
+		reply, err := redis.Values(rsa.do(ctx, "SCAN", cursor, "MATCH", scanPattern(prefix), "COUNT", scanCount))
+		if err != nil {
+			return err
+		}
+
+		var keys []string
+		_, err = redis.Scan(reply, &cursor, &keys)
+		if err != nil {
+			return err
+		}
+
+		err = rsa.clearKeys(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
 }
 
 // Close closes the Cache Session.
 func (rsa *RedisSessionAdapter) Close() error {
 	return rsa.conn.Close()
 }
+
+// do sends a command to Redis using the session connection.
+//
+// The context deadline and cancellation are honored when the connection
+// supports them, otherwise the context is only checked before sending
+// the command.
+func (rsa *RedisSessionAdapter) do(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := rsa.conn.(redis.ConnWithContext); ok {
+		return redis.DoContext(rsa.conn, ctx, commandName, args...)
+	}
+
+	return rsa.conn.Do(commandName, args...)
+}
+
+// eval runs a Lua script on Redis using the session connection,
+// following the same context rules of do.
+func (rsa *RedisSessionAdapter) eval(ctx context.Context, script *redis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := rsa.conn.(redis.ConnWithContext); ok {
+		return script.DoContext(ctx, rsa.conn, keysAndArgs...)
+	}
+
+	return script.Do(rsa.conn, keysAndArgs...)
+}
+
+// flush sends the pipelined commands and returns their replies.
+//
+// Unlike do, it never returns before writing the commands, so
+// they are not left pending on the connection.
+func (rsa *RedisSessionAdapter) flush(ctx context.Context) (interface{}, error) {
+	if _, ok := rsa.conn.(redis.ConnWithContext); ok {
+		return redis.DoContext(rsa.conn, ctx, "")
+	}
+
+	return rsa.conn.Do("")
+}
+
+// pipeline sends the commands queued by send in a single round
+// trip, returning their replies in order.
+//
+// Unlike do, the replies are read even if the context is done
+// after sending the commands, so they are not left pending on
+// the connection.
+func (rsa *RedisSessionAdapter) pipeline(ctx context.Context, send func(conn redis.Conn) error) ([]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rsa.mutex.Lock()
+	defer rsa.mutex.Unlock()
+
+	err := send(rsa.conn)
+	if err != nil {
+		rsa.flush(ctx)
+		return nil, err
+	}
+
+	replies, err := redis.Values(rsa.flush(ctx))
+	if err == redis.ErrNil {
+		// no command has been sent.
+		return nil, nil
+	}
+
+	return replies, err
+}
+
+// deleteAll deletes the keys together with their metadata
+// with pipelined scripts, returning the tags of every key.
+func (rsa *RedisSessionAdapter) deleteAll(ctx context.Context, keys []string) (map[string][]string, error) {
+	replies, err := rsa.pipeline(ctx, func(conn redis.Conn) error {
+		for _, key := range keys {
+			err := deleteScript.Send(conn, key, rsa.metaKey(key))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyTags := make(map[string][]string, len(keys))
+	for i, reply := range replies {
+		tags, err := redis.Strings(reply, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		keyTags[keys[i]] = tags
+	}
+
+	return keyTags, nil
+}
+
+// updateTags removes the key from the sets of the old tags, then
+// adds it to the sets of the new ones, extending their TTL so that
+// they do not expire before the key.
+func (rsa *RedisSessionAdapter) updateTags(ctx context.Context, key string, oldTags []string, newTags []string, TTL time.Duration) error {
+	kept := make(map[string]bool, len(newTags))
+	for _, tag := range newTags {
+		kept[tag] = true
+	}
+
+	return rsa.sendTagCommands(ctx, func(conn redis.Conn) error {
+		for _, tag := range oldTags {
+			if kept[tag] {
+				continue
+			}
+
+			err := conn.Send("SREM", rsa.tagKey(tag), key)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, tag := range newTags {
+			err := addTagScript.Send(conn, rsa.tagKey(tag), key, TTL.Milliseconds())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// extendTags extends the TTL of the sets of the
+// tags, so that they do not expire before the given one.
+func (rsa *RedisSessionAdapter) extendTags(ctx context.Context, tags []string, TTL time.Duration) error {
+	return rsa.sendTagCommands(ctx, func(conn redis.Conn) error {
+		for _, tag := range tags {
+			err := extendTagScript.Send(conn, rsa.tagKey(tag), TTL.Milliseconds())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// untag removes every key from the sets of its tags.
+func (rsa *RedisSessionAdapter) untag(ctx context.Context, keyTags map[string][]string) error {
+	return rsa.sendTagCommands(ctx, func(conn redis.Conn) error {
+		for key, tags := range keyTags {
+			for _, tag := range tags {
+				err := conn.Send("SREM", rsa.tagKey(tag), key)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// sendTagCommands pipelines the commands updating the sets of the
+// tags, returning the first error replied by Redis, if any.
+func (rsa *RedisSessionAdapter) sendTagCommands(ctx context.Context, send func(conn redis.Conn) error) error {
+	replies, err := rsa.pipeline(ctx, send)
+	if err != nil {
+		return err
+	}
+
+	for _, reply := range replies {
+		if replyErr, ok := reply.(redis.Error); ok {
+			return replyErr
+		}
+	}
+
+	return nil
+}
+
+// clearKeys deletes the keys found by ClearPrefix, together with their
+// metadata, removing them from the sets of their tags. The internal
+// keys are skipped, since they are deleted together with the values.
+func (rsa *RedisSessionAdapter) clearKeys(ctx context.Context, keys []string) error {
+	cleared := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasPrefix(key, rsa.internalKeyPrefix) {
+			cleared = append(cleared, key)
+		}
+	}
+
+	if len(cleared) == 0 {
+		return nil
+	}
+
+	keyTags, err := rsa.deleteAll(ctx, cleared)
+	if err != nil {
+		return err
+	}
+
+	return rsa.untag(ctx, keyTags)
+}
+
+// newVersion generates a random version for a value.
+func newVersion() (string, error) {
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// tagKey returns the key of the set
+// containing the keys of a tag.
+func (rsa *RedisSessionAdapter) tagKey(tag string) string {
+	return rsa.internalKeyPrefix + "tag:" + tag
+}
+
+// metaKey returns the key of the hash containing the version and
+// the tags of a key, in the same hash slot of the key.
+//
+// Keys without a hash tag are wrapped in braces, so that the whole
+// key becomes the hash tag, unless they contain a closing brace,
+// which would end the hash tag early.
+func (rsa *RedisSessionAdapter) metaKey(key string) string {
+	if hasHashTag(key) || strings.Contains(key, "}") {
+		return rsa.internalKeyPrefix + "meta:" + key
+	}
+
+	return rsa.internalKeyPrefix + "meta:{" + key + "}"
+}
+
+// hasHashTag returns whether the key contains a Redis Cluster hash
+// tag, a non-empty substring between the first "{" and the next "}".
+func hasHashTag(key string) bool {
+	start := strings.IndexByte(key, '{')
+	if start < 0 {
+		return false
+	}
+
+	return strings.IndexByte(key[start+1:], '}') > 0
+}
@@ -32,7 +32,7 @@ func newTestSessionFunc(t *testing.T, defaultTTL time.Duration) func() (cacheada
 			t.Error(err)
 		}
 
-		return rediscacheadapters.NewSession(conn, defaultTTL)
+		return rediscacheadapters.NewSession(conn, defaultTTL, rediscacheadapters.WithOwnedKeyPrefix(""))
 	}
 }
 
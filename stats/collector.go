@@ -0,0 +1,231 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+)
+
+// Stats is the snapshot of the statistics of an adapter.
+type Stats struct {
+	Hits     uint64 `json:"hits"`     // The values found by Get, GetMany and GetWithVersion.
+	Misses   uint64 `json:"misses"`   // The values not found (cacheadapters.ErrNotFound) by Get, GetMany and GetWithVersion.
+	Errors   uint64 `json:"errors"`   // The failed operations, misses and version conflicts excluded.
+	Warnings uint64 `json:"warnings"` // The operations of a MultiCacheAdapter succeeded with a warning.
+	Sets     uint64 `json:"sets"`     // The values written by the write operations.
+	Deletes  uint64 `json:"deletes"`  // The keys deleted by Delete and DeleteMany.
+
+	Operations map[interceptorcacheadapters.OperationName]OperationStats `json:"operations"` // The statistics by operation.
+}
+
+// HitRatio returns the ratio of the hits over the hits and the
+// misses, or zero if no value has been read yet.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// OperationStats is the snapshot of the statistics of an operation.
+type OperationStats struct {
+	Calls   uint64    `json:"calls"`   // The number of calls of the operation.
+	Errors  uint64    `json:"errors"`  // The number of failed calls, misses and version conflicts excluded.
+	Latency Histogram `json:"latency"` // The latencies of the calls.
+}
+
+// Collector collects the statistics of the operations
+// passing through its Intercept function.
+//
+// The counters are updated atomically, so that recording the
+// operations of different goroutines does not serialize them.
+type Collector struct {
+	counters   counters                                                       // The counters, except the ones of the operations, first to be 64-bit aligned.
+	mutex      sync.RWMutex                                                   // mutex to handle the operations.
+	operations map[interceptorcacheadapters.OperationName]*operationCollector // The statistics of the operations.
+}
+
+// counters are the counters of a Collector, except the ones of the
+// operations, updated atomically.
+type counters struct {
+	hits     uint64 // The values found.
+	misses   uint64 // The values not found.
+	errors   uint64 // The failed operations.
+	warnings uint64 // The operations succeeded with a warning.
+	sets     uint64 // The values written.
+	deletes  uint64 // The keys deleted.
+}
+
+// operationCollector collects the statistics of an operation,
+// updating its counters atomically.
+type operationCollector struct {
+	calls   uint64     // The number of calls of the operation, first to be 64-bit aligned.
+	errors  uint64     // The number of failed calls.
+	latency *histogram // The latencies of the calls.
+}
+
+// NewCollector creates a new empty Collector.
+//
+// Use Registry.Wrap instead to collect the statistics of an adapter
+// and expose them, unless you want to add the Intercept function to
+// your own chain of interceptors.
+func NewCollector() *Collector {
+	return &Collector{
+		operations: make(map[interceptorcacheadapters.OperationName]*operationCollector),
+	}
+}
+
+// Intercept is an interceptorcacheadapters.Interceptor
+// collecting the statistics of the operation.
+func (c *Collector) Intercept(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	start := time.Now()
+	err := next(ctx, op)
+	c.record(op, err, time.Since(start))
+
+	return err
+}
+
+// Stats returns a snapshot of the statistics.
+//
+// Every counter is read atomically, but not all of them together,
+// so operations completed meanwhile may be counted only in some of them.
+func (c *Collector) Stats() Stats {
+	result := Stats{
+		Hits:     atomic.LoadUint64(&c.counters.hits),
+		Misses:   atomic.LoadUint64(&c.counters.misses),
+		Errors:   atomic.LoadUint64(&c.counters.errors),
+		Warnings: atomic.LoadUint64(&c.counters.warnings),
+		Sets:     atomic.LoadUint64(&c.counters.sets),
+		Deletes:  atomic.LoadUint64(&c.counters.deletes),
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	result.Operations = make(map[interceptorcacheadapters.OperationName]OperationStats, len(c.operations))
+	for name, operation := range c.operations {
+		result.Operations[name] = OperationStats{
+			Calls:   atomic.LoadUint64(&operation.calls),
+			Errors:  atomic.LoadUint64(&operation.errors),
+			Latency: operation.latency.snapshot(),
+		}
+	}
+
+	return result
+}
+
+// operation returns the collector of the operation with
+// the given name, creating it on its first call.
+func (c *Collector) operation(name interceptorcacheadapters.OperationName) *operationCollector {
+	c.mutex.RLock()
+	operation, exists := c.operations[name]
+	c.mutex.RUnlock()
+
+	if exists {
+		return operation
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	operation, exists = c.operations[name]
+	if !exists {
+		operation = &operationCollector{latency: newHistogram()}
+		c.operations[name] = operation
+	}
+
+	return operation
+}
+
+// record adds the outcome of the operation to the statistics.
+func (c *Collector) record(op *interceptorcacheadapters.Operation, err error, latency time.Duration) {
+	operation := c.operation(op.Name)
+
+	atomic.AddUint64(&operation.calls, 1)
+	operation.latency.observe(latency)
+
+	if errors.Is(err, multicacheadapters.ErrMultiCacheWarning) {
+		// the operation succeeded, the warning
+		// reports the failures of some sub-adapters.
+		atomic.AddUint64(&c.counters.warnings, 1)
+		err = nil
+	}
+
+	if isFailure(err) {
+		atomic.AddUint64(&operation.errors, 1)
+		atomic.AddUint64(&c.counters.errors, 1)
+		return
+	}
+
+	switch op.Name {
+	case interceptorcacheadapters.OperationGet, interceptorcacheadapters.OperationGetWithVersion:
+		c.recordRead(err)
+	case interceptorcacheadapters.OperationGetMany:
+		for _, keyErr := range op.BatchResult {
+			c.recordRead(keyErr)
+		}
+	case interceptorcacheadapters.OperationSet, interceptorcacheadapters.OperationSetWithTags, interceptorcacheadapters.OperationCompareAndSwap:
+		if err == nil {
+			atomic.AddUint64(&c.counters.sets, 1)
+		}
+	case interceptorcacheadapters.OperationSetIfAbsent, interceptorcacheadapters.OperationSetIfPresent:
+		if op.Written {
+			atomic.AddUint64(&c.counters.sets, 1)
+		}
+	case interceptorcacheadapters.OperationSetMany:
+		for _, keyErr := range op.BatchResult {
+			if keyErr == nil {
+				atomic.AddUint64(&c.counters.sets, 1)
+			}
+		}
+	case interceptorcacheadapters.OperationDelete:
+		atomic.AddUint64(&c.counters.deletes, 1)
+	case interceptorcacheadapters.OperationDeleteMany:
+		for _, keyErr := range op.BatchResult {
+			if keyErr == nil {
+				atomic.AddUint64(&c.counters.deletes, 1)
+			}
+		}
+	}
+}
+
+// recordRead adds the outcome of the read of a value to the statistics.
+func (c *Collector) recordRead(err error) {
+	switch {
+	case err == nil:
+		atomic.AddUint64(&c.counters.hits, 1)
+	case errors.Is(err, cacheadapters.ErrNotFound):
+		atomic.AddUint64(&c.counters.misses, 1)
+	default:
+		atomic.AddUint64(&c.counters.errors, 1)
+	}
+}
+
+// isFailure checks if the error reports a failure of the operation,
+// rather than one of its expected outcomes, like a miss.
+func isFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, cacheadapters.ErrNotFound) &&
+		!errors.Is(err, cacheadapters.ErrVersionConflict)
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters
+
+import "fmt"
+
+var (
+	// ErrInvalidName will come out if you try to register
+	// statistics with an empty name.
+	ErrInvalidName = fmt.Errorf("you must pass a non-empty name for the statistics")
+	// ErrDuplicateName will come out if you try to register statistics
+	// with a name already used in the same registry.
+	ErrDuplicateName = fmt.Errorf("the name of the statistics is already used in the registry")
+	// ErrAlreadyPublished will come out if you try to publish the
+	// statistics with a name already used by another expvar variable.
+	ErrAlreadyPublished = fmt.Errorf("an expvar variable with the same name is already published")
+)
@@ -0,0 +1,108 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the buckets
+// of the latency histograms.
+var latencyBuckets = []time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+}
+
+// LatencyBuckets returns the upper bounds of the buckets
+// of the latency histograms, by increasing value.
+func LatencyBuckets() []time.Duration {
+	return append([]time.Duration(nil), latencyBuckets...)
+}
+
+// Histogram is the snapshot of a latency histogram.
+type Histogram struct {
+	Buckets []Bucket      `json:"buckets"` // The buckets, by increasing upper bound.
+	Count   uint64        `json:"count"`   // The number of observed latencies.
+	Sum     time.Duration `json:"sum"`     // The sum of the observed latencies.
+}
+
+// Bucket is a bucket of a latency histogram.
+type Bucket struct {
+	UpperBound time.Duration `json:"upper_bound"` // The upper bound (inclusive) of the bucket.
+	Count      uint64        `json:"count"`       // The number of latencies lower or equal to the upper bound.
+}
+
+// histogram collects the latencies in the latencyBuckets,
+// updating its counters atomically.
+type histogram struct {
+	count       uint64          // The number of observed latencies, first to be 64-bit aligned.
+	sum         int64           // The sum of the observed latencies, in nanoseconds.
+	counts      []uint64        // The number of latencies falling in each bucket, not cumulative.
+	upperBounds []time.Duration // The upper bounds of the buckets.
+}
+
+// newHistogram creates a new empty histogram.
+func newHistogram() *histogram {
+	return &histogram{
+		counts:      make([]uint64, len(latencyBuckets)),
+		upperBounds: LatencyBuckets(),
+	}
+}
+
+// observe adds the latency to the histogram.
+func (h *histogram) observe(latency time.Duration) {
+	atomic.AddUint64(&h.count, 1)
+	atomic.AddInt64(&h.sum, int64(latency))
+
+	for i, upperBound := range h.upperBounds {
+		if latency <= upperBound {
+			atomic.AddUint64(&h.counts[i], 1)
+			return
+		}
+	}
+}
+
+// snapshot returns a copy of the histogram, with cumulative buckets.
+//
+// Every counter is read atomically, but not all of them together. The
+// buckets are read before the count, which observe increments first,
+// so that the count is never lower than the last cumulative bucket.
+func (h *histogram) snapshot() Histogram {
+	result := Histogram{
+		Buckets: make([]Bucket, len(h.upperBounds)),
+	}
+
+	var cumulative uint64
+	for i, upperBound := range h.upperBounds {
+		cumulative += atomic.LoadUint64(&h.counts[i])
+		result.Buckets[i] = Bucket{UpperBound: upperBound, Count: cumulative}
+	}
+
+	result.Count = atomic.LoadUint64(&h.count)
+	result.Sum = time.Duration(atomic.LoadInt64(&h.sum))
+
+	return result
+}
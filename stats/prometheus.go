@@ -0,0 +1,157 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+)
+
+// prometheusContentType is the content type of the Prometheus text format.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelEscaper escapes the values of the Prometheus labels.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// counterMetric describes a counter of the Stats.
+type counterMetric struct {
+	name  string             // The name of the metric.
+	help  string             // The description of the metric.
+	value func(Stats) uint64 // The value of the metric in the Stats.
+}
+
+// counterMetrics are the counters of the Stats exposed to Prometheus.
+var counterMetrics = []counterMetric{
+	{"cache_hits_total", "The values found in cache.", func(s Stats) uint64 { return s.Hits }},
+	{"cache_misses_total", "The values not found in cache.", func(s Stats) uint64 { return s.Misses }},
+	{"cache_errors_total", "The failed cache operations.", func(s Stats) uint64 { return s.Errors }},
+	{"cache_warnings_total", "The cache operations succeeded with a warning.", func(s Stats) uint64 { return s.Warnings }},
+	{"cache_sets_total", "The values written in cache.", func(s Stats) uint64 { return s.Sets }},
+	{"cache_deletes_total", "The keys deleted from cache.", func(s Stats) uint64 { return s.Deletes }},
+}
+
+// Handler returns an HTTP handler serving the statistics
+// of the registry in the Prometheus text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+		r.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes the statistics of the registry
+// in the Prometheus text format.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	stats := r.Stats()
+
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writer := bufio.NewWriter(w)
+
+	for _, metric := range counterMetrics {
+		writeHeader(writer, metric.name, metric.help, "counter")
+		for _, name := range names {
+			writeSample(writer, metric.name, labels("adapter", name), strconv.FormatUint(metric.value(stats[name]), 10))
+		}
+	}
+
+	writeHeader(writer, "cache_operations_total", "The cache operations.", "counter")
+	for _, name := range names {
+		for _, operation := range sortedOperations(stats[name]) {
+			writeSample(writer, "cache_operations_total", labels("adapter", name, "operation", string(operation)), strconv.FormatUint(stats[name].Operations[operation].Calls, 10))
+		}
+	}
+
+	writeHeader(writer, "cache_operation_errors_total", "The failed cache operations.", "counter")
+	for _, name := range names {
+		for _, operation := range sortedOperations(stats[name]) {
+			writeSample(writer, "cache_operation_errors_total", labels("adapter", name, "operation", string(operation)), strconv.FormatUint(stats[name].Operations[operation].Errors, 10))
+		}
+	}
+
+	writeHeader(writer, "cache_operation_duration_seconds", "The latency of the cache operations.", "histogram")
+	for _, name := range names {
+		for _, operation := range sortedOperations(stats[name]) {
+			latency := stats[name].Operations[operation].Latency
+			for _, bucket := range latency.Buckets {
+				writeSample(writer, "cache_operation_duration_seconds_bucket", labels("adapter", name, "operation", string(operation), "le", seconds(bucket.UpperBound)), strconv.FormatUint(bucket.Count, 10))
+			}
+
+			writeSample(writer, "cache_operation_duration_seconds_bucket", labels("adapter", name, "operation", string(operation), "le", "+Inf"), strconv.FormatUint(latency.Count, 10))
+			writeSample(writer, "cache_operation_duration_seconds_sum", labels("adapter", name, "operation", string(operation)), seconds(latency.Sum))
+			writeSample(writer, "cache_operation_duration_seconds_count", labels("adapter", name, "operation", string(operation)), strconv.FormatUint(latency.Count, 10))
+		}
+	}
+
+	return writer.Flush()
+}
+
+// sortedOperations returns the names of the operations of the stats, sorted.
+func sortedOperations(stats Stats) []interceptorcacheadapters.OperationName {
+	result := make([]interceptorcacheadapters.OperationName, 0, len(stats.Operations))
+	for operation := range stats.Operations {
+		result = append(result, operation)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric.
+func writeHeader(writer *bufio.Writer, name string, help string, metricType string) {
+	writer.WriteString("# HELP " + name + " " + help + "\n")
+	writer.WriteString("# TYPE " + name + " " + metricType + "\n")
+}
+
+// writeSample writes a sample of a metric.
+func writeSample(writer *bufio.Writer, name string, labels string, value string) {
+	writer.WriteString(name + labels + " " + value + "\n")
+}
+
+// labels formats the label pairs, given as names followed by values.
+func labels(pairs ...string) string {
+	var builder strings.Builder
+
+	builder.WriteString("{")
+	for i := 0; i < len(pairs); i += 2 {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+
+		builder.WriteString(pairs[i] + `="` + labelEscaper.Replace(pairs[i+1]) + `"`)
+	}
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+// seconds formats the duration in seconds.
+func seconds(duration time.Duration) string {
+	return strconv.FormatFloat(duration.Seconds(), 'g', -1, 64)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters
+
+import (
+	"expvar"
+	"sync"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+)
+
+// Registry contains the statistics of many adapters, each one
+// identified by a name, and exposes them together.
+//
+// To break down the statistics of a MultiCacheAdapter by
+// sub-adapter, wrap every sub-adapter as well:
+//
+//	registry := statscacheadapters.NewRegistry()
+//	redisAdapter, _ := registry.Wrap("redis", redisAdapter)
+//	mongoAdapter, _ := registry.Wrap("mongodb", mongoAdapter)
+//	multiCacheAdapter, _ := multicacheadapters.New(redisAdapter, mongoAdapter)
+//	adapter, _ := registry.Wrap("multicache", multiCacheAdapter)
+type Registry struct {
+	mutex      sync.RWMutex
+	collectors map[string]*Collector // The collectors, by name.
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]*Collector),
+	}
+}
+
+// Wrap wraps the adapter, and the sessions it opens, collecting the
+// statistics of their operations in the registry with the given name.
+func (r *Registry) Wrap(name string, adapter cacheadapters.CacheAdapter) (*interceptorcacheadapters.InterceptedAdapter, error) {
+	collector := NewCollector()
+
+	// check the adapter before registering the name.
+	result, err := interceptorcacheadapters.New(adapter, collector.Intercept)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Register(name, collector)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Register adds the collector to the registry with the given name,
+// to expose the statistics of a collector used in your own chain of
+// interceptors.
+func (r *Registry) Register(name string, collector *Collector) error {
+	if name == "" {
+		return ErrInvalidName
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.collectors[name]; exists {
+		return ErrDuplicateName
+	}
+
+	r.collectors[name] = collector
+	return nil
+}
+
+// Stats returns a snapshot of the statistics of every adapter, by name.
+func (r *Registry) Stats() map[string]Stats {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make(map[string]Stats, len(r.collectors))
+	for name, collector := range r.collectors {
+		result[name] = collector.Stats()
+	}
+
+	return result
+}
+
+// publishMutex serializes the publications of the registries, since
+// expvar panics when publishing a name already published.
+var publishMutex sync.Mutex
+
+// Publish publishes the statistics of the registry as an expvar
+// variable with the given name, served as JSON by the expvar handler.
+//
+// Returns ErrAlreadyPublished if the name is already published, even
+// concurrently by another registry.
+func (r *Registry) Publish(name string) error {
+	publishMutex.Lock()
+	defer publishMutex.Unlock()
+
+	if expvar.Get(name) != nil {
+		return ErrAlreadyPublished
+	}
+
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return r.Stats()
+	}))
+
+	return nil
+}
@@ -0,0 +1,388 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscacheadapters_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	interceptorcacheadapters "github.com/tryvium-travels/golang-cache-adapters/interceptor"
+	multicacheadapters "github.com/tryvium-travels/golang-cache-adapters/multicache"
+	statscacheadapters "github.com/tryvium-travels/golang-cache-adapters/stats"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+// StatsTestSuite contains all methods to run tests in a
+// isolated suite.
+type StatsTestSuite struct {
+	*suite.Suite
+	*testutil.CacheAdapterPartialTestSuite
+	defaultTTL time.Duration
+}
+
+func testSleepFunc() func(time.Duration) {
+	return func(duration time.Duration) {
+		time.Sleep(duration)
+	}
+}
+
+// failing is an interceptor failing every operation.
+func failing(ctx context.Context, op *interceptorcacheadapters.Operation, next interceptorcacheadapters.Invoker) error {
+	return testutil.ErrTestingFailureCheck
+}
+
+func newTestAdapterFunc(defaultTTL time.Duration) func() (cacheadapters.CacheAdapter, error) {
+	return func() (cacheadapters.CacheAdapter, error) {
+		inMemoryAdapter, err := inmemorycacheadapters.New(defaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return statscacheadapters.NewRegistry().Wrap("in_memory", inMemoryAdapter)
+	}
+}
+
+func newTestSessionFunc(defaultTTL time.Duration) func() (cacheadapters.CacheSessionAdapter, error) {
+	return func() (cacheadapters.CacheSessionAdapter, error) {
+		adapter, err := newTestAdapterFunc(defaultTTL)()
+		if err != nil {
+			return nil, err
+		}
+
+		return adapter.OpenSession()
+	}
+}
+
+// newStatsTestSuite creates a new test suite with tests for adapters
+// and sessions collecting statistics, backed by an In-Memory adapter.
+func newStatsTestSuite(defaultTTL time.Duration) *StatsTestSuite {
+	var suite suite.Suite
+
+	return &StatsTestSuite{
+		Suite: &suite,
+		CacheAdapterPartialTestSuite: &testutil.CacheAdapterPartialTestSuite{
+			Suite:      &suite,
+			DefaultTTL: defaultTTL,
+			NewAdapter: newTestAdapterFunc(defaultTTL),
+			NewSession: newTestSessionFunc(defaultTTL),
+			SleepFunc:  testSleepFunc(),
+		},
+		defaultTTL: defaultTTL,
+	}
+}
+
+func TestStatsSuite(t *testing.T) {
+	defaultTTL := 1 * time.Second
+	suite.Run(t, newStatsTestSuite(defaultTTL))
+}
+
+func (suite *StatsTestSuite) newInMemoryAdapter() cacheadapters.CacheAdapter {
+	inMemoryAdapter, err := inmemorycacheadapters.New(suite.defaultTTL)
+	suite.Require().NoError(err, "Should not fail to create the in-memory adapter")
+
+	return inMemoryAdapter
+}
+
+func (suite *StatsTestSuite) TestWrap_InvalidName() {
+	adapter, err := statscacheadapters.NewRegistry().Wrap("", suite.newInMemoryAdapter())
+	suite.Require().Nil(adapter, "Should be nil on empty name")
+	suite.Require().ErrorIs(err, statscacheadapters.ErrInvalidName, "Should give error on empty name")
+}
+
+func (suite *StatsTestSuite) TestWrap_DuplicateName() {
+	registry := statscacheadapters.NewRegistry()
+
+	_, err := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+	suite.Require().NoError(err, "Should not error on the first name")
+
+	adapter, err := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+	suite.Require().Nil(adapter, "Should be nil on duplicate name")
+	suite.Require().ErrorIs(err, statscacheadapters.ErrDuplicateName, "Should give error on duplicate name")
+}
+
+func (suite *StatsTestSuite) TestWrap_NilAdapter() {
+	registry := statscacheadapters.NewRegistry()
+
+	adapter, err := registry.Wrap("in_memory", nil)
+	suite.Require().Nil(adapter, "Should be nil on nil adapter")
+	suite.Require().ErrorIs(err, interceptorcacheadapters.ErrNilAdapter, "Should give error on nil adapter")
+	suite.Require().Empty(registry.Stats(), "Should not register the name of a nil adapter")
+}
+
+func (suite *StatsTestSuite) TestStats_HitsMissesAndErrors() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid Get")
+
+	err = adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should miss the key never set")
+
+	err = adapter.Get(testutil.TestKeyForSet, nil)
+	suite.Require().Error(err, "Should error on nil reference")
+
+	err = adapter.Delete(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Delete")
+
+	stats := registry.Stats()["in_memory"]
+	suite.Require().Equal(uint64(1), stats.Hits, "Should count the hits")
+	suite.Require().Equal(uint64(1), stats.Misses, "Should count the misses")
+	suite.Require().Equal(uint64(1), stats.Errors, "Should count the errors, misses excluded")
+	suite.Require().Equal(uint64(1), stats.Sets, "Should count the sets")
+	suite.Require().Equal(uint64(1), stats.Deletes, "Should count the deletes")
+	suite.Require().Equal(0.5, stats.HitRatio(), "Should compute the hit ratio")
+
+	get := stats.Operations[interceptorcacheadapters.OperationGet]
+	suite.Require().Equal(uint64(3), get.Calls, "Should count the calls of the operation")
+	suite.Require().Equal(uint64(1), get.Errors, "Should count the errors of the operation")
+	suite.Require().Equal(uint64(3), get.Latency.Count, "Should observe the latency of every call")
+}
+
+func (suite *StatsTestSuite) TestStats_BatchOperations() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+
+	firstKey := testutil.TestKeyForMany + ":1"
+	secondKey := testutil.TestKeyForMany + ":2"
+
+	_, err := adapter.SetMany(map[string]interface{}{firstKey: testutil.TestValue}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+
+	var first, second testutil.TestStruct
+	_, err = adapter.GetMany(map[string]interface{}{firstKey: &first, secondKey: &second})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+
+	_, err = adapter.DeleteMany([]string{firstKey})
+	suite.Require().NoError(err, "Should not error on valid DeleteMany")
+
+	stats := registry.Stats()["in_memory"]
+	suite.Require().Equal(uint64(1), stats.Hits, "Should count the hits of every key")
+	suite.Require().Equal(uint64(1), stats.Misses, "Should count the misses of every key")
+	suite.Require().Equal(uint64(1), stats.Sets, "Should count the sets of every key")
+	suite.Require().Equal(uint64(1), stats.Deletes, "Should count the deletes of every key")
+}
+
+func (suite *StatsTestSuite) TestStats_ConditionalSets() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+
+	written, err := adapter.SetIfAbsent(testutil.TestKeyForSetIf, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().True(written, "Should write the absent key")
+
+	written, err = adapter.SetIfAbsent(testutil.TestKeyForSetIf, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().False(written, "Should not write the present key")
+
+	stats := registry.Stats()["in_memory"]
+	suite.Require().Equal(uint64(1), stats.Sets, "Should count only the written values")
+}
+
+func (suite *StatsTestSuite) TestStats_Sessions() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+
+	session, err := adapter.OpenSession()
+	suite.Require().NoError(err, "Should not error on valid OpenSession")
+
+	err = session.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = session.Close()
+	suite.Require().NoError(err, "Should not error on valid Close")
+
+	stats := registry.Stats()["in_memory"]
+	suite.Require().Equal(uint64(1), stats.Sets, "Should count the operations of the sessions")
+	suite.Require().Contains(stats.Operations, interceptorcacheadapters.OperationOpenSession, "Should count the opened sessions")
+	suite.Require().Contains(stats.Operations, interceptorcacheadapters.OperationClose, "Should count the closed sessions")
+}
+
+func (suite *StatsTestSuite) TestStats_MultiCacheBreakdown() {
+	registry := statscacheadapters.NewRegistry()
+
+	brokenAdapter, _ := interceptorcacheadapters.New(suite.newInMemoryAdapter(), failing)
+	first, _ := registry.Wrap("first", brokenAdapter)
+	second, _ := registry.Wrap("second", suite.newInMemoryAdapter())
+
+	multiCacheAdapter, err := multicacheadapters.New(first, second)
+	suite.Require().NoError(err, "Should create the multi cache adapter")
+	multiCacheAdapter.EnableWarnings()
+
+	adapter, _ := registry.Wrap("multicache", multiCacheAdapter)
+
+	err = adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn about the failing sub-adapter")
+
+	var actual testutil.TestStruct
+	err = adapter.Get(testutil.TestKeyForSet, &actual)
+	suite.Require().ErrorIs(err, multicacheadapters.ErrMultiCacheWarning, "Should warn about the failing sub-adapter")
+	suite.Require().Equal(testutil.TestValue, actual, "Should read the value from the working sub-adapter")
+
+	stats := registry.Stats()
+	suite.Require().Equal(uint64(2), stats["first"].Errors, "Should count the errors of the failing sub-adapter")
+	suite.Require().Equal(uint64(0), stats["first"].Hits, "Should not count hits for the failing sub-adapter")
+	suite.Require().Equal(uint64(1), stats["second"].Hits, "Should count the hits of the working sub-adapter")
+	suite.Require().Equal(uint64(1), stats["second"].Sets, "Should count the sets of the working sub-adapter")
+	suite.Require().Equal(uint64(2), stats["multicache"].Warnings, "Should count the warnings of the multi cache adapter")
+	suite.Require().Equal(uint64(0), stats["multicache"].Errors, "Should not count the warnings as errors")
+	suite.Require().Equal(uint64(1), stats["multicache"].Hits, "Should count the hits of the multi cache adapter")
+}
+
+func (suite *StatsTestSuite) TestCollector_Latency() {
+	collector := statscacheadapters.NewCollector()
+
+	slow := func(ctx context.Context, op *interceptorcacheadapters.Operation) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+
+	op := &interceptorcacheadapters.Operation{Name: interceptorcacheadapters.OperationSet}
+	err := collector.Intercept(context.Background(), op, slow)
+	suite.Require().NoError(err, "Should return the error of the operation")
+
+	latency := collector.Stats().Operations[interceptorcacheadapters.OperationSet].Latency
+	suite.Require().Equal(uint64(1), latency.Count, "Should observe the latency")
+	suite.Require().GreaterOrEqual(latency.Sum, 5*time.Millisecond, "Should sum the latencies")
+	suite.Require().Len(latency.Buckets, len(statscacheadapters.LatencyBuckets()), "Should have a bucket for every upper bound")
+
+	for _, bucket := range latency.Buckets {
+		if bucket.UpperBound < 5*time.Millisecond {
+			suite.Require().Equal(uint64(0), bucket.Count, "Should not count the latency in the lower buckets")
+		}
+	}
+
+	suite.Require().Equal(uint64(1), latency.Buckets[len(latency.Buckets)-1].Count, "Should have cumulative buckets")
+}
+
+func (suite *StatsTestSuite) TestLatencyBuckets_Copy() {
+	buckets := statscacheadapters.LatencyBuckets()
+	buckets[0] = time.Hour
+
+	suite.Require().NotEqual(time.Hour, statscacheadapters.LatencyBuckets()[0], "Should not change the upper bounds of the histograms")
+
+	collector := statscacheadapters.NewCollector()
+	op := &interceptorcacheadapters.Operation{Name: interceptorcacheadapters.OperationSet}
+	collector.Intercept(context.Background(), op, func(ctx context.Context, op *interceptorcacheadapters.Operation) error {
+		return nil
+	})
+
+	latency := collector.Stats().Operations[interceptorcacheadapters.OperationSet].Latency
+	suite.Require().Equal(statscacheadapters.LatencyBuckets()[0], latency.Buckets[0].UpperBound, "Should use the default upper bounds")
+}
+
+func (suite *StatsTestSuite) TestCollector_Concurrent() {
+	collector := statscacheadapters.NewCollector()
+
+	hit := func(ctx context.Context, op *interceptorcacheadapters.Operation) error {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				op := &interceptorcacheadapters.Operation{Name: interceptorcacheadapters.OperationGet}
+				collector.Intercept(context.Background(), op, hit)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := collector.Stats()
+	suite.Require().Equal(uint64(800), stats.Hits, "Should count every concurrent hit")
+	suite.Require().Equal(uint64(800), stats.Operations[interceptorcacheadapters.OperationGet].Calls, "Should count every concurrent call")
+	suite.Require().Equal(uint64(800), stats.Operations[interceptorcacheadapters.OperationGet].Latency.Count, "Should observe every concurrent latency")
+}
+
+func (suite *StatsTestSuite) TestPublish() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in_memory", suite.newInMemoryAdapter())
+
+	err := adapter.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	err = registry.Publish("cache_stats_test")
+	suite.Require().NoError(err, "Should publish the registry")
+
+	err = registry.Publish("cache_stats_test")
+	suite.Require().ErrorIs(err, statscacheadapters.ErrAlreadyPublished, "Should not publish twice with the same name")
+
+	var published map[string]statscacheadapters.Stats
+	err = json.Unmarshal([]byte(expvar.Get("cache_stats_test").String()), &published)
+	suite.Require().NoError(err, "Should publish the stats as JSON")
+	suite.Require().Equal(uint64(1), published["in_memory"].Sets, "Should publish the current stats")
+}
+
+func (suite *StatsTestSuite) TestPublish_Concurrent() {
+	var wg sync.WaitGroup
+	var published int32
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := statscacheadapters.NewRegistry().Publish("cache_stats_test_concurrent")
+			if err == nil {
+				atomic.AddInt32(&published, 1)
+				return
+			}
+
+			suite.Assert().ErrorIs(err, statscacheadapters.ErrAlreadyPublished, "Should not publish twice with the same name")
+		}()
+	}
+	wg.Wait()
+
+	suite.Require().Equal(int32(1), published, "Should publish the name once, without panicking")
+}
+
+func (suite *StatsTestSuite) TestHandler_Prometheus() {
+	registry := statscacheadapters.NewRegistry()
+	adapter, _ := registry.Wrap("in\"memory", suite.newInMemoryAdapter())
+
+	var actual testutil.TestStruct
+	err := adapter.Get(testutil.TestKeyForGet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should miss the key never set")
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	suite.Require().Equal("text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"), "Should use the Prometheus content type")
+	suite.Require().Contains(body, "# TYPE cache_misses_total counter\n", "Should describe the metrics")
+	suite.Require().Contains(body, "cache_misses_total{adapter=\"in\\\"memory\"} 1\n", "Should expose the counters, escaping the labels")
+	suite.Require().Contains(body, "cache_operations_total{adapter=\"in\\\"memory\",operation=\"Get\"} 1\n", "Should expose the operations")
+	suite.Require().Contains(body, "cache_operation_duration_seconds_bucket{adapter=\"in\\\"memory\",operation=\"Get\",le=\"+Inf\"} 1\n", "Should expose the latency histograms")
+	suite.Require().Contains(body, "cache_operation_duration_seconds_count{adapter=\"in\\\"memory\",operation=\"Get\"} 1\n", "Should expose the latency count")
+	suite.Require().True(strings.HasSuffix(body, "\n"), "Should end with a new line")
+}
@@ -15,6 +15,7 @@
 package testutil
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -68,6 +69,11 @@ type CacheAdapterPartialTestSuite struct {
 	//       }
 	//   }
 	NewSession func() (cacheadapters.CacheSessionAdapter, error)
+
+	// Whether the adapter stores the values without encoding
+	// them, so that it does not fail on values which cannot
+	// be encoded, like the in-memory one in an object mode.
+	StoresObjects bool
 }
 
 func (suite *CacheAdapterPartialTestSuite) TestNew_OK() {
@@ -148,6 +154,10 @@ func (suite *CacheAdapterPartialTestSuite) TestSet_OK_NilTTL() {
 }
 
 func (suite *CacheAdapterPartialTestSuite) TestSet_NonMarshalableReference() {
+	if suite.StoresObjects {
+		suite.T().Skip("the adapter does not encode the values")
+	}
+
 	adapter, _ := suite.NewAdapter()
 
 	actualNonMarshalable := complex128(1)
@@ -267,3 +277,665 @@ func (suite *CacheAdapterPartialTestSuite) TestOpenSession_OK() {
 	suite.Require().NoError(err, "Should not error on valid session opening")
 	defer session.Close()
 }
+
+func (suite *CacheAdapterPartialTestSuite) TestOpenSessionContext_Canceled() {
+	adapter, _ := suite.NewAdapter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := adapter.OpenSessionContext(ctx)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not open a session with a canceled context")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetGetContext_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := adapter.SetContext(ctx, TestKeyForSet, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set with a live context")
+
+	var actual TestStruct
+	err = adapter.GetContext(ctx, TestKeyForSet, &actual)
+	suite.Require().NoError(err, "Should not error on valid get with a live context")
+	suite.Require().Equal(TestValue, actual, "The value just set must be equal to the test value")
+
+	err = adapter.SetTTLContext(ctx, TestKeyForSet, time.Minute)
+	suite.Require().NoError(err, "Should not error on valid SetTTL with a live context")
+
+	err = adapter.DeleteContext(ctx, TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Delete with a live context")
+
+	err = adapter.GetContext(ctx, TestKeyForSet, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestOperationsContext_Canceled() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForDelete, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var actual TestStruct
+	err = adapter.GetContext(ctx, TestKeyForDelete, &actual)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Get with a canceled context")
+
+	err = adapter.SetContext(ctx, TestKeyForDelete, TestValue, nil)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Set with a canceled context")
+
+	err = adapter.SetTTLContext(ctx, TestKeyForDelete, time.Second)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not SetTTL with a canceled context")
+
+	err = adapter.DeleteContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Delete with a canceled context")
+
+	_, err = adapter.ExistsContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not check Exists with a canceled context")
+
+	_, err = adapter.TTLContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not obtain the TTL with a canceled context")
+
+	err = adapter.Get(TestKeyForDelete, &actual)
+	suite.Require().NoError(err, "The value should still be there since the Delete was canceled")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetManyGetMany_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	firstKey := fmt.Sprintf("%s:1", TestKeyForMany)
+	secondKey := fmt.Sprintf("%s:2", TestKeyForMany)
+	missingKey := fmt.Sprintf("%s:but-invalid", TestKeyForMany)
+
+	result, err := adapter.SetMany(map[string]interface{}{
+		firstKey:  TestValue,
+		secondKey: TestValue,
+	}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+	suite.Require().NoError(result[firstKey], "Should set the first key")
+	suite.Require().NoError(result[secondKey], "Should set the second key")
+
+	var firstActual, secondActual, missingActual TestStruct
+	result, err = adapter.GetMany(map[string]interface{}{
+		firstKey:   &firstActual,
+		secondKey:  &secondActual,
+		missingKey: &missingActual,
+	})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().Len(result, 3, "Should contain a result for every key")
+	suite.Require().NoError(result[firstKey], "Should find the first key")
+	suite.Require().NoError(result[secondKey], "Should find the second key")
+	suite.Require().ErrorIs(result[missingKey], cacheadapters.ErrNotFound, "Should not find the missing key")
+	suite.Require().Equal(TestValue, firstActual, "The first value must be equal to the test value")
+	suite.Require().Equal(TestValue, secondActual, "The second value must be equal to the test value")
+	suite.Require().Equal(TestStruct{}, missingActual, "The missing value must remain empty")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestGetMany_NilReference() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForMany, TestValue, nil)
+	suite.Require().NoError(err, "Should perform the Set in order to test the GetMany")
+
+	result, err := adapter.GetMany(map[string]interface{}{TestKeyForMany: nil})
+	suite.Require().NoError(err, "Should not error on the whole GetMany")
+	suite.Require().ErrorIs(result[TestKeyForMany], cacheadapters.ErrGetRequiresObjectReference, "Should return ErrGetRequiresObjectReference on nil object reference")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetMany_InvalidTTL() {
+	adapter, _ := suite.NewAdapter()
+
+	invalidDuration := -time.Second
+
+	_, err := adapter.SetMany(map[string]interface{}{TestKeyForMany: TestValue}, &invalidDuration)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should error on SetMany with invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetMany_NonMarshalableReference() {
+	if suite.StoresObjects {
+		suite.T().Skip("the adapter does not encode the values")
+	}
+
+	adapter, _ := suite.NewAdapter()
+
+	validKey := fmt.Sprintf("%s:valid", TestKeyForMany)
+	invalidKey := fmt.Sprintf("%s:but-invalid", TestKeyForMany)
+
+	result, err := adapter.SetMany(map[string]interface{}{
+		validKey:   TestValue,
+		invalidKey: complex128(1),
+	}, nil)
+	suite.Require().NoError(err, "Should not error on the whole SetMany")
+	suite.Require().NoError(result[validKey], "Should set the valid key")
+	suite.Require().Error(result[invalidKey], "Should not set the non marshalable value")
+
+	var actual TestStruct
+	err = adapter.Get(validKey, &actual)
+	suite.Require().NoError(err, "Should find the valid key")
+	suite.Require().Equal(TestValue, actual, "The value must be equal to the test value")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestDeleteMany_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	firstKey := fmt.Sprintf("%s:1", TestKeyForMany)
+	secondKey := fmt.Sprintf("%s:2", TestKeyForMany)
+
+	_, err := adapter.SetMany(map[string]interface{}{
+		firstKey:  TestValue,
+		secondKey: TestValue,
+	}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+
+	result, err := adapter.DeleteMany([]string{firstKey, secondKey})
+	suite.Require().NoError(err, "Should not error on valid DeleteMany")
+	suite.Require().NoError(result[firstKey], "Should delete the first key")
+	suite.Require().NoError(result[secondKey], "Should delete the second key")
+
+	var actual TestStruct
+	err = adapter.Get(firstKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after deleted")
+
+	err = adapter.Get(secondKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestExists_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForExists, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	exists, err := adapter.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+	suite.Require().True(exists, "Should exist after being set")
+
+	err = adapter.Delete(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	exists, err = adapter.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on Exists of a missing key")
+	suite.Require().False(exists, "Should not exist after being deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestExists_Expired() {
+	adapter, _ := suite.NewAdapter()
+
+	duration := 100 * time.Millisecond
+
+	err := adapter.Set(TestKeyForExists, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	exists, err := adapter.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on Exists of an expired key")
+	suite.Require().False(exists, "Should not exist after being expired")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestTTL_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForExists, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	remainingTTL, err := adapter.TTL(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid TTL")
+	suite.Require().Greater(remainingTTL, time.Duration(0), "The remaining TTL must be positive")
+	suite.Require().LessOrEqual(remainingTTL, DummyTTL, "The remaining TTL must not exceed the one set")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestTTL_NotFound() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	_, err = adapter.TTL(TestKeyForExists)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not find the TTL of a missing key")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestIncrementDecrement_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForCounter)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	counter, err := adapter.Increment(TestKeyForCounter, 5, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(5), counter, "A new counter must start from zero")
+
+	counter, err = adapter.Increment(TestKeyForCounter, 2, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(7), counter, "The counter must be incremented")
+
+	counter, err = adapter.Decrement(TestKeyForCounter, 10, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Decrement")
+	suite.Require().Equal(int64(-3), counter, "The counter must be decremented")
+
+	var actual int64
+	err = adapter.Get(TestKeyForCounter, &actual)
+	suite.Require().NoError(err, "Should not error on Get of a counter")
+	suite.Require().Equal(int64(-3), actual, "The counter must be readable with Get")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestIncrement_KeepsTTL() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForCounter)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	duration := 200 * time.Millisecond
+
+	_, err = adapter.Increment(TestKeyForCounter, 1, &duration)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+
+	suite.SleepFunc(100 * time.Millisecond)
+
+	counter, err := adapter.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(2), counter, "The counter must be incremented")
+
+	suite.SleepFunc(150 * time.Millisecond)
+
+	counter, err = adapter.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(1), counter, "The counter must restart after the first TTL expired")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestIncrement_InvalidCounter() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForCounter, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	_, err = adapter.Increment(TestKeyForCounter, 1, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidCounter, "Should not increment a non-integer value")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestIncrement_InvalidTTL() {
+	adapter, _ := suite.NewAdapter()
+
+	_, err := adapter.Increment(TestKeyForCounter, 1, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not increment with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetIfAbsent_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForSetIf)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	written, err := adapter.SetIfAbsent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().True(written, "Should write a missing key")
+
+	written, err = adapter.SetIfAbsent(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().False(written, "Should not overwrite an existing key")
+
+	var actual TestStruct
+	err = adapter.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The first written value must win")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetIfAbsent_Expired() {
+	adapter, _ := suite.NewAdapter()
+
+	duration := 100 * time.Millisecond
+
+	err := adapter.Set(TestKeyForSetIf, TestStruct{"2"}, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	written, err := adapter.SetIfAbsent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().True(written, "Should treat an expired key as absent")
+
+	var actual TestStruct
+	err = adapter.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The value must be the new one")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetIfPresent_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForSetIf)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	written, err := adapter.SetIfPresent(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().False(written, "Should not write a missing key")
+
+	var actual TestStruct
+	err = adapter.Get(TestKeyForSetIf, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found since it was not written")
+
+	err = adapter.Set(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	written, err = adapter.SetIfPresent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().True(written, "Should overwrite an existing key")
+
+	err = adapter.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The value must be the replaced one")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetIfPresent_Expired() {
+	adapter, _ := suite.NewAdapter()
+
+	duration := 100 * time.Millisecond
+
+	err := adapter.Set(TestKeyForSetIf, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	written, err := adapter.SetIfPresent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().False(written, "Should treat an expired key as absent")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetIf_InvalidTTL() {
+	adapter, _ := suite.NewAdapter()
+
+	_, err := adapter.SetIfAbsent(TestKeyForSetIf, TestValue, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetIfAbsent with an invalid TTL")
+
+	_, err = adapter.SetIfPresent(TestKeyForSetIf, TestValue, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetIfPresent with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestCompareAndSwap_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual TestStruct
+	version, err := adapter.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+	suite.Require().Equal(TestStruct{"2"}, actual, "The value must be the one just set")
+
+	err = adapter.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on CompareAndSwap with the current version")
+
+	newVersion, err := adapter.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+	suite.Require().Equal(TestValue, actual, "The value must be the swapped one")
+	suite.Require().NotEqual(version, newVersion, "The version must change after a swap")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestCompareAndSwap_Conflict() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual TestStruct
+	version, err := adapter.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	err = adapter.Set(TestKeyForVersion, TestStruct{"3"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = adapter.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a value modified in the meantime")
+
+	err = adapter.Get(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestStruct{"3"}, actual, "The concurrent write must not be lost")
+
+	err = adapter.Delete(TestKeyForVersion)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	err = adapter.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a deleted value")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestCompareAndSwap_SameValueWrittenAgain() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual TestStruct
+	version, err := adapter.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	err = adapter.Set(TestKeyForVersion, TestStruct{"3"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = adapter.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = adapter.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a value written again with the same content")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestGetWithVersion_NotFound() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Delete(TestKeyForVersion)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	var actual TestStruct
+	_, err = adapter.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetWithTagsInvalidateTag_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	taggedKey := fmt.Sprintf("%s:tagged", TestKeyForTags)
+	otherTaggedKey := fmt.Sprintf("%s:other-tagged", TestKeyForTags)
+	untaggedKey := fmt.Sprintf("%s:untagged", TestKeyForTags)
+
+	err := adapter.SetWithTags(taggedKey, TestValue, &DummyTTL, TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.SetWithTags(otherTaggedKey, TestValue, &DummyTTL, "another:tag", TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.SetWithTags(untaggedKey, TestValue, &DummyTTL, "another:tag")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = adapter.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+
+	var actual TestStruct
+	err = adapter.Get(taggedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its tag is invalidated")
+
+	err = adapter.Get(otherTaggedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after one of its tags is invalidated")
+
+	err = adapter.Get(untaggedKey, &actual)
+	suite.Require().NoError(err, "Should be found since it does not carry the invalidated tag")
+
+	err = adapter.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on InvalidateTag of an unused tag")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSetWithTags_InvalidTTL() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.SetWithTags(TestKeyForTags, TestValue, &InvalidTTL, TestTag)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetWithTags with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestScan_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	prefix := TestKeyForScan + "ok:"
+	keys := []string{
+		prefix + "first",
+		prefix + "second",
+		prefix + "nested:third",
+	}
+
+	for _, key := range keys {
+		err := adapter.SetWithTags(key, TestValue, &DummyTTL, TestKeyForScan+"tag")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err := adapter.Set(TestKeyForSet, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	iterator, err := adapter.Scan(prefix)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+
+	suite.Require().ElementsMatch(keys, collectKeys(iterator), "Should return only the keys starting with the prefix")
+	suite.Require().NoError(iterator.Err(), "Should not error after a valid iteration")
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+
+	iterator, err = adapter.Scan(prefix + "nested:")
+	suite.Require().NoError(err, "Should not error on valid Scan")
+
+	suite.Require().ElementsMatch(keys[2:], collectKeys(iterator), "Should return only the keys starting with the longer prefix")
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+
+	iterator, err = adapter.Scan("")
+	suite.Require().NoError(err, "Should not error on Scan of all the keys")
+
+	allKeys := collectKeys(iterator)
+	suite.Require().Subset(allKeys, append(keys, TestKeyForSet), "Should return all the keys with an empty prefix")
+	for _, key := range allKeys {
+		suite.Require().NotContains(key, TestKeyForScan+"tag", "Should not return the internal keys of the tags")
+	}
+
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestScan_SpecialCharacters() {
+	adapter, _ := suite.NewAdapter()
+
+	specialKey := TestKeyForScan + "special*[?]\\.+"
+	otherKey := TestKeyForScan + "special-other"
+
+	err := adapter.Set(specialKey, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = adapter.Set(otherKey, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	iterator, err := adapter.Scan(specialKey)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	suite.Require().Equal([]string{specialKey}, collectKeys(iterator), "Should match the prefix literally")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestScan_Expired() {
+	adapter, _ := suite.NewAdapter()
+
+	expiredKey := TestKeyForScan + "expired"
+	duration := 100 * time.Millisecond
+
+	err := adapter.Set(expiredKey, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	iterator, err := adapter.Scan(expiredKey)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	suite.Require().Empty(collectKeys(iterator), "Should not return the expired keys")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestScanContext_Canceled() {
+	adapter, _ := suite.NewAdapter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := adapter.ScanContext(ctx, TestKeyForScan)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Scan with a canceled context")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestClearPrefix_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	clearedKey := TestKeyForClear + "cleared:1"
+	otherClearedKey := TestKeyForClear + "cleared:2"
+	keptKey := TestKeyForClear + "kept"
+
+	for _, key := range []string{clearedKey, otherClearedKey, keptKey} {
+		err := adapter.SetWithTags(key, TestValue, &DummyTTL, TestTag)
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err := adapter.ClearPrefix(TestKeyForClear + "cleared:")
+	suite.Require().NoError(err, "Should not error on valid ClearPrefix")
+
+	var actual TestStruct
+	err = adapter.Get(clearedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its prefix is cleared")
+
+	err = adapter.Get(otherClearedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its prefix is cleared")
+
+	err = adapter.Get(keptKey, &actual)
+	suite.Require().NoError(err, "Should be found since it does not start with the cleared prefix")
+
+	err = adapter.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on InvalidateTag of cleared keys")
+
+	err = adapter.ClearPrefix(TestKeyForClear + "nothing:")
+	suite.Require().NoError(err, "Should not error on ClearPrefix without matching keys")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestClear_OK() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.SetWithTags(TestKeyForClear, TestValue, &DummyTTL, TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	_, err = adapter.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+
+	err = adapter.Clear()
+	suite.Require().NoError(err, "Should not error on valid Clear")
+
+	iterator, err := adapter.Scan("")
+	suite.Require().NoError(err, "Should not error on Scan after Clear")
+	defer iterator.Close()
+
+	suite.Require().Empty(collectKeys(iterator), "Should not find any key after Clear")
+
+	err = adapter.Set(TestKeyForClear, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should be usable after Clear")
+
+	err = adapter.Clear()
+	suite.Require().NoError(err, "Should not error on Clear of a cache")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestClearContext_Canceled() {
+	adapter, _ := suite.NewAdapter()
+
+	err := adapter.Set(TestKeyForClear, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = adapter.ClearContext(ctx)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Clear with a canceled context")
+
+	err = adapter.ClearPrefixContext(ctx, TestKeyForClear)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not ClearPrefix with a canceled context")
+
+	exists, err := adapter.Exists(TestKeyForClear)
+	suite.Require().NoError(err, "Should not error on Exists")
+	suite.Require().True(exists, "The value should still be there since the Clear was canceled")
+}
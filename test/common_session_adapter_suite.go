@@ -15,6 +15,7 @@
 package testutil
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -105,6 +106,10 @@ func (suite *CacheAdapterPartialTestSuite) TestSessionSet_OK_NilTTL() {
 }
 
 func (suite *CacheAdapterPartialTestSuite) TestSessionSet_NonMarshalableReference() {
+	if suite.StoresObjects {
+		suite.T().Skip("the adapter does not encode the values")
+	}
+
 	session, _ := suite.NewSession()
 	defer session.Close()
 
@@ -231,3 +236,587 @@ func (suite *CacheAdapterPartialTestSuite) TestSessionClose_OK() {
 
 	suite.Require().NoError(err, "Should not error on valid session opening")
 }
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionOperationsContext_Canceled() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForDelete, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var actual TestStruct
+	err = session.GetContext(ctx, TestKeyForDelete, &actual)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Get with a canceled context")
+
+	err = session.SetContext(ctx, TestKeyForDelete, TestValue, nil)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Set with a canceled context")
+
+	err = session.SetTTLContext(ctx, TestKeyForDelete, time.Second)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not SetTTL with a canceled context")
+
+	err = session.DeleteContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Delete with a canceled context")
+
+	_, err = session.ExistsContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not check Exists with a canceled context")
+
+	_, err = session.TTLContext(ctx, TestKeyForDelete)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not obtain the TTL with a canceled context")
+
+	err = session.Get(TestKeyForDelete, &actual)
+	suite.Require().NoError(err, "The value should still be there since the Delete was canceled")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetManyGetManyDeleteMany_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	firstKey := fmt.Sprintf("%s:1", TestKeyForMany)
+	secondKey := fmt.Sprintf("%s:2", TestKeyForMany)
+
+	result, err := session.SetMany(map[string]interface{}{
+		firstKey:  TestValue,
+		secondKey: TestValue,
+	}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+	suite.Require().NoError(result[firstKey], "Should set the first key")
+	suite.Require().NoError(result[secondKey], "Should set the second key")
+
+	var firstActual, secondActual TestStruct
+	result, err = session.GetMany(map[string]interface{}{
+		firstKey:  &firstActual,
+		secondKey: &secondActual,
+	})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().NoError(result[firstKey], "Should find the first key")
+	suite.Require().NoError(result[secondKey], "Should find the second key")
+	suite.Require().Equal(TestValue, firstActual, "The first value must be equal to the test value")
+	suite.Require().Equal(TestValue, secondActual, "The second value must be equal to the test value")
+
+	_, err = session.DeleteMany([]string{firstKey, secondKey})
+	suite.Require().NoError(err, "Should not error on valid DeleteMany")
+
+	result, err = session.GetMany(map[string]interface{}{
+		firstKey:  &firstActual,
+		secondKey: &secondActual,
+	})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().ErrorIs(result[firstKey], cacheadapters.ErrNotFound, "Should not be found after deleted")
+	suite.Require().ErrorIs(result[secondKey], cacheadapters.ErrNotFound, "Should not be found after deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionGetManyContext_Canceled() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var actual TestStruct
+	_, err := session.GetManyContext(ctx, map[string]interface{}{TestKeyForMany: &actual})
+	suite.Require().ErrorIs(err, context.Canceled, "Should not GetMany with a canceled context")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionExists_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForExists, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	exists, err := session.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid Exists")
+	suite.Require().True(exists, "Should exist after being set")
+
+	err = session.Delete(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	exists, err = session.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on Exists of a missing key")
+	suite.Require().False(exists, "Should not exist after being deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionExists_Expired() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	duration := 100 * time.Millisecond
+
+	err := session.Set(TestKeyForExists, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	exists, err := session.Exists(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on Exists of an expired key")
+	suite.Require().False(exists, "Should not exist after being expired")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionTTL_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForExists, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	remainingTTL, err := session.TTL(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid TTL")
+	suite.Require().Greater(remainingTTL, time.Duration(0), "The remaining TTL must be positive")
+	suite.Require().LessOrEqual(remainingTTL, DummyTTL, "The remaining TTL must not exceed the one set")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionTTL_NotFound() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForExists)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	_, err = session.TTL(TestKeyForExists)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not find the TTL of a missing key")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionIncrementDecrement_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForCounter)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	counter, err := session.Increment(TestKeyForCounter, 5, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(5), counter, "A new counter must start from zero")
+
+	counter, err = session.Increment(TestKeyForCounter, 2, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(7), counter, "The counter must be incremented")
+
+	counter, err = session.Decrement(TestKeyForCounter, 10, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Decrement")
+	suite.Require().Equal(int64(-3), counter, "The counter must be decremented")
+
+	var actual int64
+	err = session.Get(TestKeyForCounter, &actual)
+	suite.Require().NoError(err, "Should not error on Get of a counter")
+	suite.Require().Equal(int64(-3), actual, "The counter must be readable with Get")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionIncrement_KeepsTTL() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForCounter)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	duration := 200 * time.Millisecond
+
+	_, err = session.Increment(TestKeyForCounter, 1, &duration)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+
+	suite.SleepFunc(100 * time.Millisecond)
+
+	counter, err := session.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(2), counter, "The counter must be incremented")
+
+	suite.SleepFunc(150 * time.Millisecond)
+
+	counter, err = session.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+	suite.Require().Equal(int64(1), counter, "The counter must restart after the first TTL expired")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionIncrement_InvalidCounter() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForCounter, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	_, err = session.Increment(TestKeyForCounter, 1, nil)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidCounter, "Should not increment a non-integer value")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionIncrement_InvalidTTL() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	_, err := session.Increment(TestKeyForCounter, 1, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not increment with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetIfAbsent_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForSetIf)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	written, err := session.SetIfAbsent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().True(written, "Should write a missing key")
+
+	written, err = session.SetIfAbsent(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().False(written, "Should not overwrite an existing key")
+
+	var actual TestStruct
+	err = session.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The first written value must win")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetIfAbsent_Expired() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	duration := 100 * time.Millisecond
+
+	err := session.Set(TestKeyForSetIf, TestStruct{"2"}, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	written, err := session.SetIfAbsent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfAbsent")
+	suite.Require().True(written, "Should treat an expired key as absent")
+
+	var actual TestStruct
+	err = session.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The value must be the new one")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetIfPresent_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForSetIf)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	written, err := session.SetIfPresent(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().False(written, "Should not write a missing key")
+
+	var actual TestStruct
+	err = session.Get(TestKeyForSetIf, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found since it was not written")
+
+	err = session.Set(TestKeyForSetIf, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	written, err = session.SetIfPresent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().True(written, "Should overwrite an existing key")
+
+	err = session.Get(TestKeyForSetIf, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestValue, actual, "The value must be the replaced one")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetIfPresent_Expired() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	duration := 100 * time.Millisecond
+
+	err := session.Set(TestKeyForSetIf, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	written, err := session.SetIfPresent(TestKeyForSetIf, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid SetIfPresent")
+	suite.Require().False(written, "Should treat an expired key as absent")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetIf_InvalidTTL() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	_, err := session.SetIfAbsent(TestKeyForSetIf, TestValue, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetIfAbsent with an invalid TTL")
+
+	_, err = session.SetIfPresent(TestKeyForSetIf, TestValue, &InvalidTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetIfPresent with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionCompareAndSwap_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual TestStruct
+	version, err := session.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+	suite.Require().Equal(TestStruct{"2"}, actual, "The value must be the one just set")
+
+	err = session.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on CompareAndSwap with the current version")
+
+	newVersion, err := session.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+	suite.Require().Equal(TestValue, actual, "The value must be the swapped one")
+	suite.Require().NotEqual(version, newVersion, "The version must change after a swap")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionCompareAndSwap_Conflict() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForVersion, TestStruct{"2"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	var actual TestStruct
+	version, err := session.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid GetWithVersion")
+
+	err = session.Set(TestKeyForVersion, TestStruct{"3"}, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = session.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a value modified in the meantime")
+
+	err = session.Get(TestKeyForVersion, &actual)
+	suite.Require().NoError(err, "Should not error on valid get")
+	suite.Require().Equal(TestStruct{"3"}, actual, "The concurrent write must not be lost")
+
+	err = session.Delete(TestKeyForVersion)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	err = session.CompareAndSwap(TestKeyForVersion, version, TestValue, &DummyTTL)
+	suite.Require().ErrorIs(err, cacheadapters.ErrVersionConflict, "Should not swap a deleted value")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionGetWithVersion_NotFound() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Delete(TestKeyForVersion)
+	suite.Require().NoError(err, "Should not error on valid delete")
+
+	var actual TestStruct
+	_, err = session.GetWithVersion(TestKeyForVersion, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after deleted")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetWithTagsInvalidateTag_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	taggedKey := fmt.Sprintf("%s:tagged", TestKeyForTags)
+	otherTaggedKey := fmt.Sprintf("%s:other-tagged", TestKeyForTags)
+	untaggedKey := fmt.Sprintf("%s:untagged", TestKeyForTags)
+
+	err := session.SetWithTags(taggedKey, TestValue, &DummyTTL, TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = session.SetWithTags(otherTaggedKey, TestValue, &DummyTTL, "another:tag", TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = session.SetWithTags(untaggedKey, TestValue, &DummyTTL, "another:tag")
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	err = session.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on valid InvalidateTag")
+
+	var actual TestStruct
+	err = session.Get(taggedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its tag is invalidated")
+
+	err = session.Get(otherTaggedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after one of its tags is invalidated")
+
+	err = session.Get(untaggedKey, &actual)
+	suite.Require().NoError(err, "Should be found since it does not carry the invalidated tag")
+
+	err = session.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on InvalidateTag of an unused tag")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionSetWithTags_InvalidTTL() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.SetWithTags(TestKeyForTags, TestValue, &InvalidTTL, TestTag)
+	suite.Require().ErrorIs(err, cacheadapters.ErrInvalidTTL, "Should not SetWithTags with an invalid TTL")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionScan_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	prefix := TestKeyForScan + "ok:"
+	keys := []string{
+		prefix + "first",
+		prefix + "second",
+		prefix + "nested:third",
+	}
+
+	for _, key := range keys {
+		err := session.SetWithTags(key, TestValue, &DummyTTL, TestKeyForScan+"tag")
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err := session.Set(TestKeyForSet, TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	iterator, err := session.Scan(prefix)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+
+	suite.Require().ElementsMatch(keys, collectKeys(iterator), "Should return only the keys starting with the prefix")
+	suite.Require().NoError(iterator.Err(), "Should not error after a valid iteration")
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+
+	iterator, err = session.Scan(prefix + "nested:")
+	suite.Require().NoError(err, "Should not error on valid Scan")
+
+	suite.Require().ElementsMatch(keys[2:], collectKeys(iterator), "Should return only the keys starting with the longer prefix")
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+
+	iterator, err = session.Scan("")
+	suite.Require().NoError(err, "Should not error on Scan of all the keys")
+
+	allKeys := collectKeys(iterator)
+	suite.Require().Subset(allKeys, append(keys, TestKeyForSet), "Should return all the keys with an empty prefix")
+	for _, key := range allKeys {
+		suite.Require().NotContains(key, TestKeyForScan+"tag", "Should not return the internal keys of the tags")
+	}
+
+	suite.Require().NoError(iterator.Close(), "Should not error on Close")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionScan_SpecialCharacters() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	specialKey := TestKeyForScan + "special*[?]\\.+"
+	otherKey := TestKeyForScan + "special-other"
+
+	err := session.Set(specialKey, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	err = session.Set(otherKey, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	iterator, err := session.Scan(specialKey)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	suite.Require().Equal([]string{specialKey}, collectKeys(iterator), "Should match the prefix literally")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionScan_Expired() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	expiredKey := TestKeyForScan + "expired"
+	duration := 100 * time.Millisecond
+
+	err := session.Set(expiredKey, TestValue, &duration)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	suite.SleepFunc(200 * time.Millisecond)
+
+	iterator, err := session.Scan(expiredKey)
+	suite.Require().NoError(err, "Should not error on valid Scan")
+	defer iterator.Close()
+
+	suite.Require().Empty(collectKeys(iterator), "Should not return the expired keys")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionScanContext_Canceled() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := session.ScanContext(ctx, TestKeyForScan)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Scan with a canceled context")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionClearPrefix_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	clearedKey := TestKeyForClear + "cleared:1"
+	otherClearedKey := TestKeyForClear + "cleared:2"
+	keptKey := TestKeyForClear + "kept"
+
+	for _, key := range []string{clearedKey, otherClearedKey, keptKey} {
+		err := session.SetWithTags(key, TestValue, &DummyTTL, TestTag)
+		suite.Require().NoError(err, "Should not error on valid SetWithTags")
+	}
+
+	err := session.ClearPrefix(TestKeyForClear + "cleared:")
+	suite.Require().NoError(err, "Should not error on valid ClearPrefix")
+
+	var actual TestStruct
+	err = session.Get(clearedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its prefix is cleared")
+
+	err = session.Get(otherClearedKey, &actual)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not be found after its prefix is cleared")
+
+	err = session.Get(keptKey, &actual)
+	suite.Require().NoError(err, "Should be found since it does not start with the cleared prefix")
+
+	err = session.InvalidateTag(TestTag)
+	suite.Require().NoError(err, "Should not error on InvalidateTag of cleared keys")
+
+	err = session.ClearPrefix(TestKeyForClear + "nothing:")
+	suite.Require().NoError(err, "Should not error on ClearPrefix without matching keys")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionClear_OK() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.SetWithTags(TestKeyForClear, TestValue, &DummyTTL, TestTag)
+	suite.Require().NoError(err, "Should not error on valid SetWithTags")
+
+	_, err = session.Increment(TestKeyForCounter, 1, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid Increment")
+
+	err = session.Clear()
+	suite.Require().NoError(err, "Should not error on valid Clear")
+
+	iterator, err := session.Scan("")
+	suite.Require().NoError(err, "Should not error on Scan after Clear")
+	defer iterator.Close()
+
+	suite.Require().Empty(collectKeys(iterator), "Should not find any key after Clear")
+
+	err = session.Set(TestKeyForClear, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should be usable after Clear")
+
+	err = session.Clear()
+	suite.Require().NoError(err, "Should not error on Clear of a cache")
+}
+
+func (suite *CacheAdapterPartialTestSuite) TestSessionClearContext_Canceled() {
+	session, _ := suite.NewSession()
+	defer session.Close()
+
+	err := session.Set(TestKeyForClear, TestValue, &DummyTTL)
+	suite.Require().NoError(err, "Should not error on valid set")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = session.ClearContext(ctx)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not Clear with a canceled context")
+
+	err = session.ClearPrefixContext(ctx, TestKeyForClear)
+	suite.Require().ErrorIs(err, context.Canceled, "Should not ClearPrefix with a canceled context")
+
+	exists, err := session.Exists(TestKeyForClear)
+	suite.Require().NoError(err, "Should not error on Exists")
+	suite.Require().True(exists, "The value should still be there since the Clear was canceled")
+}
@@ -1,16 +1,32 @@
 package testutil
 
-import "time"
+import (
+	"time"
+
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+)
 
 var (
-	TestKeyForGet    = "test:key:for-get:1234"     // The test key used to test the Get operations
-	TestKeyForSet    = "test:key:for-set:1234"     // The test key used to test the Set operations
-	TestKeyForSetTTL = "test:key:for-set-ttl:1234" // The test key used to test the SetTTL operations
-	TestKeyForDelete = "test:key:for-delete:1234"  // The test key used to test the Delete operations
-	TestValue        = TestStruct{"1"}             // The test value being Set
-	TestValueJSON    = []byte(`{"value":"1"}`)     // The Test value as JSON string
+	TestKeyForGet     = "test:key:for-get:1234"     // The test key used to test the Get operations
+	TestKeyForSet     = "test:key:for-set:1234"     // The test key used to test the Set operations
+	TestKeyForSetTTL  = "test:key:for-set-ttl:1234" // The test key used to test the SetTTL operations
+	TestKeyForDelete  = "test:key:for-delete:1234"  // The test key used to test the Delete operations
+	TestKeyForMany    = "test:key:for-many:1234"    // The test key prefix used to test the batch operations
+	TestKeyForExists  = "test:key:for-exists:1234"  // The test key used to test the Exists and TTL operations
+	TestKeyForCounter = "test:key:for-counter:1234" // The test key used to test the Increment and Decrement operations
+	TestKeyForSetIf   = "test:key:for-set-if:1234"  // The test key used to test the conditional Set operations
+	TestKeyForVersion = "test:key:for-version:1234" // The test key used to test the versioned operations
+	TestKeyForTags    = "test:key:for-tags:1234"    // The test key prefix used to test the tag operations
+	TestKeyForScan    = "test:key:for-scan:1234:"   // The test key prefix used to test the Scan operations
+	TestKeyForClear   = "test:key:for-clear:1234:"  // The test key prefix used to test the Clear operations
+	TestTag           = "test:tag:1234"             // The test tag attached in the tag operations
+	TestValue         = TestStruct{"1"}             // The test value being Set
+	TestValueJSON     = []byte(`{"value":"1"}`)     // The Test value as JSON string
 )
 
+// TestValueRaw is the test value as encoded by the default codec.
+var TestValueRaw = cacheadapters.RawMessage(TestValueJSON)
+
 // TestStruct is just an example struct to check if the json
 // marchalling and unmarshalling are correct in all tests.
 type TestStruct struct {
@@ -29,3 +45,13 @@ var (
 	// needed.
 	ZeroTTL time.Duration = 0
 )
+
+// collectKeys returns all the keys of the iterator.
+func collectKeys(iterator cacheadapters.KeyIterator) []string {
+	keys := make([]string, 0)
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys
+}
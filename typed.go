@@ -0,0 +1,186 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters
+
+import (
+	"context"
+	"time"
+)
+
+// Typed wraps a CacheAdapter, or a CacheSessionAdapter, to operate
+// on values of type T without declaring and passing object references.
+//
+// Example of usage:
+//
+//	    users, _ := cacheadapters.NewTyped[User](adapter)
+//	    user, err := users.Get("user:1234")
+type Typed[T any] struct {
+	operator cacheOperator // The adapter, or session, used to access the cache.
+	loader   *Loader       // The loader used in the GetOrSet operations.
+}
+
+// NewTyped creates a new Typed cache of values of type T
+// on top of the given adapter.
+func NewTyped[T any](adapter CacheAdapter) (*Typed[T], error) {
+	if adapter == nil {
+		return nil, ErrInvalidTypedAdapter
+	}
+
+	return newTyped[T](adapter), nil
+}
+
+// NewTypedSession creates a new Typed cache of values of type T
+// on top of the given session.
+//
+// The session is not closed by the Typed cache.
+func NewTypedSession[T any](session CacheSessionAdapter) (*Typed[T], error) {
+	if session == nil {
+		return nil, ErrInvalidTypedAdapter
+	}
+
+	return newTyped[T](session), nil
+}
+
+// newTyped creates a new Typed cache on top of the operator.
+func newTyped[T any](operator cacheOperator) *Typed[T] {
+	return &Typed[T]{
+		operator: operator,
+		loader:   newLoader(operator),
+	}
+}
+
+// EnableNegativeResults makes the GetOrSet operations remember, for
+// the given TTL, the keys for which the loader function returned
+// ErrNotFound. See Loader.EnableNegativeResults.
+func (t *Typed[T]) EnableNegativeResults(TTL time.Duration) error {
+	return t.loader.EnableNegativeResults(TTL)
+}
+
+// DisableNegativeResults stops remembering negative results.
+func (t *Typed[T]) DisableNegativeResults() {
+	t.loader.DisableNegativeResults()
+}
+
+// UseCodec sets the codec used to copy the loaded values to the
+// callers of the GetOrSet operations. See Loader.UseCodec.
+func (t *Typed[T]) UseCodec(codec Codec) error {
+	return t.loader.UseCodec(codec)
+}
+
+// UseNegativeKeyPrefix sets the prefix of the keys used to remember
+// the negative results. See Loader.UseNegativeKeyPrefix.
+func (t *Typed[T]) UseNegativeKeyPrefix(prefix string) error {
+	return t.loader.UseNegativeKeyPrefix(prefix)
+}
+
+// Get obtains a value from the cache using a key.
+func (t *Typed[T]) Get(key string) (T, error) {
+	return t.GetContext(context.Background(), key)
+}
+
+// GetContext is the same as Get, but honors the cancellation
+// and the deadline of the given context.
+func (t *Typed[T]) GetContext(ctx context.Context, key string) (T, error) {
+	var value T
+
+	err := t.operator.GetContext(ctx, key, &value)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return value, nil
+}
+
+// Set sets a value into the cache, with the specified key.
+func (t *Typed[T]) Set(key string, value T, TTL *time.Duration) error {
+	return t.SetContext(context.Background(), key, value, TTL)
+}
+
+// SetContext is the same as Set, but honors the cancellation
+// and the deadline of the given context.
+func (t *Typed[T]) SetContext(ctx context.Context, key string, value T, TTL *time.Duration) error {
+	return t.operator.SetContext(ctx, key, value, TTL)
+}
+
+// GetMany obtains multiple values from the cache at once, returning
+// the values found and the outcome for every key.
+func (t *Typed[T]) GetMany(keys []string) (map[string]T, BatchResult, error) {
+	return t.GetManyContext(context.Background(), keys)
+}
+
+// GetManyContext is the same as GetMany, but honors the cancellation
+// and the deadline of the given context.
+func (t *Typed[T]) GetManyContext(ctx context.Context, keys []string) (map[string]T, BatchResult, error) {
+	objectRefs := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		objectRefs[key] = new(T)
+	}
+
+	result, err := t.operator.GetManyContext(ctx, objectRefs)
+	if err != nil {
+		return nil, result, err
+	}
+
+	values := make(map[string]T, len(keys))
+	for key, objectRef := range objectRefs {
+		if result[key] == nil {
+			values[key] = *objectRef.(*T)
+		}
+	}
+
+	return values, result, nil
+}
+
+// SetMany sets multiple values into the cache at once, with the same TTL.
+func (t *Typed[T]) SetMany(values map[string]T, TTL *time.Duration) (BatchResult, error) {
+	return t.SetManyContext(context.Background(), values, TTL)
+}
+
+// SetManyContext is the same as SetMany, but honors the cancellation
+// and the deadline of the given context.
+func (t *Typed[T]) SetManyContext(ctx context.Context, values map[string]T, TTL *time.Duration) (BatchResult, error) {
+	objects := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		objects[key] = value
+	}
+
+	return t.operator.SetManyContext(ctx, objects, TTL)
+}
+
+// GetOrSet obtains a value from the cache using a key or, if it is
+// not found, from the loader function, storing it in the cache with
+// the given TTL (or the default one if nil).
+//
+// Concurrent calls for the same key wait for a single call of the
+// loader function. If the loaded value cannot be stored, it is
+// returned along with the error. See Loader.GetOrSet.
+func (t *Typed[T]) GetOrSet(key string, TTL *time.Duration, loader func() (T, error)) (T, error) {
+	return t.GetOrSetContext(context.Background(), key, TTL, func(ctx context.Context) (T, error) {
+		return loader()
+	})
+}
+
+// GetOrSetContext is the same as GetOrSet, but honors the cancellation
+// and the deadline of the given context, and passes it to the loader.
+func (t *Typed[T]) GetOrSetContext(ctx context.Context, key string, TTL *time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+
+	err := t.loader.GetOrSetContext(ctx, key, &value, TTL, func(ctx context.Context) (interface{}, error) {
+		return loader(ctx)
+	})
+
+	return value, err
+}
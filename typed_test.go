@@ -0,0 +1,167 @@
+// Copyright 2023 Tryvium Travels LTD
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapters_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	cacheadapters "github.com/tryvium-travels/golang-cache-adapters"
+	inmemorycacheadapters "github.com/tryvium-travels/golang-cache-adapters/in_memory"
+	testutil "github.com/tryvium-travels/golang-cache-adapters/test"
+)
+
+func TestTypedSuite(t *testing.T) {
+	suite.Run(t, new(TypedTestSuite))
+}
+
+// TypedTestSuite contains all methods to run tests in a
+// isolated suite.
+type TypedTestSuite struct {
+	suite.Suite
+	adapter cacheadapters.CacheAdapter
+	typed   *cacheadapters.Typed[testutil.TestStruct]
+}
+
+func (suite *TypedTestSuite) SetupTest() {
+	suite.adapter, _ = inmemorycacheadapters.New(time.Second)
+	suite.typed, _ = cacheadapters.NewTyped[testutil.TestStruct](suite.adapter)
+}
+
+func (suite *TypedTestSuite) TestNewTyped_NilAdapter() {
+	typed, err := cacheadapters.NewTyped[testutil.TestStruct](nil)
+	suite.Nil(typed, "Should be nil if NewTyped is without adapter")
+	suite.ErrorIs(err, cacheadapters.ErrInvalidTypedAdapter, "Should give ErrInvalidTypedAdapter on NewTyped without adapter")
+
+	typedSession, err := cacheadapters.NewTypedSession[testutil.TestStruct](nil)
+	suite.Nil(typedSession, "Should be nil if NewTypedSession is without session")
+	suite.ErrorIs(err, cacheadapters.ErrInvalidTypedAdapter, "Should give ErrInvalidTypedAdapter on NewTypedSession without session")
+}
+
+func (suite *TypedTestSuite) TestSetGet_OK() {
+	err := suite.typed.Set(testutil.TestKeyForSet, testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	actual, err := suite.typed.Get(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the value set")
+
+	var untyped testutil.TestStruct
+	err = suite.adapter.Get(testutil.TestKeyForSet, &untyped)
+	suite.Require().NoError(err, "Should be readable from the wrapped adapter")
+	suite.Require().Equal(testutil.TestValue, untyped, "Should be equal to the value set")
+}
+
+func (suite *TypedTestSuite) TestGet_NotFound() {
+	actual, err := suite.typed.Get(testutil.TestKeyForGet)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should not find a missing key")
+	suite.Require().Zero(actual, "Should return the zero value on errors")
+}
+
+func (suite *TypedTestSuite) TestGet_WrongType() {
+	err := suite.adapter.Set(testutil.TestKeyForGet, "a plain string", nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	actual, err := suite.typed.Get(testutil.TestKeyForGet)
+	suite.Require().Error(err, "Should error on a value of another type")
+	suite.Require().Zero(actual, "Should return the zero value on errors")
+}
+
+func (suite *TypedTestSuite) TestSetManyGetMany_OK() {
+	firstKey := fmt.Sprintf("%s:1", testutil.TestKeyForMany)
+	secondKey := fmt.Sprintf("%s:2", testutil.TestKeyForMany)
+	missingKey := fmt.Sprintf("%s:but-invalid", testutil.TestKeyForMany)
+
+	result, err := suite.typed.SetMany(map[string]testutil.TestStruct{
+		firstKey:  testutil.TestValue,
+		secondKey: {Value: "2"},
+	}, nil)
+	suite.Require().NoError(err, "Should not error on valid SetMany")
+	suite.Require().NoError(result[firstKey], "Should set the first key")
+	suite.Require().NoError(result[secondKey], "Should set the second key")
+
+	values, result, err := suite.typed.GetMany([]string{firstKey, secondKey, missingKey})
+	suite.Require().NoError(err, "Should not error on valid GetMany")
+	suite.Require().Len(result, 3, "Should contain a result for every key")
+	suite.Require().ErrorIs(result[missingKey], cacheadapters.ErrNotFound, "Should not find the missing key")
+	suite.Require().Equal(map[string]testutil.TestStruct{
+		firstKey:  testutil.TestValue,
+		secondKey: {Value: "2"},
+	}, values, "Should contain only the values found")
+}
+
+func (suite *TypedTestSuite) TestGetOrSet_OK() {
+	calls := 0
+	loader := func() (testutil.TestStruct, error) {
+		calls++
+		return testutil.TestValue, nil
+	}
+
+	actual, err := suite.typed.GetOrSet(testutil.TestKeyForGet, nil, loader)
+	suite.Require().NoError(err, "Should not error on valid GetOrSet")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the loaded value")
+
+	actual, err = suite.typed.GetOrSet(testutil.TestKeyForGet, nil, loader)
+	suite.Require().NoError(err, "Should not error on valid GetOrSet")
+	suite.Require().Equal(testutil.TestValue, actual, "Should be equal to the cached value")
+	suite.Require().Equal(1, calls, "Should call the loader only on the miss")
+}
+
+func (suite *TypedTestSuite) TestGetOrSet_NegativeResults() {
+	err := suite.typed.EnableNegativeResults(time.Second)
+	suite.Require().NoError(err, "Should not error on valid TTL")
+
+	calls := 0
+	loader := func() (testutil.TestStruct, error) {
+		calls++
+		return testutil.TestStruct{}, cacheadapters.ErrNotFound
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = suite.typed.GetOrSet(testutil.TestKeyForGet, nil, loader)
+		suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should return the error of the loader")
+	}
+	suite.Require().Equal(1, calls, "Should remember the negative result")
+
+	suite.typed.DisableNegativeResults()
+
+	_, err = suite.typed.GetOrSet(testutil.TestKeyForGet, nil, loader)
+	suite.Require().ErrorIs(err, cacheadapters.ErrNotFound, "Should return the error of the loader")
+	suite.Require().Equal(2, calls, "Should call the loader when negative results are disabled")
+}
+
+func (suite *TypedTestSuite) TestTypedSession_OK() {
+	session, err := suite.adapter.OpenSession()
+	suite.Require().NoError(err, "Should open a session")
+	defer session.Close()
+
+	typed, err := cacheadapters.NewTypedSession[*testutil.TestStruct](session)
+	suite.Require().NoError(err, "Should not error on valid session")
+
+	err = typed.Set(testutil.TestKeyForSet, &testutil.TestValue, nil)
+	suite.Require().NoError(err, "Should not error on valid Set")
+
+	actual, err := typed.Get(testutil.TestKeyForSet)
+	suite.Require().NoError(err, "Should not error on valid Get")
+	suite.Require().Equal(&testutil.TestValue, actual, "Should be equal to the value set")
+
+	loaded, err := typed.GetOrSet(testutil.TestKeyForGet, nil, func() (*testutil.TestStruct, error) {
+		return &testutil.TestValue, nil
+	})
+	suite.Require().NoError(err, "Should not error on valid GetOrSet")
+	suite.Require().Equal(&testutil.TestValue, loaded, "Should be equal to the loaded value")
+}